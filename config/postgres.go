@@ -3,8 +3,10 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/google/uuid"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -38,13 +40,38 @@ func runMigrations(db *gorm.DB) error {
 		&schemas.User{},
 		&schemas.UserConfig{},
 		&schemas.Category{},
+		&schemas.Account{},
+		&schemas.Merchant{},
 		&schemas.Expense{},
+		&schemas.CategoryRule{},
 		&schemas.ExpenseItem{},
+		&schemas.ExpenseAllocation{},
 		&schemas.Receipt{},
+		&schemas.ReceiptDraft{},
+		&schemas.ReceiptJob{},
+		&schemas.Income{},
+		&schemas.Transfer{},
+		&schemas.InstallmentPurchase{},
+		&schemas.Budget{},
+		&schemas.BudgetAlert{},
+		&schemas.Goal{},
+		&schemas.GoalContribution{},
 		&schemas.GeneratedTip{},
 		&schemas.MealPlan{},
 		&schemas.MealItem{},
 		&schemas.Session{},
+		&schemas.RefreshToken{},
+		&schemas.UserToken{},
+		&schemas.MFARecoveryCode{},
+		&schemas.UserIdentity{},
+		&schemas.APIKey{},
+		&schemas.Household{},
+		&schemas.HouseholdMember{},
+		&schemas.HouseholdInvitation{},
+		&schemas.RecurrenceRule{},
+		&schemas.RecurrenceSkip{},
+		&schemas.LoginThrottle{},
+		&schemas.LoginAttempt{},
 		&schemas.SyncJob{},
 		&schemas.TokenUsage{},
 	); err != nil {
@@ -52,5 +79,62 @@ func runMigrations(db *gorm.DB) error {
 		return err
 	}
 
+	if err := backfillSessionIDs(db); err != nil {
+		logger.ErrorF("Erro ao preencher identificadores de sessão: %v", err)
+		return err
+	}
+
+	if err := migrateLegacySessionTokens(db); err != nil {
+		logger.ErrorF("Erro ao converter tokens de sessão: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// backfillSessionIDs atribui identificadores públicos às sessões criadas antes da coluna id existir.
+func backfillSessionIDs(db *gorm.DB) error {
+	var tokens []string
+	if err := db.Model(&schemas.Session{}).Where("id IS NULL").Pluck("token", &tokens).Error; err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if err := db.Model(&schemas.Session{}).
+			Where("token = ?", token).
+			Update("id", uuid.New()).Error; err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// migrateLegacySessionTokens converte sessões gravadas com o token em texto puro para o digest,
+// preservando os logins ativos, e descarta as que já não podem ser utilizadas.
+func migrateLegacySessionTokens(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("token_hashed = ? AND (valid = ? OR expires_at < ?)", false, false, time.Now()).
+			Delete(&schemas.Session{}).Error; err != nil {
+			return err
+		}
+
+		var tokens []string
+		if err := tx.Model(&schemas.Session{}).Where("token_hashed = ?", false).Pluck("token", &tokens).Error; err != nil {
+			return err
+		}
+
+		for _, token := range tokens {
+			digest := schemas.HashToken(token)
+			if err := tx.Model(&schemas.Session{}).
+				Where("token = ?", token).
+				Updates(map[string]interface{}{"token": digest, "token_hashed": true}).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&schemas.RefreshToken{}).
+				Where("session_token = ?", token).
+				Update("session_token", digest).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -18,35 +18,40 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
-            "post": {
-                "description": "Valida credenciais e emite um novo token de sessão",
-                "consumes": [
-                    "application/json"
+        "/accounts": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
+                "description": "Lista as contas e carteiras do escopo atual com o saldo atual de cada uma (saldo inicial + receitas - despesas +/- transferências)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Contas"
                 ],
-                "summary": "Autenticar usuário",
+                "summary": "Listar contas",
                 "parameters": [
                     {
-                        "description": "Credenciais",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.LoginRequest"
-                        }
+                        "type": "boolean",
+                        "description": "Inclui contas desativadas (padrão false)",
+                        "name": "includeInactive",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.AuthSuccessResponse"
+                            "$ref": "#/definitions/handler.AccountListSuccess"
                         }
                     },
                     "400": {
@@ -61,6 +66,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.APIError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -68,16 +79,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/auth/logout": {
+            },
             "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Invalida a sessão atual ou todas as sessões do usuário autenticado",
+                "description": "Cria uma conta corrente, poupança, cartão de crédito ou carteira de dinheiro. Sem currency, usa a moeda configurada pelo usuário. Cartões de crédito exigem closingDay e dueDay, usados para montar as faturas.",
                 "consumes": [
                     "application/json"
                 ],
@@ -85,24 +94,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Contas"
                 ],
-                "summary": "Encerrar sessão",
+                "summary": "Criar conta",
                 "parameters": [
                     {
-                        "description": "Opções de encerramento",
+                        "description": "Dados da conta",
                         "name": "body",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.LogoutRequest"
+                            "$ref": "#/definitions/handler.AccountRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.APISuccess"
+                            "$ref": "#/definitions/handler.AccountSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
                         }
                     },
                     "401": {
@@ -111,6 +133,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.APIError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -120,66 +148,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/me": {
+        "/accounts/balances": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Retorna os dados do usuário autenticado",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Auth"
-                ],
-                "summary": "Perfil do usuário",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.UserProfileSuccess"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/handler.APIError"
-                        }
-                    }
-                }
-            }
-        },
-        "/auth/register": {
-            "post": {
-                "description": "Cria um usuário, configura dados padrão e retorna a sessão autenticada",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retorna, para cada conta do escopo (ou apenas a informada), o saldo no início do período, cada receita, despesa e transferência do período com o saldo acumulado após ela e o saldo final. Sem from/to, o período é o mês informado (ou o atual).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Contas"
                 ],
-                "summary": "Registrar novo usuário",
+                "summary": "Histórico de saldos",
                 "parameters": [
                     {
-                        "description": "Dados de registro",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.RegisterRequest"
-                        }
+                        "type": "integer",
+                        "description": "Mês (1-12), ignorado quando from/to são informados",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano, ignorado quando from/to são informados",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial (inclusiva), ex.: 2024-01-01",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (inclusiva), ex.: 2024-03-31",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Limita o histórico a uma conta",
+                        "name": "accountId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.AuthSuccessResponse"
+                            "$ref": "#/definitions/handler.AccountBalanceListSuccess"
                         }
                     },
                     "400": {
@@ -188,8 +214,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.APIError"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handler.APIError"
                         }
@@ -203,98 +235,63 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories": {
+        "/accounts/{id}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Retorna as categorias do usuário autenticado",
+                "description": "Retorna uma conta com o saldo atual",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Categorias"
+                    "Contas"
                 ],
-                "summary": "Listar categorias",
+                "summary": "Buscar conta",
                 "parameters": [
                     {
-                        "enum": [
-                            "true",
-                            "false",
-                            "all"
-                        ],
                         "type": "string",
-                        "description": "Filtra por status das categorias. Use true para apenas ativas, false para apenas inativas e deixe em branco ou use all para todas.",
-                        "name": "status",
-                        "in": "query"
+                        "description": "Identificador da conta",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.CategoryListSuccess"
+                            "$ref": "#/definitions/handler.AccountSuccess"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/handler.APIError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/handler.APIError"
                         }
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "Cria uma categoria personalizada para o usuário",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Categorias"
-                ],
-                "summary": "Criar categoria",
-                "parameters": [
-                    {
-                        "description": "Dados da categoria",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.CategoryRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.CategoryItemSuccess"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handler.APIError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handler.APIError"
                         }
@@ -306,16 +303,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/categories/{id}": {
+            },
             "put": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Atualiza dados de uma categoria existente",
+                "description": "Atualiza nome, tipo, saldo inicial, dias de fechamento e vencimento (cartões de crédito) ou status de uma conta. A moeda não pode ser alterada.",
                 "consumes": [
                     "application/json"
                 ],
@@ -323,13 +318,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categorias"
+                    "Contas"
                 ],
-                "summary": "Atualizar categoria",
+                "summary": "Atualizar conta",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Identificador da categoria",
+                        "description": "Identificador da conta",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -340,15 +335,21 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CategoryRequest"
+                            "$ref": "#/definitions/handler.UpdateAccountRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.CategoryItemSuccess"
+                            "$ref": "#/definitions/handler.AccountSuccess"
                         }
                     },
                     "400": {
@@ -363,6 +364,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.APIError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -383,18 +390,24 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "Marca uma categoria como inativa",
+                "description": "Marca a conta como inativa; o histórico é mantido, mas ela deixa de aceitar novos lançamentos e transferências",
                 "tags": [
-                    "Categorias"
+                    "Contas"
                 ],
-                "summary": "Desativar categoria",
+                "summary": "Desativar conta",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Identificador da categoria",
+                        "description": "Identificador da conta",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -404,12 +417,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.APISuccess"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/handler.APIError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -425,40 +450,59 @@ const docTemplate = `{
                 }
             }
         },
-        "/dashboard/summary": {
+        "/accounts/{id}/statements": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Retorna totais do mês atual e comparação com o mês anterior",
+                "description": "Lista as faturas do cartão de crédito a partir da que fecha no mês informado (ou no atual), com as despesas de cada uma. Parcelas trazem o rótulo k/N.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Dashboard"
+                    "Contas"
                 ],
-                "summary": "Resumo do dashboard",
+                "summary": "Faturas do cartão",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador do cartão de crédito",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
-                        "description": "Mês (1-12)",
+                        "description": "Mês de fechamento da primeira fatura (1-12)",
                         "name": "month",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Ano",
+                        "description": "Ano de fechamento da primeira fatura",
                         "name": "year",
                         "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de faturas (padrão 3, máximo 24)",
+                        "name": "months",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.DashboardSummaryResponse"
+                            "$ref": "#/definitions/handler.StatementListSuccess"
                         }
                     },
                     "400": {
@@ -472,48 +516,54 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.APIError"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
                     }
                 }
             }
         },
-        "/expenses": {
+        "/admin/token-usage": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Lista despesas filtradas por mês e ano",
+                "description": "Agrega o consumo de tokens de todos os usuários no período, por tipo de requisição e pelos maiores consumidores. Disponível para suporte e administradores.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Despesas"
+                    "Admin"
                 ],
-                "summary": "Listar despesas",
+                "summary": "Consumo global de tokens",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Mês (1-12)",
-                        "name": "month",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Ano",
-                        "name": "year",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Filtro por categoria",
-                        "name": "categoryId",
+                        "description": "Início do período (YYYY-MM-DD ou RFC3339)",
+                        "name": "from",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Origem: manual|ocr|ia",
-                        "name": "origin",
+                        "description": "Fim do período, inclusivo (YYYY-MM-DD ou RFC3339)",
+                        "name": "to",
                         "in": "query"
                     }
                 ],
@@ -521,7 +571,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.ExpensesListResponse"
+                            "$ref": "#/definitions/handler.AdminTokenUsageSuccess"
                         }
                     },
                     "400": {
@@ -535,42 +585,79 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.APIError"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/users": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Registra uma nova despesa para o usuário autenticado",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lista os usuários com busca por nome ou email e filtros de papel e situação. Disponível para suporte e administradores.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Despesas"
+                    "Admin"
                 ],
-                "summary": "Criar despesa",
+                "summary": "Listar usuários",
                 "parameters": [
                     {
-                        "description": "Dados da despesa",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.ExpenseRequest"
-                        }
+                        "type": "string",
+                        "description": "Trecho do nome ou email",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "user",
+                            "support",
+                            "admin"
+                        ],
+                        "type": "string",
+                        "description": "Papel",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Situação da conta",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Número máximo de registros por página (1-200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página a ser retornada (\u003e=1)",
+                        "name": "page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.ExpenseResponse"
+                            "$ref": "#/definitions/handler.AdminUserListSuccess"
                         }
                     },
                     "400": {
@@ -600,25 +687,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/expenses/{id}": {
+        "/admin/users/{id}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Retorna os detalhes de uma despesa específica",
+                "description": "Retorna perfil, bloqueio de login, sessões ativas, provedores vinculados e consumo de tokens do usuário. Disponível para suporte e administradores.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Despesas"
+                    "Admin"
                 ],
-                "summary": "Buscar despesa",
+                "summary": "Detalhar usuário",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Identificador da despesa",
+                        "description": "ID do usuário",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -628,7 +715,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.ExpenseResponse"
+                            "$ref": "#/definitions/handler.AdminUserDetailSuccess"
                         }
                     },
                     "400": {
@@ -643,54 +730,56 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.APIError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handler.APIError"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/users/{id}/activate": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Atualiza campos de uma despesa existente",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Reativa uma conta desativada. O usuário precisa entrar novamente. Apenas administradores.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Despesas"
+                    "Admin"
                 ],
-                "summary": "Atualizar despesa",
+                "summary": "Reativar usuário",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Identificador da despesa",
+                        "description": "ID do usuário",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Campos para atualização",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UpdateExpenseRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.ExpenseResponse"
+                            "$ref": "#/definitions/handler.UserProfileSuccess"
                         }
                     },
                     "400": {
@@ -716,24 +805,41 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.APIError"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/users/{id}/deactivate": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Exclui uma despesa definitivamente",
+                "description": "Desativa a conta e encerra todas as sessões e refresh tokens do usuário. Apenas administradores.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Despesas"
+                    "Admin"
                 ],
-                "summary": "Remover despesa",
+                "summary": "Desativar usuário",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Identificador da despesa",
+                        "description": "ID do usuário",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -743,7 +849,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.APISuccess"
+                            "$ref": "#/definitions/handler.UserProfileSuccess"
                         }
                     },
                     "400": {
@@ -758,43 +864,68 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.APIError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handler.APIError"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
                     }
                 }
             }
         },
-        "/meal-plans": {
-            "get": {
+        "/admin/users/{id}/logout": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Retorna o plano de refeições salvo para a semana ISO informada (padrão: semana atual)",
+                "description": "Revoga todas as sessões e refresh tokens do usuário, obrigando um novo login em todos os dispositivos. Disponível para suporte e administradores.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Refeições"
+                    "Admin"
                 ],
-                "summary": "Consultar plano de refeições da semana",
+                "summary": "Encerrar sessões do usuário",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Semana no formato YYYY-Www (ex: 2024-W37)",
-                        "name": "week",
-                        "in": "query"
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.MealPlanResponse"
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
                         }
                     },
                     "401": {
@@ -803,6 +934,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.APIError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -818,14 +955,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/meal-plans/generate": {
-            "post": {
+        "/admin/users/{id}/role": {
+            "put": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Cria um plano semanal com receitas baseadas nos itens de compras recentes. Usa heurísticas se o modelo não estiver disponível.",
+                "description": "Define o papel do usuário (user, support ou admin). Administradores não podem alterar o próprio papel. Apenas administradores.",
                 "consumes": [
                     "application/json"
                 ],
@@ -833,16 +970,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Refeições"
+                    "Admin"
                 ],
-                "summary": "Gerar plano de refeições com Gemini",
+                "summary": "Alterar papel do usuário",
                 "parameters": [
                     {
-                        "description": "Preferências para geração",
+                        "type": "string",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Novo papel",
                         "name": "body",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.GenerateMealPlanRequest"
+                            "$ref": "#/definitions/handler.UpdateUserRoleRequest"
                         }
                     }
                 ],
@@ -850,7 +995,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.MealPlanResponse"
+                            "$ref": "#/definitions/handler.UserProfileSuccess"
                         }
                     },
                     "400": {
@@ -865,6 +1010,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.APIError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -874,40 +1037,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/receipts/scan": {
+        "/admin/users/{id}/unlock": {
             "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Analisa uma imagem em Base64 usando Gemini e retorna extrações estruturadas",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Remove o bloqueio por tentativas de login incorretas do email do usuário. Disponível para suporte e administradores.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Recibos"
+                    "Admin"
                 ],
-                "summary": "Processar recibo com OCR",
+                "summary": "Desbloquear login",
                 "parameters": [
                     {
-                        "description": "Dados do recibo",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.ReceiptScanRequest"
-                        }
+                        "type": "string",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.ReceiptScanResponse"
+                            "$ref": "#/definitions/handler.APISuccess"
                         }
                     },
                     "400": {
@@ -922,23 +1080,76 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.APIError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
                             "$ref": "#/definitions/handler.APIError"
                         }
                     }
                 }
             }
         },
-        "/sync/jobs": {
+        "/auth/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista as chaves de API ativas do usuário autenticado, sem o valor secreto",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Listar chaves de API",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIKeyListSuccess"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
             "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Salva o histórico de uma sincronização concluída",
+                "description": "Cria uma chave pessoal com os escopos informados e validade opcional. A chave é exibida apenas nesta resposta; o servidor guarda somente o digest. Escopos: expenses:read, expenses:write, incomes:read, incomes:write, accounts:read, accounts:write, budgets:read, budgets:write, goals:read, goals:write, categories:read, categories:write, receipts:scan, dashboard:read, tips:read, tips:write, meal-plans:read, meal-plans:write, sync:write, token-usage:read.",
                 "consumes": [
                     "application/json"
                 ],
@@ -946,17 +1157,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Sincronização"
+                    "Auth"
                 ],
-                "summary": "Registrar sincronização",
+                "summary": "Criar chave de API",
                 "parameters": [
                     {
-                        "description": "Origens",
+                        "description": "Nome, escopos e validade",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.SyncRequest"
+                            "$ref": "#/definitions/handler.CreateAPIKeyRequest"
                         }
                     }
                 ],
@@ -964,7 +1175,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SyncJobResponse"
+                            "$ref": "#/definitions/handler.APIKeyCreatedSuccess"
                         }
                     },
                     "400": {
@@ -979,6 +1190,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.APIError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -988,49 +1211,90 @@ const docTemplate = `{
                 }
             }
         },
-        "/tips": {
-            "get": {
+        "/auth/api-keys/{id}": {
+            "delete": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Retorna dicas ordenadas por relevância para o usuário autenticado",
+                "description": "Revoga imediatamente a chave informada",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Dicas"
+                    "Auth"
                 ],
-                "summary": "Listar dicas financeiras",
+                "summary": "Revogar chave de API",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Mês (1-12)",
-                        "name": "month",
-                        "in": "query"
+                        "type": "string",
+                        "description": "ID da chave",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "description": "Ano",
-                        "name": "year",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/identities": {
+            "get": {
+                "security": [
                     {
-                        "type": "boolean",
-                        "description": "true para recalcular dicas antes de responder",
-                        "name": "refresh",
-                        "in": "query"
+                        "Bearer": []
                     }
                 ],
+                "description": "Lista as contas de provedores externos vinculadas ao usuário autenticado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Listar provedores vinculados",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handler.TipResponse"
-                            }
+                            "$ref": "#/definitions/handler.IdentityListSuccess"
                         }
                     },
                     "401": {
@@ -1048,43 +1312,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/tips/generate": {
-            "post": {
+        "/auth/identities/{id}": {
+            "delete": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Recalcula dicas com base nos gastos recentes e Gemini. Usa heurísticas caso o modelo não esteja disponível.",
+                "description": "Remove o vínculo com um provedor externo. Contas sem senha precisam manter ao menos um provedor.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Dicas"
+                    "Auth"
                 ],
-                "summary": "Gerar novas dicas financeiras",
+                "summary": "Desvincular provedor",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Mês (1-12)",
-                        "name": "month",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Ano",
-                        "name": "year",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Identificador do vínculo",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handler.TipResponse"
-                            }
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
                         }
                     },
                     "401": {
@@ -1093,6 +1355,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.APIError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1102,40 +1376,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/token-usage": {
-            "get": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
+        "/auth/login": {
+            "post": {
+                "description": "Valida credenciais e emite um novo token de sessão. Com o segundo fator habilitado, retorna um desafio (status mfa_pending) a ser concluído em /auth/mfa/verify. Falhas repetidas bloqueiam o email ou IP temporariamente (429, code account_locked).",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Retorna o histórico de consumo de tokens do usuário autenticado com totais agregados",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Token Usage"
+                    "Auth"
                 ],
-                "summary": "Listar consumo de tokens",
+                "summary": "Autenticar usuário",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Número máximo de registros por página (1-200)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Página a ser retornada (\u003e=1)",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Credenciais",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.TokenUsageListSuccess"
+                            "$ref": "#/definitions/handler.AuthSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
                         }
                     },
                     "401": {
@@ -1144,6 +1419,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.APIError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1152,170 +1439,8747 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handler.APIError": {
-            "type": "object",
-            "properties": {
-                "details": {},
-                "message": {
-                    "type": "string"
-                }
-            }
         },
-        "handler.APISuccess": {
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Invalida a sessão atual ou todas as sessões do usuário autenticado, revogando também os refresh tokens associados",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Encerrar sessão",
+                "parameters": [
+                    {
+                        "description": "Opções de encerramento",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/me": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna os dados do usuário autenticado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Perfil do usuário",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserProfileSuccess"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Agenda a exclusão definitiva da conta e de todos os dados após o período de carência (ACCOUNT_DELETION_GRACE_DAYS), encerrando as demais sessões. Com carência zero a exclusão é imediata. Exige a senha e, com segundo fator habilitado, um código.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Excluir conta",
+                "parameters": [
+                    {
+                        "description": "Confirmação",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.AccountDeletionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AccountDeletionSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Atualiza nome e/ou email do usuário autenticado. A troca de email exige a senha atual e só é aplicada após a confirmação pelo link enviado ao novo endereço.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Atualizar perfil",
+                "parameters": [
+                    {
+                        "description": "Campos do perfil",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserProfileSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/me/config": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Substitui as preferências do usuário (moeda, limite mensal, idioma, tema e notificações) com as mesmas regras do cadastro",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Atualizar configurações",
+                "parameters": [
+                    {
+                        "description": "Preferências",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateConfigRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserProfileSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/me/deletion/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Cancela a exclusão agendada enquanto o período de carência não terminou",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Cancelar exclusão da conta",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/me/export": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Gera um arquivo ZIP com todos os dados do usuário autenticado em JSON, além de despesas e itens em CSV (portabilidade LGPD)",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Exportar dados pessoais",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/me/password": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Altera a senha mediante a senha atual e encerra todas as outras sessões do usuário. Contas criadas por provedor externo definem a primeira senha sem informar a atual.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Alterar senha",
+                "parameters": [
+                    {
+                        "description": "Senha atual e nova senha",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Ativa a autenticação em dois fatores com um código do app autenticador e retorna os códigos de recuperação, exibidos uma única vez",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Confirmar segundo fator",
+                "parameters": [
+                    {
+                        "description": "Código TOTP",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.MFARecoveryCodesSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Desativa a autenticação em dois fatores mediante a senha atual e um código TOTP ou de recuperação",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Desativar segundo fator",
+                "parameters": [
+                    {
+                        "description": "Senha e código",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.MFADisableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Gera um novo segredo TOTP e a URI otpauth para o app autenticador. O segundo fator só é ativado após a confirmação com um código válido.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Iniciar cadastro do segundo fator",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.MFAEnrollSuccess"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Substitui todos os códigos de recuperação do segundo fator mediante um código TOTP válido",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Gerar novos códigos de recuperação",
+                "parameters": [
+                    {
+                        "description": "Código TOTP",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.MFARecoveryCodesSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa/verify": {
+            "post": {
+                "description": "Troca o desafio mfa_pending retornado pelo login e um código TOTP ou de recuperação por uma sessão autenticada",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Concluir login com segundo fator",
+                "parameters": [
+                    {
+                        "description": "Desafio e código",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.MFAVerifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AuthSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/{provider}": {
+            "post": {
+                "description": "Valida o ID token do provedor (ex.: google), vincula ou cria o usuário e emite a mesma sessão do login por senha. Contas existentes são vinculadas pelo email quando o provedor o confirma. Se o email da conta ainda não tinha sido verificado, a senha, o segundo fator, as sessões e as chaves de API existentes são descartados antes do vínculo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Entrar com provedor externo",
+                "parameters": [
+                    {
+                        "enum": [
+                            "google"
+                        ],
+                        "type": "string",
+                        "description": "Provedor",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.OIDCLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AuthSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password/forgot": {
+            "post": {
+                "description": "Envia por email um token de uso único para redefinir a senha. A resposta é a mesma para emails cadastrados ou não.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Solicitar redefinição de senha",
+                "parameters": [
+                    {
+                        "description": "Email da conta",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password/reset": {
+            "post": {
+                "description": "Define uma nova senha a partir do token recebido por email, encerra todas as sessões do usuário e revoga suas chaves de API",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Redefinir senha",
+                "parameters": [
+                    {
+                        "description": "Token e nova senha",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Troca um refresh token válido por um novo token de acesso e um novo refresh token. A reutilização de um refresh token já trocado revoga toda a família de tokens.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Renovar sessão",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AuthSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Cria um usuário, configura dados padrão, envia o email de verificação e retorna a sessão autenticada (ou apenas o usuário quando REQUIRE_EMAIL_VERIFICATION está ativo)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Registrar novo usuário",
+                "parameters": [
+                    {
+                        "description": "Dados de registro",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AuthSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista os dispositivos com sessão ativa do usuário autenticado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Listar sessões ativas",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SessionListSuccess"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Encerra uma sessão específica do usuário autenticado e revoga os refresh tokens do dispositivo",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Revogar sessão",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da sessão",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify-email": {
+            "post": {
+                "description": "Confirma a posse do email a partir do token enviado no cadastro ou na troca de email; neste caso o novo endereço passa a valer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Confirmar email",
+                "parameters": [
+                    {
+                        "description": "Token de verificação",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.VerifyEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify-email/resend": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Gera um novo token de verificação e o envia para o email do usuário autenticado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Reenviar verificação de email",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/budgets": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista os orçamentos mensais por categoria do escopo atual",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orçamentos"
+                ],
+                "summary": "Listar orçamentos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BudgetListSuccess"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Define o limite mensal de gastos de uma categoria, válido a partir do mês informado (ou do atual). Rollover: none (padrão), surplus (a sobra do mês anterior aumenta o limite) ou full (sobra e estouro passam para o mês seguinte). Cada categoria aceita um único orçamento.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orçamentos"
+                ],
+                "summary": "Criar orçamento",
+                "parameters": [
+                    {
+                        "description": "Dados do orçamento",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.BudgetRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BudgetSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/budgets/alerts": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista os alertas gerados no mês quando os gastos de uma categoria atingiram 50, 80 ou 100% do orçamento, do mais recente para o mais antigo",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orçamentos"
+                ],
+                "summary": "Listar alertas de orçamento",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mês (1-12)",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BudgetAlertListSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/budgets/status": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna, para cada orçamento válido no mês, o limite (base + saldo transportado), o gasto na categoria considerando as divisões de despesas, o restante, o percentual usado, a projeção para o fim do mês e os limiares de alerta (50, 80 e 100%) já atingidos. A projeção do mês atual extrapola o ritmo diário de gastos; meses encerrados projetam o próprio gasto.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orçamentos"
+                ],
+                "summary": "Situação dos orçamentos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mês (1-12)",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BudgetStatusSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/budgets/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Altera o limite mensal ou o tipo de rollover de um orçamento. O novo limite vale para todos os meses, inclusive no cálculo do saldo transportado.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orçamentos"
+                ],
+                "summary": "Atualizar orçamento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador do orçamento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos para atualização",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateBudgetRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BudgetSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Remove o orçamento da categoria junto com seus alertas",
+                "tags": [
+                    "Orçamentos"
+                ],
+                "summary": "Excluir orçamento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador do orçamento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna as categorias do usuário autenticado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categorias"
+                ],
+                "summary": "Listar categorias",
+                "parameters": [
+                    {
+                        "enum": [
+                            "true",
+                            "false",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Filtra por status das categorias. Use true para apenas ativas, false para apenas inativas e deixe em branco ou use all para todas.",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "despesa",
+                            "receita"
+                        ],
+                        "type": "string",
+                        "description": "Filtra por natureza: despesa ou receita",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CategoryListSuccess"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Cria uma categoria personalizada para o usuário. kind define se ela classifica despesas (padrão) ou receitas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categorias"
+                ],
+                "summary": "Criar categoria",
+                "parameters": [
+                    {
+                        "description": "Dados da categoria",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CategoryRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CategoryItemSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Atualiza dados de uma categoria existente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categorias"
+                ],
+                "summary": "Atualizar categoria",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da categoria",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos para atualização",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CategoryRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CategoryItemSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Marca uma categoria como inativa",
+                "tags": [
+                    "Categorias"
+                ],
+                "summary": "Desativar categoria",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da categoria",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/dashboard/cash-flow": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna receitas, despesas, saldo e taxa de poupança (saldo / receitas) de cada mês, terminando no mês informado (ou no atual), e os totais do período",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboard"
+                ],
+                "summary": "Fluxo de caixa mensal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Último mês do período (1-12)",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano do último mês",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de meses (padrão 6, máximo 24)",
+                        "name": "months",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CashFlowSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/dashboard/summary": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna totais do mês atual, comparação com o mês anterior e o saldo do mês (receitas menos despesas, com a taxa de poupança)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboard"
+                ],
+                "summary": "Resumo do dashboard",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mês (1-12)",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.DashboardSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista despesas com filtros, ordenação e paginação por cursor. Sem from/to, o período é o mês informado (ou o atual). O resumo considera todas as despesas filtradas, não apenas a página retornada. Para a próxima página, repita a consulta com o nextCursor recebido.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Despesas"
+                ],
+                "summary": "Listar despesas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mês (1-12), ignorado quando from/to são informados",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano, ignorado quando from/to são informados",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial (inclusiva), ex.: 2024-01-01",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (inclusiva), ex.: 2024-03-31",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filtro por categoria; repita o parâmetro ou separe por vírgula para várias. Despesas divididas entram também pelas categorias das divisões",
+                        "name": "categoryId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtro por conta",
+                        "name": "accountId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtro por estabelecimento",
+                        "name": "merchantId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Origem: manual|ocr|ia",
+                        "name": "origin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Apenas despesas recorrentes (true) ou não recorrentes (false)",
+                        "name": "recurring",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor mínimo",
+                        "name": "minAmount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor máximo",
+                        "name": "maxAmount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Busca na descrição e nos nomes dos itens",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "date",
+                            "amount",
+                            "description"
+                        ],
+                        "type": "string",
+                        "description": "Ordenação: date|amount|description (padrão date)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Direção: asc|desc (padrão desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens por página (padrão 50, máximo 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor devolvido em pagination.nextCursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ExpenseListSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Registra uma nova despesa para o usuário autenticado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Despesas"
+                ],
+                "summary": "Criar despesa",
+                "parameters": [
+                    {
+                        "description": "Dados da despesa",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ExpenseRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ExpenseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/suggest-category": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Sugere, entre as categorias de despesa ativas do escopo, a categoria da descrição informada e de cada item. A sugestão vem, nesta ordem, do histórico confirmado pelo usuário (correções feitas ao editar despesas e despesas anteriores do mesmo estabelecimento ou descrição), de palavras-chave e, quando nada disso resolve e useAi não é false, do Gemini. Itens sem sugestão própria ficam na categoria da descrição. source indica a origem: history, keyword ou llm.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Despesas"
+                ],
+                "summary": "Sugerir categoria de despesa",
+                "parameters": [
+                    {
+                        "description": "Descrição, estabelecimento e itens do lançamento",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuggestCategoryRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CategorySuggestionSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna os detalhes de uma despesa específica, com os itens do cupom e a divisão entre categorias",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Despesas"
+                ],
+                "summary": "Buscar despesa",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da despesa",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ExpenseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Atualiza campos de uma despesa existente. Em despesas divididas entre categorias, o novo valor não pode ser menor que a soma das divisões. Trocar a categoria ensina o categorizer: despesas futuras do mesmo estabelecimento ou com a mesma descrição passam a ser sugeridas na nova categoria",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Despesas"
+                ],
+                "summary": "Atualizar despesa",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da despesa",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos para atualização",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateExpenseRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ExpenseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Exclui uma despesa definitivamente",
+                "tags": [
+                    "Despesas"
+                ],
+                "summary": "Remover despesa",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da despesa",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/{id}/allocations": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Substitui a divisão da despesa entre categorias. Cada divisão informa a categoria e um valor manual (amount) ou os itens do cupom (itemIds), que contam pelo total de cada item. A soma não pode passar do valor da despesa e o restante fica na categoria principal. Envie uma lista vazia para desfazer a divisão.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Despesas"
+                ],
+                "summary": "Dividir despesa entre categorias",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da despesa",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Divisões da despesa",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ExpenseAllocationsRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ExpenseItemSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/expenses/{id}/recurrence": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Cria uma regra de recorrência usando a despesa como modelo e primeira ocorrência (índice 0). As ocorrências seguintes são geradas pelo agendador quando a data chega; ocorrências já vencidas são geradas imediatamente. Frequências: weekly, monthly (dayOfMonth opcional, padrão o dia da despesa) e yearly, a cada interval unidades, até endDate ou count ocorrências.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recorrências"
+                ],
+                "summary": "Tornar despesa recorrente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da despesa modelo",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Regra de recorrência",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RecurrenceRuleRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RecurrenceRuleSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/goals": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista as metas de economia do escopo atual com o progresso de cada uma",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metas"
+                ],
+                "summary": "Listar metas",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Inclui metas arquivadas (padrão false)",
+                        "name": "includeArchived",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GoalListSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Cria uma meta de economia com valor alvo, prazo e, opcionalmente, a conta onde o dinheiro fica reservado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metas"
+                ],
+                "summary": "Criar meta",
+                "parameters": [
+                    {
+                        "description": "Dados da meta",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.GoalRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GoalSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/goals/progress": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Resume as metas ativas: total a atingir, total guardado, aporte mensal necessário somado e quantas estão no ritmo ou atrasadas. Para cada meta, o aporte mensal necessário divide o que falta pelos meses até o prazo (incluindo o atual); a meta está no ritmo quando o valor guardado alcança o esperado numa evolução linear entre a criação e o prazo.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metas"
+                ],
+                "summary": "Progresso das metas",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GoalProgressSuccess"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/goals/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna uma meta com o progresso e o histórico de aportes e resgates",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metas"
+                ],
+                "summary": "Buscar meta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da meta",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GoalSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Atualiza nome, valor alvo, prazo, conta vinculada ou arquivamento de uma meta",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metas"
+                ],
+                "summary": "Atualizar meta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da meta",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos para atualização",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateGoalRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GoalSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Exclui a meta e seu histórico de aportes. Transferências geradas pelos aportes são mantidas, pois o dinheiro continua na conta de destino.",
+                "tags": [
+                    "Metas"
+                ],
+                "summary": "Excluir meta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da meta",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/goals/{id}/contributions": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Registra um aporte na meta ou, com valor negativo, um resgate. Com fromAccountId, o aporte também gera uma transferência da conta informada para a conta vinculada à meta e o resgate, uma transferência da conta da meta de volta para a conta informada; as duas contas devem usar a mesma moeda.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metas"
+                ],
+                "summary": "Registrar aporte",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da meta",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados do aporte",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.GoalContributionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GoalSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/goals/{id}/contributions/{contributionId}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Remove um aporte ou resgate da meta, junto com a transferência que ele gerou",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metas"
+                ],
+                "summary": "Remover aporte",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da meta",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Identificador do aporte",
+                        "name": "contributionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GoalSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/households": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista os grupos familiares dos quais o usuário participa, com o papel dele em cada um",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Grupos familiares"
+                ],
+                "summary": "Listar grupos familiares",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HouseholdListSuccess"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Cria um grupo familiar com o usuário como proprietário e as categorias padrão. Para usar os dados do grupo, envie o cabeçalho X-Household-ID nas rotas de categorias, despesas e dashboard.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Grupos familiares"
+                ],
+                "summary": "Criar grupo familiar",
+                "parameters": [
+                    {
+                        "description": "Nome do grupo",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.HouseholdRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HouseholdSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/households/invitations/accept": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Aceita o convite recebido por email e passa a participar do grupo familiar. O convite só pode ser aceito pela conta com o email convidado.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Grupos familiares"
+                ],
+                "summary": "Aceitar convite",
+                "parameters": [
+                    {
+                        "description": "Token do convite",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.AcceptHouseholdInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HouseholdSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna o grupo familiar com seus membros e papéis",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Grupos familiares"
+                ],
+                "summary": "Detalhar grupo familiar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HouseholdSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Exclui o grupo familiar, suas categorias e despesas, membros e convites. Apenas proprietários.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Grupos familiares"
+                ],
+                "summary": "Excluir grupo familiar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Altera o nome do grupo familiar. Apenas proprietários.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Grupos familiares"
+                ],
+                "summary": "Renomear grupo familiar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Novo nome",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.HouseholdRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HouseholdSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista os convites do grupo familiar que ainda não foram aceitos, revogados ou expiraram. Apenas proprietários.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Grupos familiares"
+                ],
+                "summary": "Listar convites pendentes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HouseholdInvitationListSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Envia por email um convite para participar do grupo com o papel editor (padrão) ou viewer. Convites anteriores para o mesmo email deixam de valer. Apenas proprietários.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Grupos familiares"
+                ],
+                "summary": "Convidar para o grupo familiar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Email e papel",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.HouseholdInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HouseholdInvitationSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/invitations/{invitationId}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Cancela um convite pendente do grupo familiar. Apenas proprietários.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Grupos familiares"
+                ],
+                "summary": "Revogar convite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do convite",
+                        "name": "invitationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/members/{userId}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Define o papel (owner, editor ou viewer) de outro membro do grupo familiar. Apenas proprietários.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Grupos familiares"
+                ],
+                "summary": "Alterar papel de membro",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do usuário membro",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Novo papel",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateHouseholdMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.HouseholdSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Remove um membro do grupo familiar. Proprietários removem qualquer membro; os demais podem remover apenas a si mesmos para sair do grupo. O último proprietário não pode sair.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Grupos familiares"
+                ],
+                "summary": "Remover membro",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do usuário membro",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/incomes": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista as receitas do período, da mais recente para a mais antiga. Sem from/to, o período é o mês informado (ou o atual). O resumo considera todas as receitas filtradas.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Receitas"
+                ],
+                "summary": "Listar receitas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mês (1-12), ignorado quando from/to são informados",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano, ignorado quando from/to são informados",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial (inclusiva), ex.: 2024-01-01",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (inclusiva), ex.: 2024-03-31",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filtro por categoria; repita o parâmetro ou separe por vírgula para várias",
+                        "name": "categoryId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtro por conta",
+                        "name": "accountId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade máxima de receitas (padrão 50, máximo 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.IncomeListSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Registra uma nova receita (salário, freelance, reembolso...) usando uma categoria do tipo receita",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Receitas"
+                ],
+                "summary": "Criar receita",
+                "parameters": [
+                    {
+                        "description": "Dados da receita",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.IncomeRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.IncomeItemSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/incomes/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna os detalhes de uma receita específica",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Receitas"
+                ],
+                "summary": "Buscar receita",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da receita",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.IncomeItemSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Atualiza campos de uma receita existente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Receitas"
+                ],
+                "summary": "Atualizar receita",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da receita",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos para atualização",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateIncomeRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.IncomeItemSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Exclui uma receita",
+                "tags": [
+                    "Receitas"
+                ],
+                "summary": "Remover receita",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da receita",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/incomes/{id}/recurrence": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Cria uma regra de recorrência usando a receita como modelo e primeira ocorrência (índice 0), com as mesmas opções de agendamento das despesas. As ocorrências são gerenciadas pelas rotas /recurrences.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recorrências"
+                ],
+                "summary": "Tornar receita recorrente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da receita modelo",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Regra de recorrência",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RecurrenceRuleRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RecurrenceRuleSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/installments": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista as compras parceladas do escopo, da mais recente para a mais antiga, com as parcelas e a fatura de cada uma",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Parcelamentos"
+                ],
+                "summary": "Listar compras parceladas",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filtro por cartão",
+                        "name": "accountId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.InstallmentPurchaseListSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Registra uma compra parcelada no cartão de crédito, gerando uma despesa por parcela. Cada parcela é datada um mês após a anterior e cai na fatura seguinte; a primeira parcela absorve a diferença de centavos da divisão.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Parcelamentos"
+                ],
+                "summary": "Criar compra parcelada",
+                "parameters": [
+                    {
+                        "description": "Dados da compra parcelada",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.InstallmentPurchaseRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.InstallmentPurchaseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/installments/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna a compra parcelada com suas parcelas, a despesa de cada uma e a fatura em que cai",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Parcelamentos"
+                ],
+                "summary": "Buscar compra parcelada",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da compra parcelada",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.InstallmentPurchaseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Exclui a compra parcelada e todas as suas parcelas",
+                "tags": [
+                    "Parcelamentos"
+                ],
+                "summary": "Remover compra parcelada",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da compra parcelada",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/meal-plans": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna o plano de refeições salvo para a semana ISO informada (padrão: semana atual)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refeições"
+                ],
+                "summary": "Consultar plano de refeições da semana",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semana no formato YYYY-Www (ex: 2024-W37)",
+                        "name": "week",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.MealPlanResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/meal-plans/generate": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Cria um plano semanal com receitas baseadas nos itens de compras recentes. Usa heurísticas se o modelo não estiver disponível.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refeições"
+                ],
+                "summary": "Gerar plano de refeições com Gemini",
+                "parameters": [
+                    {
+                        "description": "Preferências para geração",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenerateMealPlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.MealPlanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista os estabelecimentos identificados nos recibos do escopo atual com o total gasto, a quantidade de compras e a data da última compra, do maior para o menor total. from e to (YYYY-MM-DD, inclusivos) restringem o período somado; sem eles, considera todas as despesas.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Estabelecimentos"
+                ],
+                "summary": "Listar estabelecimentos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Início do período (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fim do período, inclusivo (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.MerchantListSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/receipts/drafts": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista os recibos processados que aguardam revisão no escopo atual, do mais recente para o mais antigo",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recibos"
+                ],
+                "summary": "Listar rascunhos de recibo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ReceiptDraftListSuccess"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/receipts/drafts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna um rascunho de recibo com os itens extraídos e a categoria sugerida",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recibos"
+                ],
+                "summary": "Detalhar rascunho de recibo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador do rascunho",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ReceiptDraftSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Corrige o estabelecimento (nome, CNPJ e endereço), a data, o valor, a categoria ou os itens extraídos antes da confirmação. Os itens informados substituem os atuais; envie categoryId vazio para remover a sugestão.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recibos"
+                ],
+                "summary": "Editar rascunho de recibo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador do rascunho",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos para atualização",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateReceiptDraftRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ReceiptDraftSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Remove o rascunho e a imagem do recibo sem registrar despesa",
+                "tags": [
+                    "Recibos"
+                ],
+                "summary": "Descartar rascunho de recibo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador do rascunho",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/receipts/drafts/{id}/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Registra o rascunho como despesa de origem OCR, com os itens, o texto extraído, a imagem original e o estabelecimento, e remove o rascunho. Sem categoria definida, a despesa vai para a categoria \"Compras OCR\"; sem estabelecimento, recebe uma descrição genérica.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recibos"
+                ],
+                "summary": "Confirmar rascunho de recibo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador do rascunho",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ExpenseItemSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/receipts/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna a situação de um recibo enviado com async=true: queued, processing, done (com o resultado do scan) ou failed (com o erro). Só quem enviou o recibo pode consultá-lo.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recibos"
+                ],
+                "summary": "Consultar processamento de recibo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador do job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ReceiptJobSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/receipts/scan": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Analisa a imagem de um recibo usando Gemini e retorna extrações estruturadas. Aceita JSON com a imagem em Base64 (imageBase64) ou multipart/form-data com o arquivo no campo image e os demais dados como campos do formulário. O formato é conferido pelo conteúdo (JPEG, PNG ou WebP) e o tamanho é limitado por RECEIPT_MAX_UPLOAD_MB (padrão 10). Quando a despesa é registrada, a imagem original é armazenada e fica disponível em /receipts/{id}/image. O estabelecimento extraído (nome, CNPJ e endereço) é vinculado à despesa e reaproveitado pelo CNPJ ou pelo nome normalizado; os totais por estabelecimento ficam em /merchants. Com async=true o recibo é enfileirado e a resposta 202 traz o job, acompanhado em /receipts/jobs/{id}. Com mode=draft o resultado vira um rascunho editável (/receipts/drafts) em vez de despesa; no modo auto (padrão) isso também acontece, com reviewRequired=true, quando a confiança fica abaixo de OCR_REVIEW_THRESHOLD (padrão 0.6).",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recibos"
+                ],
+                "summary": "Processar recibo com OCR",
+                "parameters": [
+                    {
+                        "description": "Dados do recibo (JSON)",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ReceiptScanRequest"
+                        }
+                    },
+                    {
+                        "type": "file",
+                        "description": "Imagem do recibo (multipart)",
+                        "name": "image",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Moeda (multipart)",
+                        "name": "currency",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Valor aproximado (multipart)",
+                        "name": "amountHint",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Idioma do recibo (multipart)",
+                        "name": "locale",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Retornar a saída bruta do modelo (multipart)",
+                        "name": "returnRaw",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Processar em segundo plano (multipart)",
+                        "name": "async",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "auto (padrão) ou draft (multipart)",
+                        "name": "mode",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ReceiptScanResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ReceiptJobSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/receipts/{id}/image": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna a imagem original enviada no processamento do recibo. Só recibos de despesas do escopo atual podem ser lidos.",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/webp"
+                ],
+                "tags": [
+                    "Recibos"
+                ],
+                "summary": "Baixar imagem do recibo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador do recibo",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurrences": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista as regras de recorrência de despesas e receitas do escopo atual, ordenadas pela próxima ocorrência. Chaves de API veem apenas as naturezas que seus escopos permitem ler.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recorrências"
+                ],
+                "summary": "Listar recorrências",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RecurrenceRuleListSuccess"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurrences/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna a regra de recorrência informada",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recorrências"
+                ],
+                "summary": "Detalhar recorrência",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da recorrência",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RecurrenceRuleSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Exclui a regra de recorrência; nenhuma nova ocorrência é gerada e os lançamentos já gerados são mantidos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recorrências"
+                ],
+                "summary": "Encerrar recorrência",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da recorrência",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurrences/{id}/occurrences/{index}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Altera apenas a ocorrência informada e devolve a despesa ou receita correspondente. Se ela ainda não foi gerada, o lançamento é criado agora com os valores alterados e o agendador não o gera de novo; se estava pulada, volta a valer.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recorrências"
+                ],
+                "summary": "Alterar uma ocorrência",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da recorrência",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Índice da ocorrência (0 é o lançamento modelo)",
+                        "name": "index",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos da ocorrência",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RecurrenceOccurrenceRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ExpenseItemSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurrences/{id}/occurrences/{index}/future": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Encerra a regra antes da ocorrência informada e cria uma nova regra a partir dela com os valores e o agendamento alterados. Ocorrências já geradas a partir desse índice são substituídas pelas da nova regra, descartando alterações individuais e ocorrências puladas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recorrências"
+                ],
+                "summary": "Alterar esta e as próximas ocorrências",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da recorrência",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Índice da primeira ocorrência alterada",
+                        "name": "index",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Valores e agendamento a partir da ocorrência",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RecurrenceFutureRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RecurrenceRuleSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurrences/{id}/occurrences/{index}/skip": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Impede que a ocorrência de índice informado seja gerada; se ela já foi gerada, a despesa ou receita é excluída",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recorrências"
+                ],
+                "summary": "Pular ocorrência",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da recorrência",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Índice da ocorrência (0 é o lançamento modelo)",
+                        "name": "index",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurrences/{id}/preview": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista as próximas ocorrências ainda não geradas pelo agendador, incluindo as puladas (skipped) e as já alteradas individualmente (com entryId, o ID da despesa ou receita)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recorrências"
+                ],
+                "summary": "Prévia das próximas ocorrências",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da recorrência",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de ocorrências (padrão 5, máximo 60)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RecurrenceOccurrenceListSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/sync/jobs": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Salva o histórico de uma sincronização concluída",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sincronização"
+                ],
+                "summary": "Registrar sincronização",
+                "parameters": [
+                    {
+                        "description": "Origens",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.SyncRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SyncJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tips": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna dicas ordenadas por relevância para o usuário autenticado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dicas"
+                ],
+                "summary": "Listar dicas financeiras",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mês (1-12)",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true para recalcular dicas antes de responder",
+                        "name": "refresh",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handler.TipResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tips/generate": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Recalcula dicas com base nos gastos recentes e Gemini. Usa heurísticas caso o modelo não esteja disponível.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dicas"
+                ],
+                "summary": "Gerar novas dicas financeiras",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mês (1-12)",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano",
+                        "name": "year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handler.TipResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/token-usage": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna o histórico de consumo de tokens do usuário autenticado com totais agregados",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Token Usage"
+                ],
+                "summary": "Listar consumo de tokens",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Número máximo de registros por página (1-200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página a ser retornada (\u003e=1)",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TokenUsageListSuccess"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Lista as transferências do período, da mais recente para a mais antiga. Sem from/to, o período é o mês informado (ou o atual).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contas"
+                ],
+                "summary": "Listar transferências",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Mês (1-12), ignorado quando from/to são informados",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ano, ignorado quando from/to são informados",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial (inclusiva), ex.: 2024-01-01",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (inclusiva), ex.: 2024-03-31",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transferências que saem ou entram nesta conta",
+                        "name": "accountId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade máxima de transferências (padrão 50, máximo 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TransferListSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Move dinheiro entre duas contas ativas do escopo. Transferências alteram os saldos, mas não contam como despesa nem receita. Entre contas de moedas diferentes, toAmount (valor creditado no destino) é obrigatório.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contas"
+                ],
+                "summary": "Criar transferência",
+                "parameters": [
+                    {
+                        "description": "Dados da transferência",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.TransferRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TransferSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Exclui uma transferência, desfazendo seu efeito nos saldos. Transferências geradas por aportes em metas só saem com a remoção do aporte.",
+                "tags": [
+                    "Contas"
+                ],
+                "summary": "Remover transferência",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador da transferência",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do grupo familiar ativo; omita para usar os dados pessoais",
+                        "name": "X-Household-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APISuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.APIError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handler.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {},
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.APIKeyCreatedSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.CreatedAPIKeyResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.APIKeyListSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.APIKeyResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.APISuccess": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AcceptHouseholdInvitationRequest": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AccountBalanceEntry": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "balance": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "referenceId": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AccountBalanceHistory": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/handler.AccountResponse"
+                },
+                "endBalance": {
+                    "type": "number"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.AccountBalanceEntry"
+                    }
+                },
+                "startBalance": {
+                    "type": "number"
+                }
+            }
+        },
+        "handler.AccountBalanceListSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.AccountBalanceHistory"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AccountDeletionRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AccountDeletionResponse": {
+            "type": "object",
+            "properties": {
+                "deletionDueAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AccountDeletionSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.AccountDeletionResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AccountListSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.AccountResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AccountRequest": {
+            "type": "object",
+            "properties": {
+                "closingDay": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "dueDay": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "openingBalance": {
+                    "type": "number"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AccountResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "balance": {
+                    "type": "number"
+                },
+                "closingDay": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "dueDay": {
+                    "type": "integer"
+                },
+                "householdId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "openingBalance": {
+                    "type": "number"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AccountSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.AccountResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AdminTokenUsageByType": {
+            "type": "object",
+            "properties": {
+                "requestType": {
+                    "type": "string"
+                },
+                "totalCostCents": {
+                    "type": "integer"
+                },
+                "totalPromptTokens": {
+                    "type": "integer"
+                },
+                "totalResponseTokens": {
+                    "type": "integer"
+                },
+                "totalTokens": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.AdminTokenUsageByUser": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "totalCostCents": {
+                    "type": "integer"
+                },
+                "totalPromptTokens": {
+                    "type": "integer"
+                },
+                "totalResponseTokens": {
+                    "type": "integer"
+                },
+                "totalTokens": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AdminTokenUsageResponse": {
+            "type": "object",
+            "properties": {
+                "byRequestType": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.AdminTokenUsageByType"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "summary": {
+                    "$ref": "#/definitions/handler.TokenUsageSummary"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "topUsers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.AdminTokenUsageByUser"
+                    }
+                }
+            }
+        },
+        "handler.AdminTokenUsageSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.AdminTokenUsageResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AdminUserDetailResponse": {
+            "type": "object",
+            "properties": {
+                "activeSessions": {
+                    "type": "integer"
+                },
+                "identities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.IdentityResponse"
+                    }
+                },
+                "lockedUntil": {
+                    "type": "string"
+                },
+                "tokenUsage": {
+                    "$ref": "#/definitions/handler.TokenUsageSummary"
+                },
+                "user": {
+                    "$ref": "#/definitions/handler.UserResponse"
+                }
+            }
+        },
+        "handler.AdminUserDetailSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.AdminUserDetailResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AdminUserListResponse": {
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/handler.TokenUsagePagination"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.UserResponse"
+                    }
+                }
+            }
+        },
+        "handler.AdminUserListSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.AdminUserListResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "refreshExpiresAt": {
+                    "type": "string"
+                },
+                "refreshToken": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/handler.UserResponse"
+                }
+            }
+        },
+        "handler.AuthSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.AuthResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.BudgetAlertListSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.BudgetAlertResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.BudgetAlertResponse": {
+            "type": "object",
+            "properties": {
+                "budgetId": {
+                    "type": "string"
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "limit": {
+                    "type": "number"
+                },
+                "month": {
+                    "type": "integer"
+                },
+                "spent": {
+                    "type": "number"
+                },
+                "threshold": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.BudgetListSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.BudgetResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.BudgetRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "rollover": {
+                    "type": "string"
+                },
+                "startMonth": {
+                    "type": "integer"
+                },
+                "startYear": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.BudgetResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "$ref": "#/definitions/handler.CategoryResponse"
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "householdId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "rollover": {
+                    "type": "string"
+                },
+                "startMonth": {
+                    "type": "integer"
+                },
+                "startYear": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.BudgetStatus": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "budgetId": {
+                    "type": "string"
+                },
+                "category": {
+                    "$ref": "#/definitions/handler.CategoryResponse"
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "limit": {
+                    "type": "number"
+                },
+                "percentUsed": {
+                    "type": "number"
+                },
+                "projected": {
+                    "type": "number"
+                },
+                "projectedOver": {
+                    "type": "boolean"
+                },
+                "remaining": {
+                    "type": "number"
+                },
+                "rolloverAmount": {
+                    "type": "number"
+                },
+                "spent": {
+                    "type": "number"
+                },
+                "thresholdsReached": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "handler.BudgetStatusResponse": {
+            "type": "object",
+            "properties": {
+                "budgets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.BudgetStatus"
+                    }
+                },
+                "month": {
+                    "type": "integer"
+                },
+                "totalLimit": {
+                    "type": "number"
+                },
+                "totalProjected": {
+                    "type": "number"
+                },
+                "totalSpent": {
+                    "type": "number"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.BudgetStatusSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.BudgetStatusResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.BudgetSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.BudgetResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CashFlowMonth": {
+            "type": "object",
+            "properties": {
+                "expenses": {
+                    "type": "number"
+                },
+                "income": {
+                    "type": "number"
+                },
+                "month": {
+                    "type": "integer"
+                },
+                "net": {
+                    "type": "number"
+                },
+                "savingsRate": {
+                    "type": "number"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.CashFlowResponse": {
+            "type": "object",
+            "properties": {
+                "months": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.CashFlowMonth"
+                    }
+                },
+                "net": {
+                    "type": "number"
+                },
+                "savingsRate": {
+                    "type": "number"
+                },
+                "totalExpenses": {
+                    "type": "number"
+                },
+                "totalIncome": {
+                    "type": "number"
+                }
+            }
+        },
+        "handler.CashFlowSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.CashFlowResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CategoryAggregate": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "$ref": "#/definitions/handler.CategoryResponse"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "handler.CategoryItemSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.CategoryResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CategoryListSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.CategoryResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CategoryRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "colorHex": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "colorHex": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "householdId": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CategorySuggestionResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "$ref": "#/definitions/handler.CategoryResponse"
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ItemCategorySuggestionResponse"
+                    }
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CategorySuggestionSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.CategorySuggestionResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ChangePasswordRequest": {
+            "type": "object",
+            "properties": {
+                "currentPassword": {
+                    "type": "string"
+                },
+                "newPassword": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreateAPIKeyRequest": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.CreatedAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "apiKey": {
+                    "$ref": "#/definitions/handler.APIKeyResponse"
+                },
+                "key": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.DashboardSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "month": {
+                    "type": "integer"
+                },
+                "net": {
+                    "type": "number"
+                },
+                "previousTotal": {
+                    "type": "number"
+                },
+                "savingsRate": {
+                    "type": "number"
+                },
+                "topCategories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.CategoryAggregate"
+                    }
+                },
+                "totalIncome": {
+                    "type": "number"
+                },
+                "totalSpent": {
+                    "type": "number"
+                },
+                "variationPct": {
+                    "type": "number"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ExpenseAllocationInput": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "itemIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.ExpenseAllocationResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "itemIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "primary": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.ExpenseAllocationsRequest": {
+            "type": "object",
+            "properties": {
+                "allocations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ExpenseAllocationInput"
+                    }
+                }
+            }
+        },
+        "handler.ExpenseItemResponse": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "totalPrice": {
+                    "type": "number"
+                },
+                "unitPrice": {
+                    "type": "number"
+                }
+            }
+        },
+        "handler.ExpenseItemSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.ExpenseResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ExpenseListSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.ExpensesListResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ExpensePagination": {
+            "type": "object",
+            "properties": {
+                "hasMore": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "nextCursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ExpenseRequest": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "origin": {
+                    "type": "string"
+                },
+                "receipt": {
+                    "$ref": "#/definitions/handler.ReceiptInput"
+                },
+                "recurring": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.ExpenseResponse": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "string"
+                },
+                "allocations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ExpenseAllocationResponse"
+                    }
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "$ref": "#/definitions/handler.CategoryResponse"
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "householdId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "installment": {
+                    "type": "string"
+                },
+                "installmentPurchaseId": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ExpenseItemResponse"
+                    }
+                },
+                "merchantId": {
+                    "type": "string"
+                },
+                "origin": {
+                    "type": "string"
+                },
+                "receipt": {
+                    "$ref": "#/definitions/handler.ReceiptResponse"
+                },
+                "recurrenceIndex": {
+                    "type": "integer"
+                },
+                "recurrenceRuleId": {
+                    "type": "string"
+                },
+                "recurring": {
+                    "type": "boolean"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ExpenseSummary": {
+            "type": "object",
+            "properties": {
+                "averageValue": {
+                    "type": "number"
+                },
+                "totalAmount": {
+                    "type": "number"
+                },
+                "totalCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ExpensesListResponse": {
+            "type": "object",
+            "properties": {
+                "expenses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ExpenseResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handler.ExpensePagination"
+                },
+                "summary": {
+                    "$ref": "#/definitions/handler.ExpenseSummary"
+                }
+            }
+        },
+        "handler.ForgotPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.GenerateMealPlanRequest": {
+            "type": "object",
+            "properties": {
+                "budget": {
+                    "type": "number"
+                },
+                "calorieGoal": {
+                    "type": "integer"
+                },
+                "dietaryPreference": {
+                    "type": "string"
+                },
+                "exclusions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "servings": {
+                    "type": "integer"
+                },
+                "week": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.GoalContributionRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "fromAccountId": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.GoalContributionResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "transferId": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.GoalListSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.GoalResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.GoalProgress": {
+            "type": "object",
+            "properties": {
+                "averageMonthly": {
+                    "type": "number"
+                },
+                "expectedSaved": {
+                    "type": "number"
+                },
+                "monthsLeft": {
+                    "type": "integer"
+                },
+                "percentComplete": {
+                    "type": "number"
+                },
+                "projectedCompletion": {
+                    "type": "string"
+                },
+                "remaining": {
+                    "type": "number"
+                },
+                "requiredMonthly": {
+                    "type": "number"
+                },
+                "saved": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.GoalProgressSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.GoalProgressSummary"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.GoalProgressSummary": {
+            "type": "object",
+            "properties": {
+                "behind": {
+                    "type": "integer"
+                },
+                "goals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.GoalResponse"
+                    }
+                },
+                "onTrack": {
+                    "type": "integer"
+                },
+                "totalRequiredMonthly": {
+                    "type": "number"
+                },
+                "totalSaved": {
+                    "type": "number"
+                },
+                "totalTarget": {
+                    "type": "number"
+                }
+            }
+        },
+        "handler.GoalRequest": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "string"
+                },
+                "deadline": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "targetAmount": {
+                    "type": "number"
+                }
+            }
+        },
+        "handler.GoalResponse": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "string"
+                },
+                "archived": {
+                    "type": "boolean"
+                },
+                "contributions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.GoalContributionResponse"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "deadline": {
+                    "type": "string"
+                },
+                "householdId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "progress": {
+                    "$ref": "#/definitions/handler.GoalProgress"
+                },
+                "targetAmount": {
+                    "type": "number"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.GoalSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.GoalResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.HouseholdInvitationListSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.HouseholdInvitationResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.HouseholdInvitationRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.HouseholdInvitationResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.HouseholdInvitationSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.HouseholdInvitationResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.HouseholdListSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.HouseholdResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.HouseholdMemberResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "joinedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.HouseholdRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.HouseholdResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.HouseholdMemberResponse"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.HouseholdSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.HouseholdResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.IdentityListSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.IdentityResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.IdentityResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastLoginAt": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.IncomeItemSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.IncomeResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.IncomeListSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.IncomesListResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.IncomeRequest": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "recurring": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.IncomeResponse": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "$ref": "#/definitions/handler.CategoryResponse"
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "householdId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "recurrenceIndex": {
+                    "type": "integer"
+                },
+                "recurrenceRuleId": {
+                    "type": "string"
+                },
+                "recurring": {
+                    "type": "boolean"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.IncomeSummary": {
+            "type": "object",
+            "properties": {
+                "totalAmount": {
+                    "type": "number"
+                },
+                "totalCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.IncomesListResponse": {
+            "type": "object",
+            "properties": {
+                "incomes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.IncomeResponse"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/handler.IncomeSummary"
+                }
+            }
+        },
+        "handler.InstallmentPurchaseListSuccess": {
             "type": "object",
             "properties": {
-                "data": {},
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.InstallmentPurchaseResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.InstallmentPurchaseRequest": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "string"
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "installments": {
+                    "type": "integer"
+                },
+                "purchaseDate": {
+                    "type": "string"
+                },
+                "totalAmount": {
+                    "type": "number"
+                }
+            }
+        },
+        "handler.InstallmentPurchaseResponse": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "string"
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "householdId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "installments": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.InstallmentResponse"
+                    }
+                },
+                "purchaseDate": {
+                    "type": "string"
+                },
+                "totalAmount": {
+                    "type": "number"
+                }
+            }
+        },
+        "handler.InstallmentPurchaseSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.InstallmentPurchaseResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.InstallmentResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "expenseId": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "statementMonth": {
+                    "type": "integer"
+                },
+                "statementYear": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ItemCategorySuggestionResponse": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "deviceName": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "allDevices": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.MFACodeRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.MFADisableRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.MFAEnrollResponse": {
+            "type": "object",
+            "properties": {
+                "otpauthUri": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.MFAEnrollSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.MFAEnrollResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.MFARecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recoveryCodes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.MFARecoveryCodesSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.MFARecoveryCodesResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.MFAVerifyRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "deviceName": {
+                    "type": "string"
+                },
+                "mfaToken": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.MealItemResponse": {
+            "type": "object",
+            "properties": {
+                "dayOfWeek": {
+                    "type": "string"
+                },
+                "estimatedCost": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "instructions": {
+                    "type": "string"
+                },
+                "mealType": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.MealPlanResponse": {
+            "type": "object",
+            "properties": {
+                "calorieGoal": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "estimatedCost": {
+                    "type": "number"
+                },
+                "generatedByAi": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isoWeek": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.MealItemResponse"
+                    }
+                }
+            }
+        },
+        "handler.MerchantListSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.MerchantResponse"
+                    }
+                },
                 "message": {
                     "type": "string"
                 }
             }
         },
-        "handler.AuthResponse": {
+        "handler.MerchantResponse": {
             "type": "object",
             "properties": {
-                "expiresAt": {
+                "address": {
                     "type": "string"
                 },
-                "token": {
+                "cnpj": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/handler.UserResponse"
+                "createdAt": {
+                    "type": "string"
+                },
+                "expenseCount": {
+                    "type": "integer"
+                },
+                "householdId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastPurchase": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "number"
                 }
             }
         },
-        "handler.AuthSuccessResponse": {
+        "handler.OIDCLoginRequest": {
+            "type": "object",
+            "properties": {
+                "deviceName": {
+                    "type": "string"
+                },
+                "idToken": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ReceiptDraftListSuccess": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/handler.AuthResponse"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ReceiptDraftResponse"
+                    }
                 },
                 "message": {
                     "type": "string"
                 }
             }
         },
-        "handler.CategoryAggregate": {
+        "handler.ReceiptDraftResponse": {
             "type": "object",
             "properties": {
+                "amount": {
+                    "type": "number"
+                },
                 "category": {
                     "$ref": "#/definitions/handler.CategoryResponse"
                 },
-                "total": {
+                "categoryId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "extractedText": {
+                    "type": "string"
+                },
+                "hasImage": {
+                    "type": "boolean"
+                },
+                "householdId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ReceiptItem"
+                    }
+                },
+                "merchant": {
+                    "type": "string"
+                },
+                "merchantAddress": {
+                    "type": "string"
+                },
+                "merchantCnpj": {
+                    "type": "string"
+                },
+                "ocrConfidence": {
                     "type": "number"
                 }
             }
         },
-        "handler.CategoryItemSuccess": {
+        "handler.ReceiptDraftSuccess": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/handler.CategoryResponse"
+                    "$ref": "#/definitions/handler.ReceiptDraftResponse"
                 },
                 "message": {
                     "type": "string"
                 }
             }
         },
-        "handler.CategoryListSuccess": {
+        "handler.ReceiptInput": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handler.CategoryResponse"
-                    }
+                "extractedText": {
+                    "type": "string"
                 },
-                "message": {
+                "filePath": {
                     "type": "string"
+                },
+                "ocrConfidence": {
+                    "type": "number"
                 }
             }
         },
-        "handler.CategoryRequest": {
+        "handler.ReceiptItem": {
             "type": "object",
             "properties": {
-                "active": {
-                    "type": "boolean"
+                "categoryId": {
+                    "type": "string"
                 },
-                "colorHex": {
+                "description": {
                     "type": "string"
                 },
-                "icon": {
+                "quantity": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "number"
+                },
+                "unitPrice": {
+                    "type": "number"
+                }
+            }
+        },
+        "handler.ReceiptJobResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "createdAt": {
                     "type": "string"
                 },
-                "name": {
+                "draftId": {
                     "type": "string"
                 },
-                "order": {
-                    "type": "integer"
+                "error": {
+                    "type": "string"
                 },
-                "type": {
+                "expenseId": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/handler.ReceiptScanResponse"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "handler.CategoryResponse": {
+        "handler.ReceiptJobSuccess": {
             "type": "object",
             "properties": {
-                "active": {
-                    "type": "boolean"
+                "data": {
+                    "$ref": "#/definitions/handler.ReceiptJobResponse"
                 },
-                "colorHex": {
+                "message": {
                     "type": "string"
-                },
-                "createdAt": {
+                }
+            }
+        },
+        "handler.ReceiptResponse": {
+            "type": "object",
+            "properties": {
+                "extractedText": {
                     "type": "string"
                 },
-                "icon": {
+                "filePath": {
                     "type": "string"
                 },
+                "hasImage": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "ocrConfidence": {
+                    "type": "number"
+                }
+            }
+        },
+        "handler.ReceiptScanRequest": {
+            "type": "object",
+            "properties": {
+                "amountHint": {
+                    "type": "number"
+                },
+                "async": {
+                    "type": "boolean"
+                },
+                "currency": {
                     "type": "string"
                 },
-                "order": {
-                    "type": "integer"
+                "imageBase64": {
+                    "type": "string"
                 },
-                "type": {
+                "locale": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "mode": {
                     "type": "string"
+                },
+                "returnRaw": {
+                    "type": "boolean"
                 }
             }
         },
-        "handler.DashboardSummaryResponse": {
+        "handler.ReceiptScanResponse": {
             "type": "object",
             "properties": {
-                "month": {
-                    "type": "integer"
+                "categoryId": {
+                    "type": "string"
                 },
-                "previousTotal": {
+                "categorySource": {
+                    "type": "string"
+                },
+                "confidence": {
                     "type": "number"
                 },
-                "topCategories": {
+                "currency": {
+                    "type": "string"
+                },
+                "draft": {
+                    "$ref": "#/definitions/handler.ReceiptDraftResponse"
+                },
+                "extractedText": {
+                    "type": "string"
+                },
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handler.CategoryAggregate"
+                        "$ref": "#/definitions/handler.ReceiptItem"
                     }
                 },
-                "totalSpent": {
+                "merchant": {
+                    "type": "string"
+                },
+                "merchantAddress": {
+                    "type": "string"
+                },
+                "merchantCnpj": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "rawModelOutput": {
+                    "type": "string"
+                },
+                "reviewRequired": {
+                    "type": "boolean"
+                },
+                "savedExpense": {
+                    "$ref": "#/definitions/handler.ExpenseResponse"
+                },
+                "suggestedAmount": {
                     "type": "number"
                 },
-                "variationPct": {
+                "suggestedDate": {
+                    "type": "string"
+                },
+                "tokenCostCents": {
+                    "type": "integer"
+                },
+                "tokensUsed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.RecurrenceFutureRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
                     "type": "number"
                 },
-                "year": {
-                    "type": "integer"
+                "categoryId": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "dayOfMonth": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "endDate": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "string"
+                },
+                "interval": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.RecurrenceOccurrenceListSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.RecurrenceOccurrenceResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
                 }
             }
         },
-        "handler.ExpenseRequest": {
+        "handler.RecurrenceOccurrenceRequest": {
             "type": "object",
             "properties": {
                 "amount": {
@@ -1329,319 +10193,319 @@ const docTemplate = `{
                 },
                 "description": {
                     "type": "string"
-                },
-                "origin": {
-                    "type": "string"
-                },
-                "receipt": {
-                    "$ref": "#/definitions/handler.ReceiptInput"
-                },
-                "recurring": {
-                    "type": "boolean"
                 }
             }
         },
-        "handler.ExpenseResponse": {
+        "handler.RecurrenceOccurrenceResponse": {
             "type": "object",
             "properties": {
                 "amount": {
                     "type": "number"
                 },
-                "category": {
-                    "$ref": "#/definitions/handler.CategoryResponse"
-                },
                 "categoryId": {
                     "type": "string"
                 },
-                "createdAt": {
-                    "type": "string"
-                },
                 "date": {
                     "type": "string"
                 },
                 "description": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
-                },
-                "origin": {
+                "entryId": {
                     "type": "string"
                 },
-                "receipt": {
-                    "$ref": "#/definitions/handler.ReceiptResponse"
+                "index": {
+                    "type": "integer"
                 },
-                "recurring": {
+                "skipped": {
                     "type": "boolean"
-                },
-                "updatedAt": {
-                    "type": "string"
                 }
             }
         },
-        "handler.ExpenseSummary": {
+        "handler.RecurrenceRuleListSuccess": {
             "type": "object",
             "properties": {
-                "averageValue": {
-                    "type": "number"
-                },
-                "totalAmount": {
-                    "type": "number"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.RecurrenceRuleResponse"
+                    }
                 },
-                "totalCount": {
-                    "type": "integer"
+                "message": {
+                    "type": "string"
                 }
             }
         },
-        "handler.ExpensesListResponse": {
+        "handler.RecurrenceRuleRequest": {
             "type": "object",
             "properties": {
-                "expenses": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handler.ExpenseResponse"
-                    }
+                "count": {
+                    "type": "integer"
                 },
-                "summary": {
-                    "$ref": "#/definitions/handler.ExpenseSummary"
+                "dayOfMonth": {
+                    "type": "integer"
+                },
+                "endDate": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "string"
+                },
+                "interval": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.GenerateMealPlanRequest": {
+        "handler.RecurrenceRuleResponse": {
             "type": "object",
             "properties": {
-                "budget": {
+                "accountId": {
+                    "type": "string"
+                },
+                "amount": {
                     "type": "number"
                 },
-                "calorieGoal": {
+                "categoryId": {
+                    "type": "string"
+                },
+                "count": {
                     "type": "integer"
                 },
-                "dietaryPreference": {
+                "createdAt": {
                     "type": "string"
                 },
-                "exclusions": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "createdBy": {
+                    "type": "string"
                 },
-                "servings": {
+                "dayOfMonth": {
                     "type": "integer"
                 },
-                "week": {
+                "description": {
+                    "type": "string"
+                },
+                "endDate": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "string"
+                },
+                "householdId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "interval": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "nextOccurrenceAt": {
+                    "type": "string"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "templateId": {
                     "type": "string"
                 }
             }
         },
-        "handler.LoginRequest": {
+        "handler.RecurrenceRuleSuccess": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/handler.RecurrenceRuleResponse"
                 },
-                "password": {
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "handler.LogoutRequest": {
+        "handler.RefreshTokenRequest": {
             "type": "object",
             "properties": {
-                "allDevices": {
-                    "type": "boolean"
+                "deviceName": {
+                    "type": "string"
+                },
+                "refreshToken": {
+                    "type": "string"
                 }
             }
         },
-        "handler.MealItemResponse": {
+        "handler.RegisterRequest": {
             "type": "object",
             "properties": {
-                "dayOfWeek": {
+                "currency": {
                     "type": "string"
                 },
-                "estimatedCost": {
-                    "type": "number"
+                "deviceName": {
+                    "type": "string"
                 },
-                "id": {
+                "email": {
                     "type": "string"
                 },
-                "ingredients": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "language": {
+                    "type": "string"
                 },
-                "instructions": {
+                "monthlyLimit": {
+                    "type": "number"
+                },
+                "name": {
                     "type": "string"
                 },
-                "mealType": {
+                "password": {
                     "type": "string"
                 },
-                "title": {
+                "theme": {
                     "type": "string"
                 }
             }
         },
-        "handler.MealPlanResponse": {
+        "handler.ResetPasswordRequest": {
             "type": "object",
             "properties": {
-                "calorieGoal": {
-                    "type": "integer"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
-                "estimatedCost": {
-                    "type": "number"
-                },
-                "generatedByAi": {
-                    "type": "boolean"
-                },
-                "id": {
+                "password": {
                     "type": "string"
                 },
-                "isoWeek": {
+                "token": {
                     "type": "string"
-                },
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handler.MealItemResponse"
-                    }
                 }
             }
         },
-        "handler.ReceiptInput": {
+        "handler.SessionListSuccess": {
             "type": "object",
             "properties": {
-                "extractedText": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.SessionResponse"
+                    }
                 },
-                "filePath": {
+                "message": {
                     "type": "string"
-                },
-                "ocrConfidence": {
-                    "type": "number"
                 }
             }
         },
-        "handler.ReceiptItem": {
+        "handler.SessionResponse": {
             "type": "object",
             "properties": {
-                "description": {
+                "createdAt": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "number"
-                },
-                "total": {
-                    "type": "number"
+                "current": {
+                    "type": "boolean"
                 },
-                "unitPrice": {
-                    "type": "number"
-                }
-            }
-        },
-        "handler.ReceiptResponse": {
-            "type": "object",
-            "properties": {
-                "extractedText": {
+                "deviceName": {
                     "type": "string"
                 },
-                "filePath": {
+                "expiresAt": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "ocrConfidence": {
-                    "type": "number"
+                "ipAddress": {
+                    "type": "string"
+                },
+                "lastSeenAt": {
+                    "type": "string"
+                },
+                "userAgent": {
+                    "type": "string"
                 }
             }
         },
-        "handler.ReceiptScanRequest": {
+        "handler.StatementItem": {
             "type": "object",
             "properties": {
-                "amountHint": {
+                "amount": {
                     "type": "number"
                 },
-                "currency": {
+                "categoryId": {
                     "type": "string"
                 },
-                "imageBase64": {
+                "date": {
                     "type": "string"
                 },
-                "locale": {
+                "description": {
                     "type": "string"
                 },
-                "returnRaw": {
-                    "type": "boolean"
+                "expenseId": {
+                    "type": "string"
+                },
+                "installment": {
+                    "type": "string"
+                },
+                "installmentPurchaseId": {
+                    "type": "string"
                 }
             }
         },
-        "handler.ReceiptScanResponse": {
+        "handler.StatementListSuccess": {
             "type": "object",
             "properties": {
-                "confidence": {
-                    "type": "number"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.StatementResponse"
+                    }
                 },
-                "currency": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.StatementResponse": {
+            "type": "object",
+            "properties": {
+                "accountId": {
                     "type": "string"
                 },
-                "extractedText": {
+                "closingDate": {
+                    "type": "string"
+                },
+                "dueDate": {
                     "type": "string"
                 },
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handler.ReceiptItem"
+                        "$ref": "#/definitions/handler.StatementItem"
                     }
                 },
-                "model": {
-                    "type": "string"
-                },
-                "rawModelOutput": {
-                    "type": "string"
-                },
-                "savedExpense": {
-                    "$ref": "#/definitions/handler.ExpenseResponse"
-                },
-                "suggestedAmount": {
-                    "type": "number"
+                "month": {
+                    "type": "integer"
                 },
-                "suggestedDate": {
+                "periodStart": {
                     "type": "string"
                 },
-                "tokenCostCents": {
-                    "type": "integer"
-                },
-                "tokensUsed": {
+                "total": {
+                    "type": "number"
+                },
+                "year": {
                     "type": "integer"
                 }
             }
         },
-        "handler.RegisterRequest": {
+        "handler.SuggestCategoryRequest": {
             "type": "object",
             "properties": {
-                "currency": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "language": {
+                "description": {
                     "type": "string"
                 },
-                "monthlyLimit": {
-                    "type": "number"
-                },
-                "name": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "password": {
+                "merchantId": {
                     "type": "string"
                 },
-                "theme": {
-                    "type": "string"
+                "useAi": {
+                    "type": "boolean"
                 }
             }
         },
@@ -1788,9 +10652,149 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.TransferListSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.TransferResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.TransferRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "fromAccountId": {
+                    "type": "string"
+                },
+                "toAccountId": {
+                    "type": "string"
+                },
+                "toAmount": {
+                    "type": "number"
+                }
+            }
+        },
+        "handler.TransferResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "fromAccountId": {
+                    "type": "string"
+                },
+                "householdId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "toAccountId": {
+                    "type": "string"
+                },
+                "toAmount": {
+                    "type": "number"
+                }
+            }
+        },
+        "handler.TransferSuccess": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.TransferResponse"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.UpdateAccountRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "closingDay": {
+                    "type": "integer"
+                },
+                "dueDay": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "openingBalance": {
+                    "type": "number"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.UpdateBudgetRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "rollover": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.UpdateConfigRequest": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "monthlyLimit": {
+                    "type": "number"
+                },
+                "notificationsEnabled": {
+                    "type": "boolean"
+                },
+                "theme": {
+                    "type": "string"
+                }
+            }
+        },
         "handler.UpdateExpenseRequest": {
             "type": "object",
             "properties": {
+                "accountId": {
+                    "type": "string"
+                },
                 "amount": {
                     "type": "number"
                 },
@@ -1812,11 +10816,122 @@ const docTemplate = `{
                 "recurring": {
                     "type": "boolean"
                 },
+                "removeAccount": {
+                    "type": "boolean"
+                },
                 "removeReceipt": {
                     "type": "boolean"
                 }
             }
         },
+        "handler.UpdateGoalRequest": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "string"
+                },
+                "archived": {
+                    "type": "boolean"
+                },
+                "deadline": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "removeAccount": {
+                    "type": "boolean"
+                },
+                "targetAmount": {
+                    "type": "number"
+                }
+            }
+        },
+        "handler.UpdateHouseholdMemberRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.UpdateIncomeRequest": {
+            "type": "object",
+            "properties": {
+                "accountId": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "recurring": {
+                    "type": "boolean"
+                },
+                "removeAccount": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.UpdateProfileRequest": {
+            "type": "object",
+            "properties": {
+                "currentPassword": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.UpdateReceiptDraftRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ReceiptItem"
+                    }
+                },
+                "merchant": {
+                    "type": "string"
+                },
+                "merchantAddress": {
+                    "type": "string"
+                },
+                "merchantCnpj": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.UpdateUserRoleRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
         "handler.UserConfigResponse": {
             "type": "object",
             "properties": {
@@ -1860,22 +10975,48 @@ const docTemplate = `{
                 "createdAt": {
                     "type": "string"
                 },
+                "deletionDueAt": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
+                "emailVerified": {
+                    "type": "boolean"
+                },
+                "emailVerifiedAt": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
                 "lastLogin": {
                     "type": "string"
                 },
+                "mfaEnabled": {
+                    "type": "boolean"
+                },
                 "name": {
                     "type": "string"
                 },
+                "pendingEmail": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
                 "updatedAt": {
                     "type": "string"
                 }
             }
+        },
+        "handler.VerifyEmailRequest": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
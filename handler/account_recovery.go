@@ -0,0 +1,369 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/Pmmvito/Golang-Api-Exemple/service/mailer"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	passwordResetTTL     = time.Hour
+	emailVerificationTTL = 48 * time.Hour
+)
+
+var errUserTokenInvalid = errors.New("token inválido ou expirado")
+
+// ForgotPasswordHandler godoc
+// @Summary Solicitar redefinição de senha
+// @Description Envia por email um token de uso único para redefinir a senha. A resposta é a mesma para emails cadastrados ou não.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body ForgotPasswordRequest true "Email da conta"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/password/forgot [post]
+func ForgotPasswordHandler(ctx *gin.Context) {
+	var request ForgotPasswordRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	request.Normalize()
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	const message = "se o email estiver cadastrado, enviaremos as instruções de recuperação"
+
+	user := schemas.User{}
+	if err := getDB().Where("email = ?", request.Email).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondSuccess(ctx, message, nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao buscar usuário", err.Error())
+		return
+	}
+
+	var rawToken string
+	if err := getDB().Transaction(func(tx *gorm.DB) error {
+		token, err := issueUserToken(tx, user.ID, schemas.UserTokenPasswordReset, passwordResetTTL)
+		if err != nil {
+			return err
+		}
+		rawToken = token
+		return nil
+	}); err != nil {
+		respondError(ctx, 500, "erro ao gerar token de recuperação", err.Error())
+		return
+	}
+
+	if err := sendPasswordResetEmail(ctx.Request.Context(), &user, rawToken); err != nil {
+		getLogger().WarnF("não foi possível enviar email de recuperação: %v", err)
+	}
+
+	respondSuccess(ctx, message, nil)
+}
+
+// ResetPasswordHandler godoc
+// @Summary Redefinir senha
+// @Description Define uma nova senha a partir do token recebido por email, encerra todas as sessões do usuário e revoga suas chaves de API
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body ResetPasswordRequest true "Token e nova senha"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/password/reset [post]
+func ResetPasswordHandler(ctx *gin.Context) {
+	var request ResetPasswordRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+	if err != nil {
+		respondError(ctx, 500, "erro ao gerar hash de senha", err.Error())
+		return
+	}
+
+	var resetUser schemas.User
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		token, err := consumeUserToken(tx, request.Token, schemas.UserTokenPasswordReset)
+		if err != nil {
+			return err
+		}
+
+		user := schemas.User{}
+		if err := tx.First(&user, "id = ?", token.UserID).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{"password_hash": string(passwordHash), "locked_until": nil}
+		if user.EmailVerifiedAt == nil {
+			// receber o token por email comprova a posse do endereço
+			updates["email_verified_at"] = time.Now()
+		}
+		if err := tx.Model(&user).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := promoteVerifiedAdmins(tx, user.ID); err != nil {
+			return err
+		}
+
+		if err := invalidateUserSessions(tx, user.ID, ""); err != nil {
+			return err
+		}
+		if err := revokeUserAPIKeys(tx, user.ID); err != nil {
+			return err
+		}
+		resetUser = user
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, errUserTokenInvalid) {
+			respondError(ctx, 400, err.Error(), nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao redefinir senha", err.Error())
+		return
+	}
+
+	if err := clearLoginFailures(ctx.Request.Context(), resetUser.ID, resetUser.Email); err != nil {
+		getLogger().WarnF("não foi possível limpar tentativas de login: %v", err)
+	}
+
+	respondSuccess(ctx, "senha redefinida", nil)
+}
+
+// VerifyEmailHandler godoc
+// @Summary Confirmar email
+// @Description Confirma a posse do email a partir do token enviado no cadastro ou na troca de email; neste caso o novo endereço passa a valer
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body VerifyEmailRequest true "Token de verificação"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/verify-email [post]
+func VerifyEmailHandler(ctx *gin.Context) {
+	var request VerifyEmailRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	err := getDB().Transaction(func(tx *gorm.DB) error {
+		token, err := consumeUserToken(tx, request.Token, schemas.UserTokenEmailVerification)
+		if errors.Is(err, errUserTokenInvalid) {
+			token, err = consumeUserToken(tx, request.Token, schemas.UserTokenEmailChange)
+			if err != nil {
+				return err
+			}
+			if err := applyPendingEmail(tx, token.UserID); err != nil {
+				return err
+			}
+			return promoteVerifiedAdmins(tx, token.UserID)
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&schemas.User{}).
+			Where("id = ? AND email_verified_at IS NULL", token.UserID).
+			Updates(map[string]interface{}{"email_verified_at": time.Now()}).Error; err != nil {
+			return err
+		}
+		return promoteVerifiedAdmins(tx, token.UserID)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, errUserTokenInvalid):
+			respondError(ctx, 400, err.Error(), nil)
+		case errors.Is(err, errEmailTaken):
+			respondError(ctx, 409, err.Error(), nil)
+		default:
+			respondError(ctx, 500, "erro ao verificar email", err.Error())
+		}
+		return
+	}
+
+	respondSuccess(ctx, "email verificado", nil)
+}
+
+// ResendVerificationHandler godoc
+// @Summary Reenviar verificação de email
+// @Description Gera um novo token de verificação e o envia para o email do usuário autenticado
+// @Tags Auth
+// @Security Bearer
+// @Produce json
+// @Success 200 {object} APISuccess
+// @Failure 401 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/verify-email/resend [post]
+func ResendVerificationHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	if user.EmailVerifiedAt != nil {
+		respondError(ctx, 409, "email já verificado", nil)
+		return
+	}
+
+	var rawToken string
+	if err := getDB().Transaction(func(tx *gorm.DB) error {
+		token, err := issueUserToken(tx, user.ID, schemas.UserTokenEmailVerification, emailVerificationTTL)
+		if err != nil {
+			return err
+		}
+		rawToken = token
+		return nil
+	}); err != nil {
+		respondError(ctx, 500, "erro ao gerar token de verificação", err.Error())
+		return
+	}
+
+	if err := sendVerificationEmail(ctx.Request.Context(), user, rawToken); err != nil {
+		respondError(ctx, 500, "erro ao enviar email de verificação", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "email de verificação enviado", nil)
+}
+
+// issueUserToken invalida tokens pendentes com a mesma finalidade e emite um novo,
+// devolvendo o valor em texto puro que deve ser enviado ao usuário.
+func issueUserToken(tx *gorm.DB, userID uuid.UUID, purpose schemas.UserTokenPurpose, ttl time.Duration) (string, error) {
+	now := time.Now()
+	if err := tx.Model(&schemas.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Updates(map[string]interface{}{"used_at": now}).Error; err != nil {
+		return "", err
+	}
+
+	raw, err := generateSessionToken()
+	if err != nil {
+		return "", err
+	}
+
+	token := schemas.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: schemas.HashToken(raw),
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := tx.Create(&token).Error; err != nil {
+		return "", err
+	}
+	return raw, nil
+}
+
+// consumeUserToken marca o token como utilizado de forma atômica, garantindo o uso único.
+func consumeUserToken(tx *gorm.DB, raw string, purpose schemas.UserTokenPurpose) (*schemas.UserToken, error) {
+	token := schemas.UserToken{}
+	if err := tx.Where("token_hash = ? AND purpose = ?", schemas.HashToken(raw), purpose).First(&token).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errUserTokenInvalid
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	result := tx.Model(&schemas.UserToken{}).
+		Where("id = ? AND used_at IS NULL AND expires_at > ?", token.ID, now).
+		Updates(map[string]interface{}{"used_at": now})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errUserTokenInvalid
+	}
+
+	token.UsedAt = &now
+	return &token, nil
+}
+
+// invalidateUserSessions encerra as sessões e refresh tokens do usuário, exceto a sessão informada.
+func invalidateUserSessions(tx *gorm.DB, userID uuid.UUID, keepSessionToken string) error {
+	var sessionTokens []string
+	query := tx.Model(&schemas.Session{}).Where("user_id = ? AND valid = ?", userID, true)
+	if keepSessionToken != "" {
+		query = query.Where("token <> ?", keepSessionToken)
+	}
+	if err := query.Pluck("token", &sessionTokens).Error; err != nil {
+		return err
+	}
+
+	for _, token := range sessionTokens {
+		if err := revokeRefreshTokensForSession(tx, token); err != nil {
+			return err
+		}
+	}
+
+	if keepSessionToken == "" {
+		if err := revokeUserRefreshTokens(tx, userID); err != nil {
+			return err
+		}
+	}
+
+	if len(sessionTokens) == 0 {
+		return nil
+	}
+	return tx.Model(&schemas.Session{}).
+		Where("token IN ?", sessionTokens).
+		Updates(map[string]interface{}{"valid": false}).Error
+}
+
+func sendVerificationEmail(ctx context.Context, user *schemas.User, rawToken string) error {
+	body := fmt.Sprintf("Olá, %s!\n\nConfirme seu email acessando o link abaixo:\n%s\n\nO link expira em %d horas.\n",
+		user.Name, buildAppLink("/verify-email", rawToken), int(emailVerificationTTL.Hours()))
+	return sendMail(ctx, user.Email, "Confirme seu email", body)
+}
+
+func sendPasswordResetEmail(ctx context.Context, user *schemas.User, rawToken string) error {
+	body := fmt.Sprintf("Olá, %s!\n\nRecebemos um pedido para redefinir sua senha. Use o link abaixo:\n%s\n\nO link expira em %d minutos. Se você não fez esse pedido, ignore este email.\n",
+		user.Name, buildAppLink("/reset-password", rawToken), int(passwordResetTTL.Minutes()))
+	return sendMail(ctx, user.Email, "Redefinição de senha", body)
+}
+
+func sendMail(ctx context.Context, to, subject, body string) error {
+	sendCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	return getMailer().Send(sendCtx, mailer.Message{
+		To:      []string{to},
+		Subject: subject,
+		Body:    body,
+	})
+}
+
+func buildAppLink(path, rawToken string) string {
+	base := getAppBaseURL()
+	if base == "" {
+		return "token: " + rawToken
+	}
+	return base + path + "?token=" + url.QueryEscape(rawToken)
+}
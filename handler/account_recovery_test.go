@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+)
+
+func TestConsumeUserToken(t *testing.T) {
+	setupTestDB(t)
+	user := createTestUser(t, "ana@example.com")
+
+	t.Run("uso único", func(t *testing.T) {
+		raw, err := issueUserToken(getDB(), user.ID, schemas.UserTokenPasswordReset, time.Hour)
+		if err != nil {
+			t.Fatalf("issueUserToken: %v", err)
+		}
+
+		token, err := consumeUserToken(getDB(), raw, schemas.UserTokenPasswordReset)
+		if err != nil {
+			t.Fatalf("primeiro uso: %v", err)
+		}
+		if token.UserID != user.ID || token.UsedAt == nil {
+			t.Fatalf("token consumido inesperado: %+v", token)
+		}
+
+		if _, err := consumeUserToken(getDB(), raw, schemas.UserTokenPasswordReset); !errors.Is(err, errUserTokenInvalid) {
+			t.Fatalf("reuso: erro = %v, esperado errUserTokenInvalid", err)
+		}
+	})
+
+	t.Run("expirado", func(t *testing.T) {
+		raw, err := issueUserToken(getDB(), user.ID, schemas.UserTokenEmailVerification, time.Hour)
+		if err != nil {
+			t.Fatalf("issueUserToken: %v", err)
+		}
+		if err := getDB().Model(&schemas.UserToken{}).
+			Where("token_hash = ?", schemas.HashToken(raw)).
+			Update("expires_at", time.Now().Add(-time.Minute)).Error; err != nil {
+			t.Fatalf("erro expirando token: %v", err)
+		}
+
+		if _, err := consumeUserToken(getDB(), raw, schemas.UserTokenEmailVerification); !errors.Is(err, errUserTokenInvalid) {
+			t.Fatalf("erro = %v, esperado errUserTokenInvalid", err)
+		}
+	})
+
+	t.Run("outra finalidade", func(t *testing.T) {
+		raw, err := issueUserToken(getDB(), user.ID, schemas.UserTokenEmailVerification, time.Hour)
+		if err != nil {
+			t.Fatalf("issueUserToken: %v", err)
+		}
+
+		if _, err := consumeUserToken(getDB(), raw, schemas.UserTokenPasswordReset); !errors.Is(err, errUserTokenInvalid) {
+			t.Fatalf("erro = %v, esperado errUserTokenInvalid", err)
+		}
+		if _, err := consumeUserToken(getDB(), raw, schemas.UserTokenEmailVerification); err != nil {
+			t.Fatalf("token da finalidade correta deveria ser aceito: %v", err)
+		}
+	})
+
+	t.Run("novo token invalida o anterior", func(t *testing.T) {
+		first, err := issueUserToken(getDB(), user.ID, schemas.UserTokenPasswordReset, time.Hour)
+		if err != nil {
+			t.Fatalf("issueUserToken: %v", err)
+		}
+		second, err := issueUserToken(getDB(), user.ID, schemas.UserTokenPasswordReset, time.Hour)
+		if err != nil {
+			t.Fatalf("issueUserToken: %v", err)
+		}
+
+		if _, err := consumeUserToken(getDB(), first, schemas.UserTokenPasswordReset); !errors.Is(err, errUserTokenInvalid) {
+			t.Fatalf("token anterior: erro = %v, esperado errUserTokenInvalid", err)
+		}
+		if _, err := consumeUserToken(getDB(), second, schemas.UserTokenPasswordReset); err != nil {
+			t.Fatalf("token novo: %v", err)
+		}
+	})
+
+	t.Run("desconhecido", func(t *testing.T) {
+		if _, err := consumeUserToken(getDB(), "nao-existe", schemas.UserTokenPasswordReset); !errors.Is(err, errUserTokenInvalid) {
+			t.Fatalf("erro = %v, esperado errUserTokenInvalid", err)
+		}
+	})
+}
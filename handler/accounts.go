@@ -0,0 +1,803 @@
+package handler
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var errAccountScope = errors.New("conta não pertence ao usuário ou está desativada")
+var errTransferGoalContribution = errors.New("a transferência foi gerada por um aporte; remova o aporte da meta")
+
+// ListAccountsHandler godoc
+// @Summary Listar contas
+// @Description Lista as contas e carteiras do escopo atual com o saldo atual de cada uma (saldo inicial + receitas - despesas +/- transferências)
+// @Tags Contas
+// @Security Bearer
+// @Produce json
+// @Param includeInactive query bool false "Inclui contas desativadas (padrão false)"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} AccountListSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /accounts [get]
+func ListAccountsHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	query := getDataScope(ctx, user).apply(getDB(), "accounts")
+	if raw := ctx.Query("includeInactive"); raw != "" {
+		includeInactive, err := strconv.ParseBool(raw)
+		if err != nil {
+			respondError(ctx, 400, "includeInactive inválido", nil)
+			return
+		}
+		if !includeInactive {
+			query = query.Where("active = ?", true)
+		}
+	} else {
+		query = query.Where("active = ?", true)
+	}
+
+	var accounts []schemas.Account
+	if err := query.Order("name ASC").Find(&accounts).Error; err != nil {
+		respondError(ctx, 500, "erro ao listar contas", err.Error())
+		return
+	}
+
+	movements, err := accountMovements(getDB(), accountIDs(accounts), time.Time{})
+	if err != nil {
+		respondError(ctx, 500, "erro ao calcular saldos", err.Error())
+		return
+	}
+
+	responses := make([]AccountResponse, len(accounts))
+	for i := range accounts {
+		responses[i] = toAccountResponse(&accounts[i], accounts[i].OpeningBalance+movements[accounts[i].ID])
+	}
+
+	respondSuccess(ctx, "contas", responses)
+}
+
+// CreateAccountHandler godoc
+// @Summary Criar conta
+// @Description Cria uma conta corrente, poupança, cartão de crédito ou carteira de dinheiro. Sem currency, usa a moeda configurada pelo usuário. Cartões de crédito exigem closingDay e dueDay, usados para montar as faturas.
+// @Tags Contas
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body AccountRequest true "Dados da conta"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} AccountSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /accounts [post]
+func CreateAccountHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	var request AccountRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+	if request.Currency == "" {
+		request.Currency = userCurrency(user)
+	}
+
+	account := schemas.Account{
+		UserID:         user.ID,
+		HouseholdID:    scope.HouseholdID,
+		Name:           request.Name,
+		Type:           schemas.AccountType(request.Type),
+		Currency:       request.Currency,
+		OpeningBalance: request.OpeningBalance,
+		ClosingDay:     request.ClosingDay,
+		DueDay:         request.DueDay,
+		Active:         true,
+	}
+	if err := getDB().Create(&account).Error; err != nil {
+		respondError(ctx, 500, "erro ao criar conta", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "conta criada", toAccountResponse(&account, account.OpeningBalance))
+}
+
+// GetAccountHandler godoc
+// @Summary Buscar conta
+// @Description Retorna uma conta com o saldo atual
+// @Tags Contas
+// @Security Bearer
+// @Produce json
+// @Param id path string true "Identificador da conta"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} AccountSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /accounts/{id} [get]
+func GetAccountHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	accountID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	account := schemas.Account{}
+	if err := getDataScope(ctx, user).apply(getDB(), "accounts").Where("id = ?", accountID).First(&account).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "conta não encontrada", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao carregar conta", err.Error())
+		return
+	}
+
+	movements, err := accountMovements(getDB(), []uuid.UUID{account.ID}, time.Time{})
+	if err != nil {
+		respondError(ctx, 500, "erro ao calcular saldo", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "conta", toAccountResponse(&account, account.OpeningBalance+movements[account.ID]))
+}
+
+// UpdateAccountHandler godoc
+// @Summary Atualizar conta
+// @Description Atualiza nome, tipo, saldo inicial, dias de fechamento e vencimento (cartões de crédito) ou status de uma conta. A moeda não pode ser alterada.
+// @Tags Contas
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "Identificador da conta"
+// @Param body body UpdateAccountRequest true "Campos para atualização"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} AccountSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /accounts/{id} [put]
+func UpdateAccountHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	accountID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	var request UpdateAccountRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	account := schemas.Account{}
+	if err := scope.apply(getDB(), "accounts").Where("id = ?", accountID).First(&account).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "conta não encontrada", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao carregar conta", err.Error())
+		return
+	}
+
+	updates := map[string]interface{}{}
+	if request.Name != nil {
+		updates["name"] = strings.TrimSpace(*request.Name)
+	}
+	if request.Type != nil {
+		updates["type"] = *request.Type
+	}
+	if request.OpeningBalance != nil {
+		updates["opening_balance"] = *request.OpeningBalance
+	}
+	if request.Active != nil {
+		updates["active"] = *request.Active
+	}
+
+	accountType, closingDay, dueDay := account.Type, account.ClosingDay, account.DueDay
+	if request.Type != nil {
+		accountType = schemas.AccountType(*request.Type)
+	}
+	if request.ClosingDay != nil {
+		closingDay = *request.ClosingDay
+	}
+	if request.DueDay != nil {
+		dueDay = *request.DueDay
+	}
+	if accountType == schemas.AccountTypeCreditCard {
+		if closingDay == 0 || dueDay == 0 {
+			respondError(ctx, 400, "closingDay e dueDay são obrigatórios para cartões de crédito", nil)
+			return
+		}
+		updates["closing_day"] = closingDay
+		updates["due_day"] = dueDay
+	} else {
+		updates["closing_day"] = 0
+		updates["due_day"] = 0
+	}
+
+	if err := getDB().Model(&account).Updates(updates).Error; err != nil {
+		respondError(ctx, 500, "erro ao atualizar conta", err.Error())
+		return
+	}
+	if err := getDB().First(&account, "id = ?", account.ID).Error; err != nil {
+		respondError(ctx, 500, "erro ao carregar conta atualizada", err.Error())
+		return
+	}
+
+	movements, err := accountMovements(getDB(), []uuid.UUID{account.ID}, time.Time{})
+	if err != nil {
+		respondError(ctx, 500, "erro ao calcular saldo", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "conta atualizada", toAccountResponse(&account, account.OpeningBalance+movements[account.ID]))
+}
+
+// DeactivateAccountHandler godoc
+// @Summary Desativar conta
+// @Description Marca a conta como inativa; o histórico é mantido, mas ela deixa de aceitar novos lançamentos e transferências
+// @Tags Contas
+// @Security Bearer
+// @Param id path string true "Identificador da conta"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /accounts/{id} [delete]
+func DeactivateAccountHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	accountID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	result := scope.apply(getDB().Model(&schemas.Account{}), "accounts").
+		Where("id = ?", accountID).
+		Updates(map[string]interface{}{"active": false})
+	if result.Error != nil {
+		respondError(ctx, 500, "erro ao desativar conta", result.Error.Error())
+		return
+	}
+	if result.RowsAffected == 0 {
+		respondError(ctx, 404, "conta não encontrada", nil)
+		return
+	}
+
+	respondSuccess(ctx, "conta desativada", nil)
+}
+
+// AccountBalancesHandler godoc
+// @Summary Histórico de saldos
+// @Description Retorna, para cada conta do escopo (ou apenas a informada), o saldo no início do período, cada receita, despesa e transferência do período com o saldo acumulado após ela e o saldo final. Sem from/to, o período é o mês informado (ou o atual).
+// @Tags Contas
+// @Security Bearer
+// @Produce json
+// @Param month query int false "Mês (1-12), ignorado quando from/to são informados"
+// @Param year query int false "Ano, ignorado quando from/to são informados"
+// @Param from query string false "Data inicial (inclusiva), ex.: 2024-01-01"
+// @Param to query string false "Data final (inclusiva), ex.: 2024-03-31"
+// @Param accountId query string false "Limita o histórico a uma conta"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} AccountBalanceListSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /accounts/balances [get]
+func AccountBalancesHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	filter, err := buildExpenseFilter(ctx)
+	if err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	query := getDataScope(ctx, user).apply(getDB(), "accounts")
+	if filter.AccountID != nil {
+		query = query.Where("id = ?", *filter.AccountID)
+	}
+	var accounts []schemas.Account
+	if err := query.Order("name ASC").Find(&accounts).Error; err != nil {
+		respondError(ctx, 500, "erro ao listar contas", err.Error())
+		return
+	}
+
+	ids := accountIDs(accounts)
+	before, err := accountMovements(getDB(), ids, filter.From)
+	if err != nil {
+		respondError(ctx, 500, "erro ao calcular saldos", err.Error())
+		return
+	}
+	current, err := accountMovements(getDB(), ids, time.Time{})
+	if err != nil {
+		respondError(ctx, 500, "erro ao calcular saldos", err.Error())
+		return
+	}
+	entries, err := accountEntries(getDB(), ids, filter.From, filter.To)
+	if err != nil {
+		respondError(ctx, 500, "erro ao carregar movimentações", err.Error())
+		return
+	}
+
+	histories := make([]AccountBalanceHistory, 0, len(accounts))
+	for i := range accounts {
+		account := &accounts[i]
+		balance := account.OpeningBalance + before[account.ID]
+		history := AccountBalanceHistory{
+			Account:      toAccountResponse(account, account.OpeningBalance+current[account.ID]),
+			StartBalance: roundFloat(balance),
+			Entries:      []AccountBalanceEntry{},
+		}
+		for _, entry := range entries[account.ID] {
+			balance += entry.Amount
+			entry.Amount = roundFloat(entry.Amount)
+			entry.Balance = roundFloat(balance)
+			history.Entries = append(history.Entries, entry)
+		}
+		history.EndBalance = roundFloat(balance)
+		histories = append(histories, history)
+	}
+
+	respondSuccess(ctx, "saldos", histories)
+}
+
+// ListTransfersHandler godoc
+// @Summary Listar transferências
+// @Description Lista as transferências do período, da mais recente para a mais antiga. Sem from/to, o período é o mês informado (ou o atual).
+// @Tags Contas
+// @Security Bearer
+// @Produce json
+// @Param month query int false "Mês (1-12), ignorado quando from/to são informados"
+// @Param year query int false "Ano, ignorado quando from/to são informados"
+// @Param from query string false "Data inicial (inclusiva), ex.: 2024-01-01"
+// @Param to query string false "Data final (inclusiva), ex.: 2024-03-31"
+// @Param accountId query string false "Transferências que saem ou entram nesta conta"
+// @Param limit query int false "Quantidade máxima de transferências (padrão 50, máximo 200)"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} TransferListSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /transfers [get]
+func ListTransfersHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	filter, err := buildExpenseFilter(ctx)
+	if err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	query := getDataScope(ctx, user).apply(getDB(), "transfers")
+	if !filter.From.IsZero() {
+		query = query.Where("date >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("date < ?", filter.To)
+	}
+	if filter.AccountID != nil {
+		query = query.Where("from_account_id = ? OR to_account_id = ?", *filter.AccountID, *filter.AccountID)
+	}
+
+	var transfers []schemas.Transfer
+	if err := query.Order("date DESC").Order("id DESC").Limit(filter.Limit).Find(&transfers).Error; err != nil {
+		respondError(ctx, 500, "erro ao listar transferências", err.Error())
+		return
+	}
+
+	responses := make([]TransferResponse, len(transfers))
+	for i := range transfers {
+		responses[i] = toTransferResponse(&transfers[i])
+	}
+
+	respondSuccess(ctx, "transferências", responses)
+}
+
+// CreateTransferHandler godoc
+// @Summary Criar transferência
+// @Description Move dinheiro entre duas contas ativas do escopo. Transferências alteram os saldos, mas não contam como despesa nem receita. Entre contas de moedas diferentes, toAmount (valor creditado no destino) é obrigatório.
+// @Tags Contas
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body TransferRequest true "Dados da transferência"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} TransferSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /transfers [post]
+func CreateTransferHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	var request TransferRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	fromID, err := uuid.Parse(request.FromAccountID)
+	if err != nil {
+		respondError(ctx, 400, "fromAccountId inválido", nil)
+		return
+	}
+	toID, err := uuid.Parse(request.ToAccountID)
+	if err != nil {
+		respondError(ctx, 400, "toAccountId inválido", nil)
+		return
+	}
+
+	var accounts []schemas.Account
+	if err := scope.apply(getDB(), "accounts").
+		Where("id IN ? AND active = ?", []uuid.UUID{fromID, toID}, true).
+		Find(&accounts).Error; err != nil {
+		respondError(ctx, 500, "erro ao carregar contas", err.Error())
+		return
+	}
+	if len(accounts) != 2 {
+		respondError(ctx, 403, errAccountScope.Error(), nil)
+		return
+	}
+
+	toAmount := request.Amount
+	if accounts[0].Currency != accounts[1].Currency {
+		if request.ToAmount == nil {
+			respondError(ctx, 400, "toAmount é obrigatório entre contas de moedas diferentes", nil)
+			return
+		}
+		toAmount = *request.ToAmount
+	}
+
+	date, err := parseDate(request.Date)
+	if err != nil {
+		respondError(ctx, 400, "data inválida", err.Error())
+		return
+	}
+
+	transfer := schemas.Transfer{
+		UserID:        user.ID,
+		HouseholdID:   scope.HouseholdID,
+		FromAccountID: fromID,
+		ToAccountID:   toID,
+		Amount:        request.Amount,
+		ToAmount:      toAmount,
+		Date:          date,
+		Description:   strings.TrimSpace(request.Description),
+	}
+	if err := getDB().Create(&transfer).Error; err != nil {
+		respondError(ctx, 500, "erro ao criar transferência", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "transferência criada", toTransferResponse(&transfer))
+}
+
+// DeleteTransferHandler godoc
+// @Summary Remover transferência
+// @Description Exclui uma transferência, desfazendo seu efeito nos saldos. Transferências geradas por aportes em metas só saem com a remoção do aporte.
+// @Tags Contas
+// @Security Bearer
+// @Param id path string true "Identificador da transferência"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /transfers/{id} [delete]
+func DeleteTransferHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	transferID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		transfer := schemas.Transfer{}
+		if err := scope.apply(tx, "transfers").Where("id = ?", transferID).First(&transfer).Error; err != nil {
+			return err
+		}
+		// o aporte continuaria contando na meta sem a transferência que o movimentou
+		var contributions int64
+		if err := tx.Model(&schemas.GoalContribution{}).Where("transfer_id = ?", transfer.ID).Count(&contributions).Error; err != nil {
+			return err
+		}
+		if contributions > 0 {
+			return errTransferGoalContribution
+		}
+		return tx.Delete(&transfer).Error
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			respondError(ctx, 404, "transferência não encontrada", nil)
+		case errors.Is(err, errTransferGoalContribution):
+			respondError(ctx, 409, err.Error(), nil)
+		default:
+			respondError(ctx, 500, "erro ao remover transferência", err.Error())
+		}
+		return
+	}
+
+	respondSuccess(ctx, "transferência removida", nil)
+}
+
+// accountInScope confere se a conta pertence ao escopo e ainda está ativa.
+func accountInScope(db *gorm.DB, scope dataScope, accountID uuid.UUID) bool {
+	var count int64
+	if err := scope.apply(db.Model(&schemas.Account{}), "accounts").
+		Where("id = ? AND active = ?", accountID, true).
+		Count(&count).Error; err != nil {
+		return false
+	}
+	return count > 0
+}
+
+// parseRequestAccount valida o accountId opcional de despesas e receitas, respondendo 400/403 quando inválido.
+func parseRequestAccount(ctx *gin.Context, db *gorm.DB, scope dataScope, raw string) (*uuid.UUID, bool) {
+	if raw == "" {
+		return nil, true
+	}
+	accountID, err := uuid.Parse(raw)
+	if err != nil {
+		respondError(ctx, 400, "accountId inválido", nil)
+		return nil, false
+	}
+	if !accountInScope(db, scope, accountID) {
+		respondError(ctx, 403, errAccountScope.Error(), nil)
+		return nil, false
+	}
+	return &accountID, true
+}
+
+func userCurrency(user *schemas.User) string {
+	if user.Config != nil && user.Config.Currency != "" {
+		return user.Config.Currency
+	}
+	config := schemas.UserConfig{}
+	if err := getDB().First(&config, "user_id = ?", user.ID).Error; err == nil && config.Currency != "" {
+		return config.Currency
+	}
+	return "BRL"
+}
+
+func accountIDs(accounts []schemas.Account) []uuid.UUID {
+	ids := make([]uuid.UUID, len(accounts))
+	for i := range accounts {
+		ids[i] = accounts[i].ID
+	}
+	return ids
+}
+
+// accountMovements soma, por conta, receitas e transferências recebidas menos despesas e transferências
+// enviadas com data anterior a before (todas, quando before é zero). O saldo é OpeningBalance mais esse valor.
+func accountMovements(db *gorm.DB, ids []uuid.UUID, before time.Time) (map[uuid.UUID]float64, error) {
+	totals := map[uuid.UUID]float64{}
+	if len(ids) == 0 {
+		return totals, nil
+	}
+
+	sources := []struct {
+		model  interface{}
+		column string
+		amount string
+		sign   float64
+	}{
+		{&schemas.Income{}, "account_id", "amount", 1},
+		{&schemas.Expense{}, "account_id", "amount", -1},
+		{&schemas.Transfer{}, "to_account_id", "to_amount", 1},
+		{&schemas.Transfer{}, "from_account_id", "amount", -1},
+	}
+	for _, source := range sources {
+		var rows []struct {
+			AccountID uuid.UUID
+			Total     float64
+		}
+		query := db.Model(source.model).
+			Select(source.column+" AS account_id, COALESCE(SUM("+source.amount+"),0) AS total").
+			Where(source.column+" IN ?", ids)
+		if !before.IsZero() {
+			query = query.Where("date < ?", before)
+		}
+		if err := query.Group(source.column).Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			totals[row.AccountID] += source.sign * row.Total
+		}
+	}
+	return totals, nil
+}
+
+// accountEntries lista as movimentações de cada conta no intervalo [from, to), em ordem cronológica,
+// com o valor já com sinal (negativo para saídas).
+func accountEntries(db *gorm.DB, ids []uuid.UUID, from, to time.Time) (map[uuid.UUID][]AccountBalanceEntry, error) {
+	entries := map[uuid.UUID][]AccountBalanceEntry{}
+	if len(ids) == 0 {
+		return entries, nil
+	}
+
+	period := func(query *gorm.DB) *gorm.DB {
+		if !from.IsZero() {
+			query = query.Where("date >= ?", from)
+		}
+		if !to.IsZero() {
+			query = query.Where("date < ?", to)
+		}
+		return query
+	}
+
+	type entryRow struct {
+		ID          uuid.UUID
+		AccountID   uuid.UUID
+		Date        time.Time
+		Description string
+		Amount      float64
+		CreatedAt   time.Time
+	}
+	ordered := map[uuid.UUID][]entryRow{}
+	kinds := map[uuid.UUID]string{}
+
+	for _, source := range []struct {
+		model interface{}
+		kind  string
+		sign  float64
+	}{
+		{&schemas.Income{}, string(schemas.CategoryKindIncome), 1},
+		{&schemas.Expense{}, string(schemas.CategoryKindExpense), -1},
+	} {
+		var rows []entryRow
+		if err := period(db.Model(source.model).Where("account_id IN ?", ids)).
+			Select("id, account_id, date, description, amount, created_at").
+			Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			row.Amount *= source.sign
+			kinds[row.ID] = source.kind
+			ordered[row.AccountID] = append(ordered[row.AccountID], row)
+		}
+	}
+
+	var transfers []schemas.Transfer
+	if err := period(db.Where("from_account_id IN ? OR to_account_id IN ?", ids, ids)).Find(&transfers).Error; err != nil {
+		return nil, err
+	}
+	for _, transfer := range transfers {
+		kinds[transfer.ID] = "transferencia"
+		description := transfer.Description
+		if description == "" {
+			description = "Transferência"
+		}
+		ordered[transfer.FromAccountID] = append(ordered[transfer.FromAccountID], entryRow{
+			ID: transfer.ID, AccountID: transfer.FromAccountID, Date: transfer.Date,
+			Description: description, Amount: -transfer.Amount, CreatedAt: transfer.CreatedAt,
+		})
+		ordered[transfer.ToAccountID] = append(ordered[transfer.ToAccountID], entryRow{
+			ID: transfer.ID, AccountID: transfer.ToAccountID, Date: transfer.Date,
+			Description: description, Amount: transfer.ToAmount, CreatedAt: transfer.CreatedAt,
+		})
+	}
+
+	for accountID, rows := range ordered {
+		sort.SliceStable(rows, func(i, j int) bool {
+			if !rows[i].Date.Equal(rows[j].Date) {
+				return rows[i].Date.Before(rows[j].Date)
+			}
+			return rows[i].CreatedAt.Before(rows[j].CreatedAt)
+		})
+		list := make([]AccountBalanceEntry, len(rows))
+		for i, row := range rows {
+			list[i] = AccountBalanceEntry{
+				Date:        row.Date,
+				Type:        kinds[row.ID],
+				ReferenceID: row.ID.String(),
+				Description: row.Description,
+				Amount:      row.Amount,
+			}
+		}
+		entries[accountID] = list
+	}
+	return entries, nil
+}
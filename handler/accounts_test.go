@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+)
+
+func createTestAccount(t *testing.T, user *schemas.User, name string, accountType schemas.AccountType, opening float64) *schemas.Account {
+	t.Helper()
+
+	account := schemas.Account{UserID: user.ID, Name: name, Type: accountType, Currency: "BRL", OpeningBalance: opening, Active: true}
+	if err := getDB().Create(&account).Error; err != nil {
+		t.Fatalf("erro criando conta: %v", err)
+	}
+	return &account
+}
+
+// listAccountBalances devolve o saldo de cada conta ativa do usuário, indexado pelo nome.
+func listAccountBalances(t *testing.T, user *schemas.User) map[string]float64 {
+	t.Helper()
+
+	status, body := callHandler(t, ListAccountsHandler, user, "GET", "/accounts", nil)
+	if status != 200 {
+		t.Fatalf("listagem de contas: status = %d: %v", status, body)
+	}
+	balances := map[string]float64{}
+	for _, item := range body["data"].([]interface{}) {
+		account := item.(map[string]interface{})
+		balances[account["name"].(string)] = account["balance"].(float64)
+	}
+	return balances
+}
+
+func TestTransferKeepsCombinedBalance(t *testing.T) {
+	setupTestDB(t)
+	user := createTestUser(t, "ana@example.com")
+	checking := createTestAccount(t, user, "Corrente", schemas.AccountTypeChecking, 1000)
+	savings := createTestAccount(t, user, "Reserva", schemas.AccountTypeSavings, 200)
+	month := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	status, body := callHandler(t, CreateTransferHandler, user, "POST", "/transfers",
+		TransferRequest{FromAccountID: checking.ID.String(), ToAccountID: savings.ID.String(), Amount: 300, Date: "2024-06-10"})
+	if status != 200 {
+		t.Fatalf("transferência: status = %d: %v", status, body)
+	}
+	transferID := body["data"].(map[string]interface{})["id"].(string)
+
+	balances := listAccountBalances(t, user)
+	if balances["Corrente"] != 700 || balances["Reserva"] != 500 {
+		t.Fatalf("saldos = %v, esperado Corrente 700 e Reserva 500", balances)
+	}
+	if combined := balances["Corrente"] + balances["Reserva"]; combined != 1200 {
+		t.Fatalf("saldo combinado = %.2f, esperado 1200", combined)
+	}
+	scope := personalScope(user.ID)
+	if spent, income := aggregateTotal(scope, month, month.AddDate(0, 1, 0)), aggregateIncome(scope, month, month.AddDate(0, 1, 0)); spent != 0 || income != 0 {
+		t.Fatalf("a transferência não é despesa nem receita: despesas %.2f, receitas %.2f", spent, income)
+	}
+
+	status, body = callHandler(t, DeleteTransferHandler, user, "DELETE", "/transfers/"+transferID, nil, gin.Param{Key: "id", Value: transferID})
+	if status != 200 {
+		t.Fatalf("remoção: status = %d: %v", status, body)
+	}
+	if balances := listAccountBalances(t, user); balances["Corrente"] != 1000 || balances["Reserva"] != 200 {
+		t.Fatalf("saldos após remover = %v, esperado os saldos iniciais", balances)
+	}
+}
+
+func TestDeleteTransferRejectsGoalContribution(t *testing.T) {
+	setupTestDB(t)
+	user := createTestUser(t, "ana@example.com")
+	other := createTestUser(t, "bia@example.com")
+	checking := createTestAccount(t, user, "Corrente", schemas.AccountTypeChecking, 1000)
+	savings := createTestAccount(t, user, "Reserva", schemas.AccountTypeSavings, 0)
+	goal := schemas.Goal{UserID: user.ID, Name: "Viagem", TargetAmount: 1000, Deadline: time.Now().AddDate(1, 0, 0), AccountID: &savings.ID}
+	if err := getDB().Create(&goal).Error; err != nil {
+		t.Fatalf("erro criando meta: %v", err)
+	}
+
+	status, body := callHandler(t, CreateGoalContributionHandler, user, "POST", "/goals/"+goal.ID.String()+"/contributions",
+		map[string]interface{}{"amount": 300, "date": "2024-06-10", "fromAccountId": checking.ID.String()},
+		gin.Param{Key: "id", Value: goal.ID.String()})
+	if status != 200 {
+		t.Fatalf("aporte: status = %d: %v", status, body)
+	}
+	contribution := schemas.GoalContribution{}
+	if err := getDB().First(&contribution, "goal_id = ?", goal.ID).Error; err != nil || contribution.TransferID == nil {
+		t.Fatalf("aporte sem transferência: %v", err)
+	}
+	transferID := contribution.TransferID.String()
+	param := gin.Param{Key: "id", Value: transferID}
+
+	if status, _ := callHandler(t, DeleteTransferHandler, other, "DELETE", "/transfers/"+transferID, nil, param); status != 404 {
+		t.Fatalf("outro usuário: status = %d, esperado 404", status)
+	}
+	if status, body := callHandler(t, DeleteTransferHandler, user, "DELETE", "/transfers/"+transferID, nil, param); status != 409 {
+		t.Fatalf("transferência do aporte: status = %d, esperado 409: %v", status, body)
+	}
+
+	var transfers int64
+	getDB().Model(&schemas.Transfer{}).Where("id = ?", transferID).Count(&transfers)
+	if transfers != 1 {
+		t.Fatal("a transferência do aporte deveria ser mantida")
+	}
+	if balances := listAccountBalances(t, user); balances["Corrente"] != 700 || balances["Reserva"] != 300 {
+		t.Fatalf("saldos = %v, esperado Corrente 700 e Reserva 300", balances)
+	}
+}
@@ -0,0 +1,478 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const adminTopUsersLimit = 10
+
+var errSelfAdministration = errors.New("não é possível alterar a própria conta por esta rota")
+
+// AdminListUsersHandler godoc
+// @Summary Listar usuários
+// @Description Lista os usuários com busca por nome ou email e filtros de papel e situação. Disponível para suporte e administradores.
+// @Tags Admin
+// @Security Bearer
+// @Produce json
+// @Param q query string false "Trecho do nome ou email"
+// @Param role query string false "Papel" Enums(user, support, admin)
+// @Param active query bool false "Situação da conta"
+// @Param limit query int false "Número máximo de registros por página (1-200)"
+// @Param page query int false "Página a ser retornada (>=1)"
+// @Success 200 {object} AdminUserListSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /admin/users [get]
+func AdminListUsersHandler(ctx *gin.Context) {
+	limit := parseIntDefault(ctx.Query("limit"), 50)
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+
+	page := parseIntDefault(ctx.Query("page"), 1)
+	if page < 1 {
+		page = 1
+	}
+
+	query := getDB().Model(&schemas.User{})
+	if q := strings.ToLower(strings.TrimSpace(ctx.Query("q"))); q != "" {
+		pattern := "%" + q + "%"
+		query = query.Where("LOWER(name) LIKE ? OR LOWER(email) LIKE ?", pattern, pattern)
+	}
+	if role := strings.ToLower(strings.TrimSpace(ctx.Query("role"))); role != "" {
+		request := UpdateUserRoleRequest{Role: role}
+		if err := request.Validate(); err != nil {
+			respondError(ctx, 400, err.Error(), nil)
+			return
+		}
+		query = query.Where("role = ?", request.Role)
+	}
+	switch strings.ToLower(ctx.Query("active")) {
+	case "":
+	case "true":
+		query = query.Where("active = ?", true)
+	case "false":
+		query = query.Where("active = ?", false)
+	default:
+		respondError(ctx, 400, "filtro active inválido", nil)
+		return
+	}
+
+	var totalEntries int64
+	if err := query.Count(&totalEntries).Error; err != nil {
+		respondError(ctx, 500, "erro ao contar usuários", err.Error())
+		return
+	}
+
+	var users []schemas.User
+	if err := query.Order("created_at DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&users).Error; err != nil {
+		respondError(ctx, 500, "erro ao listar usuários", err.Error())
+		return
+	}
+
+	responses := make([]UserResponse, len(users))
+	for i := range users {
+		responses[i] = toUserResponse(&users[i])
+	}
+
+	respondSuccess(ctx, "usuários", AdminUserListResponse{
+		Users: responses,
+		Pagination: TokenUsagePagination{
+			Page:         page,
+			Limit:        limit,
+			TotalEntries: totalEntries,
+		},
+	})
+}
+
+// AdminGetUserHandler godoc
+// @Summary Detalhar usuário
+// @Description Retorna perfil, bloqueio de login, sessões ativas, provedores vinculados e consumo de tokens do usuário. Disponível para suporte e administradores.
+// @Tags Admin
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID do usuário"
+// @Success 200 {object} AdminUserDetailSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /admin/users/{id} [get]
+func AdminGetUserHandler(ctx *gin.Context) {
+	target, ok := loadAdminTargetUser(ctx)
+	if !ok {
+		return
+	}
+
+	var activeSessions int64
+	if err := getDB().Model(&schemas.Session{}).
+		Where("user_id = ? AND valid = ? AND expires_at > ?", target.ID, true, time.Now()).
+		Count(&activeSessions).Error; err != nil {
+		respondError(ctx, 500, "erro ao contar sessões", err.Error())
+		return
+	}
+
+	var identities []schemas.UserIdentity
+	if err := getDB().Where("user_id = ?", target.ID).Order("created_at ASC").Find(&identities).Error; err != nil {
+		respondError(ctx, 500, "erro ao listar provedores", err.Error())
+		return
+	}
+	identityResponses := make([]IdentityResponse, len(identities))
+	for i := range identities {
+		identityResponses[i] = toIdentityResponse(&identities[i])
+	}
+
+	totals, err := aggregateTokenUsageTotals(target.ID)
+	if err != nil {
+		respondError(ctx, 500, "erro ao calcular totais de tokens", err.Error())
+		return
+	}
+
+	response := AdminUserDetailResponse{
+		User:           toUserResponse(target),
+		ActiveSessions: activeSessions,
+		Identities:     identityResponses,
+		TokenUsage:     totals.summary(),
+	}
+	if target.LockedUntil != nil && target.LockedUntil.After(time.Now()) {
+		response.LockedUntil = target.LockedUntil
+	}
+
+	respondSuccess(ctx, "usuário", response)
+}
+
+// AdminDeactivateUserHandler godoc
+// @Summary Desativar usuário
+// @Description Desativa a conta e encerra todas as sessões e refresh tokens do usuário. Apenas administradores.
+// @Tags Admin
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID do usuário"
+// @Success 200 {object} UserProfileSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /admin/users/{id}/deactivate [post]
+func AdminDeactivateUserHandler(ctx *gin.Context) {
+	setUserActive(ctx, false)
+}
+
+// AdminActivateUserHandler godoc
+// @Summary Reativar usuário
+// @Description Reativa uma conta desativada. O usuário precisa entrar novamente. Apenas administradores.
+// @Tags Admin
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID do usuário"
+// @Success 200 {object} UserProfileSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /admin/users/{id}/activate [post]
+func AdminActivateUserHandler(ctx *gin.Context) {
+	setUserActive(ctx, true)
+}
+
+// AdminForceLogoutHandler godoc
+// @Summary Encerrar sessões do usuário
+// @Description Revoga todas as sessões e refresh tokens do usuário, obrigando um novo login em todos os dispositivos. Disponível para suporte e administradores.
+// @Tags Admin
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID do usuário"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /admin/users/{id}/logout [post]
+func AdminForceLogoutHandler(ctx *gin.Context) {
+	target, ok := loadAdminTargetUser(ctx)
+	if !ok {
+		return
+	}
+
+	err := getDB().Transaction(func(tx *gorm.DB) error {
+		return invalidateUserSessions(tx, target.ID, "")
+	})
+	if err != nil {
+		respondError(ctx, 500, "erro ao encerrar sessões", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "sessões do usuário encerradas", nil)
+}
+
+// AdminUnlockUserHandler godoc
+// @Summary Desbloquear login
+// @Description Remove o bloqueio por tentativas de login incorretas do email do usuário. Disponível para suporte e administradores.
+// @Tags Admin
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID do usuário"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /admin/users/{id}/unlock [post]
+func AdminUnlockUserHandler(ctx *gin.Context) {
+	target, ok := loadAdminTargetUser(ctx)
+	if !ok {
+		return
+	}
+
+	if err := clearLoginFailures(ctx.Request.Context(), target.ID, target.Email); err != nil {
+		respondError(ctx, 500, "erro ao desbloquear login", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "login desbloqueado", nil)
+}
+
+// AdminUpdateUserRoleHandler godoc
+// @Summary Alterar papel do usuário
+// @Description Define o papel do usuário (user, support ou admin). Administradores não podem alterar o próprio papel. Apenas administradores.
+// @Tags Admin
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do usuário"
+// @Param body body UpdateUserRoleRequest true "Novo papel"
+// @Success 200 {object} UserProfileSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /admin/users/{id}/role [put]
+func AdminUpdateUserRoleHandler(ctx *gin.Context) {
+	target, ok := loadAdminTargetUser(ctx)
+	if !ok {
+		return
+	}
+
+	var request UpdateUserRoleRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	if isSelf(ctx, target.ID) {
+		respondError(ctx, 409, errSelfAdministration.Error(), nil)
+		return
+	}
+
+	if err := getDB().Model(&schemas.User{}).
+		Where("id = ?", target.ID).
+		Updates(map[string]interface{}{"role": request.Role}).Error; err != nil {
+		respondError(ctx, 500, "erro ao alterar papel", err.Error())
+		return
+	}
+	target.Role = schemas.UserRole(request.Role)
+
+	respondSuccess(ctx, "papel atualizado", toUserResponse(target))
+}
+
+// AdminTokenUsageHandler godoc
+// @Summary Consumo global de tokens
+// @Description Agrega o consumo de tokens de todos os usuários no período, por tipo de requisição e pelos maiores consumidores. Disponível para suporte e administradores.
+// @Tags Admin
+// @Security Bearer
+// @Produce json
+// @Param from query string false "Início do período (YYYY-MM-DD ou RFC3339)"
+// @Param to query string false "Fim do período, inclusivo (YYYY-MM-DD ou RFC3339)"
+// @Success 200 {object} AdminTokenUsageSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /admin/token-usage [get]
+func AdminTokenUsageHandler(ctx *gin.Context) {
+	response := AdminTokenUsageResponse{}
+
+	var fromTime, toTime time.Time
+	if value := ctx.Query("from"); value != "" {
+		parsed, err := parseDate(value)
+		if err != nil {
+			respondError(ctx, 400, "data inicial inválida", nil)
+			return
+		}
+		fromTime = parsed
+		response.From = &fromTime
+	}
+	if value := ctx.Query("to"); value != "" {
+		parsed, err := parseDate(value)
+		if err != nil {
+			respondError(ctx, 400, "data final inválida", nil)
+			return
+		}
+		if len(value) == len("2006-01-02") {
+			// datas sem horário incluem o dia inteiro
+			parsed = parsed.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		toTime = parsed
+		response.To = &toTime
+	}
+	if response.From != nil && response.To != nil && toTime.Before(fromTime) {
+		respondError(ctx, 400, "período inválido", nil)
+		return
+	}
+	scope := func(db *gorm.DB) *gorm.DB {
+		if response.From != nil {
+			db = db.Where("token_usages.created_at >= ?", fromTime)
+		}
+		if response.To != nil {
+			db = db.Where("token_usages.created_at <= ?", toTime)
+		}
+		return db
+	}
+
+	totals := tokenUsageTotals{}
+	if err := getDB().Model(&schemas.TokenUsage{}).
+		Scopes(scope).
+		Select(tokenUsageTotalsSelect).
+		Scan(&totals).Error; err != nil {
+		respondError(ctx, 500, "erro ao calcular totais de tokens", err.Error())
+		return
+	}
+	response.Summary = totals.summary()
+
+	var byType []struct {
+		RequestType string `gorm:"column:request_type"`
+		tokenUsageTotals
+	}
+	if err := getDB().Model(&schemas.TokenUsage{}).
+		Scopes(scope).
+		Select("request_type, " + tokenUsageTotalsSelect).
+		Group("request_type").
+		Order("total_sum DESC").
+		Scan(&byType).Error; err != nil {
+		respondError(ctx, 500, "erro ao agrupar consumo de tokens", err.Error())
+		return
+	}
+	response.ByRequestType = make([]AdminTokenUsageByType, len(byType))
+	for i, row := range byType {
+		response.ByRequestType[i] = AdminTokenUsageByType{
+			RequestType:       row.RequestType,
+			TokenUsageSummary: row.summary(),
+		}
+	}
+
+	var byUser []struct {
+		UserID uuid.UUID `gorm:"column:user_id"`
+		Name   string    `gorm:"column:name"`
+		Email  string    `gorm:"column:email"`
+		tokenUsageTotals
+	}
+	if err := getDB().Model(&schemas.TokenUsage{}).
+		Scopes(scope).
+		Joins("JOIN users ON users.id = token_usages.user_id").
+		Select("token_usages.user_id AS user_id, users.name AS name, users.email AS email, " + tokenUsageTotalsSelect).
+		Group("token_usages.user_id, users.name, users.email").
+		Order("total_sum DESC").
+		Limit(adminTopUsersLimit).
+		Scan(&byUser).Error; err != nil {
+		respondError(ctx, 500, "erro ao agrupar consumo de tokens", err.Error())
+		return
+	}
+	response.TopUsers = make([]AdminTokenUsageByUser, len(byUser))
+	for i, row := range byUser {
+		response.TopUsers[i] = AdminTokenUsageByUser{
+			UserID:            row.UserID.String(),
+			Name:              row.Name,
+			Email:             row.Email,
+			TokenUsageSummary: row.summary(),
+		}
+	}
+
+	respondSuccess(ctx, "consumo global de tokens", response)
+}
+
+func setUserActive(ctx *gin.Context, active bool) {
+	target, ok := loadAdminTargetUser(ctx)
+	if !ok {
+		return
+	}
+
+	if isSelf(ctx, target.ID) {
+		respondError(ctx, 409, errSelfAdministration.Error(), nil)
+		return
+	}
+
+	err := getDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&schemas.User{}).
+			Where("id = ?", target.ID).
+			Updates(map[string]interface{}{"active": active}).Error; err != nil {
+			return err
+		}
+		if active {
+			return nil
+		}
+		return invalidateUserSessions(tx, target.ID, "")
+	})
+	if err != nil {
+		respondError(ctx, 500, "erro ao atualizar situação da conta", err.Error())
+		return
+	}
+	target.Active = active
+
+	message := "usuário reativado"
+	if !active {
+		message = "usuário desativado"
+	}
+	respondSuccess(ctx, message, toUserResponse(target))
+}
+
+// loadAdminTargetUser carrega o usuário indicado no parâmetro :id, respondendo 400/404 quando necessário.
+func loadAdminTargetUser(ctx *gin.Context) (*schemas.User, bool) {
+	userID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return nil, false
+	}
+
+	user, err := reloadUser(userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "usuário não encontrado", nil)
+			return nil, false
+		}
+		respondError(ctx, 500, "erro ao carregar usuário", err.Error())
+		return nil, false
+	}
+	return user, true
+}
+
+func isSelf(ctx *gin.Context, userID uuid.UUID) bool {
+	user, err := getAuthenticatedUser(ctx)
+	return err == nil && user.ID == userID
+}
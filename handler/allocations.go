@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const maxExpenseAllocations = 50
+
+var errAllocationsExceedAmount = errors.New("a soma das divisões ultrapassa o valor da despesa")
+
+// SetExpenseAllocationsHandler godoc
+// @Summary Dividir despesa entre categorias
+// @Description Substitui a divisão da despesa entre categorias. Cada divisão informa a categoria e um valor manual (amount) ou os itens do cupom (itemIds), que contam pelo total de cada item. A soma não pode passar do valor da despesa e o restante fica na categoria principal. Envie uma lista vazia para desfazer a divisão.
+// @Tags Despesas
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "Identificador da despesa"
+// @Param body body ExpenseAllocationsRequest true "Divisões da despesa"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} ExpenseItemSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /expenses/{id}/allocations [put]
+func SetExpenseAllocationsHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	expenseID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	var request ExpenseAllocationsRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	expense := schemas.Expense{}
+	if err := scope.apply(getDB().Preload("Items"), "expenses").Where("id = ?", expenseID).First(&expense).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "despesa não encontrada", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao carregar despesa", err.Error())
+		return
+	}
+
+	items := make(map[uuid.UUID]schemas.ExpenseItem, len(expense.Items))
+	for _, item := range expense.Items {
+		items[item.ID] = item
+	}
+
+	allocations := make([]schemas.ExpenseAllocation, 0, len(request.Allocations))
+	usedItems := map[uuid.UUID]bool{}
+	allocated := 0.0
+	for _, input := range request.Allocations {
+		categoryID, err := uuid.Parse(input.CategoryID)
+		if err != nil {
+			respondError(ctx, 400, "categoryId inválido", nil)
+			return
+		}
+		if !categoryInScope(getDB(), scope, categoryID, schemas.CategoryKindExpense) {
+			respondError(ctx, 403, "categoria não pertence ao usuário", nil)
+			return
+		}
+
+		if input.Amount != nil {
+			amount := roundFloat(*input.Amount)
+			allocated += amount
+			allocations = append(allocations, schemas.ExpenseAllocation{
+				ExpenseID:  expense.ID,
+				CategoryID: categoryID,
+				Amount:     amount,
+			})
+			continue
+		}
+
+		for _, rawItemID := range input.ItemIDs {
+			itemID, err := uuid.Parse(rawItemID)
+			if err != nil {
+				respondError(ctx, 400, "itemId inválido", nil)
+				return
+			}
+			item, ok := items[itemID]
+			if !ok {
+				respondError(ctx, 400, fmt.Sprintf("item %s não pertence à despesa", rawItemID), nil)
+				return
+			}
+			if usedItems[itemID] {
+				respondError(ctx, 400, fmt.Sprintf("item %s informado em mais de uma divisão", rawItemID), nil)
+				return
+			}
+			usedItems[itemID] = true
+			allocated += item.TotalPrice
+			allocations = append(allocations, schemas.ExpenseAllocation{
+				ExpenseID:     expense.ID,
+				CategoryID:    categoryID,
+				ExpenseItemID: &item.ID,
+				Amount:        item.TotalPrice,
+			})
+		}
+	}
+	if roundFloat(allocated) > expense.Amount {
+		respondError(ctx, 400, errAllocationsExceedAmount.Error(), gin.H{
+			"allocated": roundFloat(allocated),
+			"amount":    expense.Amount,
+		})
+		return
+	}
+
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expense_id = ?", expense.ID).Delete(&schemas.ExpenseAllocation{}).Error; err != nil {
+			return err
+		}
+		if len(allocations) == 0 {
+			return nil
+		}
+		return tx.Create(&allocations).Error
+	})
+	if err != nil {
+		respondError(ctx, 500, "erro ao salvar divisão da despesa", err.Error())
+		return
+	}
+	checkBudgetAlerts(ctx.Request.Context(), scope, expense.Date)
+
+	updated := schemas.Expense{}
+	if err := getDB().Preload("Category").Preload("Receipt").Preload("Items").Preload("InstallmentPurchase").Preload("Allocations").
+		Where("id = ?", expense.ID).
+		First(&updated).Error; err != nil {
+		respondError(ctx, 500, "erro ao carregar despesa", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "divisão da despesa atualizada", toExpenseResponse(&updated))
+}
+
+// allocatedAmount soma as alocações ativas de uma despesa.
+func allocatedAmount(db *gorm.DB, expenseID uuid.UUID) (float64, error) {
+	var total float64
+	err := db.Model(&schemas.ExpenseAllocation{}).
+		Select("COALESCE(SUM(amount),0)").
+		Where("expense_id = ?", expenseID).
+		Scan(&total).Error
+	return total, err
+}
+
+// expenseAllocationBreakdown agrupa as alocações carregadas por categoria e acrescenta o restante na
+// categoria principal. Sem alocações, a despesa não está dividida e o retorno é nil.
+func expenseAllocationBreakdown(expense *schemas.Expense) []ExpenseAllocationResponse {
+	if len(expense.Allocations) == 0 {
+		return nil
+	}
+
+	remainder := expense.Amount
+	byCategory := map[uuid.UUID]*ExpenseAllocationResponse{}
+	order := []uuid.UUID{}
+	for _, allocation := range expense.Allocations {
+		remainder -= allocation.Amount
+		entry, ok := byCategory[allocation.CategoryID]
+		if !ok {
+			entry = &ExpenseAllocationResponse{CategoryID: allocation.CategoryID.String()}
+			byCategory[allocation.CategoryID] = entry
+			order = append(order, allocation.CategoryID)
+		}
+		entry.Amount += allocation.Amount
+		if allocation.ExpenseItemID != nil {
+			entry.ItemIDs = append(entry.ItemIDs, allocation.ExpenseItemID.String())
+		}
+	}
+
+	breakdown := make([]ExpenseAllocationResponse, 0, len(order)+1)
+	if remainder = roundFloat(remainder); remainder > 0 {
+		breakdown = append(breakdown, ExpenseAllocationResponse{
+			CategoryID: expense.CategoryID.String(),
+			Amount:     remainder,
+			Primary:    true,
+		})
+	}
+	for _, categoryID := range order {
+		entry := byCategory[categoryID]
+		entry.Amount = roundFloat(entry.Amount)
+		breakdown = append(breakdown, *entry)
+	}
+	return breakdown
+}
+
+// categoryTotals soma as despesas do período por categoria respeitando as divisões: cada despesa conta na
+// categoria principal apenas pelo que não foi alocado, e cada alocação conta na própria categoria.
+// O resultado vem do maior para o menor total.
+func categoryTotals(scope dataScope, start, end time.Time) ([]categoryTotal, error) {
+	primary := []categoryTotal{}
+	if err := scope.apply(getDB().Model(&schemas.Expense{}), "expenses").
+		Select("expenses.category_id, SUM(expenses.amount - COALESCE((SELECT SUM(expense_allocations.amount) FROM expense_allocations WHERE expense_allocations.expense_id = expenses.id AND expense_allocations.deleted_at IS NULL), 0)) AS total").
+		Where("expenses.date >= ? AND expenses.date < ?", start, end).
+		Group("expenses.category_id").
+		Scan(&primary).Error; err != nil {
+		return nil, err
+	}
+
+	allocated := []categoryTotal{}
+	if err := scope.apply(getDB().Model(&schemas.ExpenseAllocation{}).
+		Joins("JOIN expenses ON expenses.id = expense_allocations.expense_id AND expenses.deleted_at IS NULL"), "expenses").
+		Select("expense_allocations.category_id, SUM(expense_allocations.amount) AS total").
+		Where("expenses.date >= ? AND expenses.date < ?", start, end).
+		Group("expense_allocations.category_id").
+		Scan(&allocated).Error; err != nil {
+		return nil, err
+	}
+
+	sums := map[uuid.UUID]float64{}
+	for _, total := range append(primary, allocated...) {
+		sums[total.CategoryID] += total.Total
+	}
+	totals := make([]categoryTotal, 0, len(sums))
+	for categoryID, total := range sums {
+		if roundFloat(total) <= 0 {
+			continue
+		}
+		totals = append(totals, categoryTotal{CategoryID: categoryID, Total: total})
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Total != totals[j].Total {
+			return totals[i].Total > totals[j].Total
+		}
+		return totals[i].CategoryID.String() < totals[j].CategoryID.String()
+	})
+	return totals, nil
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+)
+
+func TestSplitExpenseCountsOncePerCategory(t *testing.T) {
+	setupTestDB(t)
+	user := createTestUser(t, "ana@example.com")
+	scope := personalScope(user.ID)
+	month := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+	createCategory := func(name string) *schemas.Category {
+		category := schemas.Category{UserID: user.ID, Name: name, Kind: schemas.CategoryKindExpense, Active: true}
+		if err := getDB().Create(&category).Error; err != nil {
+			t.Fatalf("erro criando categoria: %v", err)
+		}
+		return &category
+	}
+	market := createCategory("Mercado")
+	cleaning := createCategory("Limpeza")
+	pharmacy := createCategory("Farmácia")
+
+	split := schemas.Expense{UserID: user.ID, CategoryID: market.ID, Description: "compra do mês", Amount: 100, Date: month.AddDate(0, 0, 4)}
+	other := schemas.Expense{UserID: user.ID, CategoryID: cleaning.ID, Description: "detergente", Amount: 10, Date: month.AddDate(0, 0, 9)}
+	for _, expense := range []*schemas.Expense{&split, &other} {
+		if err := getDB().Create(expense).Error; err != nil {
+			t.Fatalf("erro criando despesa: %v", err)
+		}
+	}
+	allocations := []schemas.ExpenseAllocation{
+		{ExpenseID: split.ID, CategoryID: cleaning.ID, Amount: 30},
+		{ExpenseID: split.ID, CategoryID: pharmacy.ID, Amount: 20},
+	}
+	if err := getDB().Create(&allocations).Error; err != nil {
+		t.Fatalf("erro criando alocações: %v", err)
+	}
+	removed := schemas.ExpenseAllocation{ExpenseID: split.ID, CategoryID: pharmacy.ID, Amount: 15}
+	if err := getDB().Create(&removed).Error; err != nil {
+		t.Fatalf("erro criando alocação: %v", err)
+	}
+	if err := getDB().Delete(&removed).Error; err != nil {
+		t.Fatalf("erro excluindo alocação: %v", err)
+	}
+
+	totals, err := categoryTotals(scope, month, month.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("categoryTotals: %v", err)
+	}
+	want := []struct {
+		category *schemas.Category
+		total    float64
+	}{
+		{market, 50},
+		{cleaning, 40},
+		{pharmacy, 20},
+	}
+	if len(totals) != len(want) {
+		t.Fatalf("totais = %+v, esperado %d categorias", totals, len(want))
+	}
+	sum := 0.0
+	for i, expected := range want {
+		if totals[i].CategoryID != expected.category.ID || roundFloat(totals[i].Total) != expected.total {
+			t.Fatalf("posição %d = %s %.2f, esperado %s %.2f", i, totals[i].CategoryID, totals[i].Total, expected.category.Name, expected.total)
+		}
+		sum += totals[i].Total
+	}
+	if total := aggregateTotal(scope, month, month.AddDate(0, 1, 0)); roundFloat(sum) != roundFloat(total) {
+		t.Fatalf("soma por categoria = %.2f, total do mês = %.2f", sum, total)
+	}
+
+	status, body := callHandler(t, DashboardSummaryHandler, user, "GET", "/dashboard/summary?month=5&year=2024", nil)
+	if status != 200 {
+		t.Fatalf("dashboard: status = %d: %v", status, body)
+	}
+	top := body["data"].(map[string]interface{})["topCategories"].([]interface{})
+	if len(top) != len(want) {
+		t.Fatalf("topCategories = %v", top)
+	}
+	for i, expected := range want {
+		entry := top[i].(map[string]interface{})
+		if entry["total"].(float64) != expected.total {
+			t.Fatalf("topCategories[%d] = %v, esperado %.2f", i, entry, expected.total)
+		}
+	}
+}
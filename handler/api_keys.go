@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	apiKeyPrefix               = "gfk_"
+	apiKeyDisplayPrefixLength  = len(apiKeyPrefix) + 8
+	maxAPIKeysPerUser          = 25
+	errorCodeInsufficientScope = "insufficient_scope"
+)
+
+var errTooManyAPIKeys = errors.New("limite de chaves de API atingido, revogue alguma antes de criar outra")
+
+// CreateAPIKeyHandler godoc
+// @Summary Criar chave de API
+// @Description Cria uma chave pessoal com os escopos informados e validade opcional. A chave é exibida apenas nesta resposta; o servidor guarda somente o digest. Escopos: expenses:read, expenses:write, incomes:read, incomes:write, accounts:read, accounts:write, budgets:read, budgets:write, goals:read, goals:write, categories:read, categories:write, receipts:scan, dashboard:read, tips:read, tips:write, meal-plans:read, meal-plans:write, sync:write, token-usage:read.
+// @Tags Auth
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body CreateAPIKeyRequest true "Nome, escopos e validade"
+// @Success 200 {object} APIKeyCreatedSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/api-keys [post]
+func CreateAPIKeyHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	var request CreateAPIKeyRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	secret, err := generateSessionToken()
+	if err != nil {
+		respondError(ctx, 500, "erro ao gerar chave", err.Error())
+		return
+	}
+	rawKey := apiKeyPrefix + secret
+
+	scopes := make([]schemas.APIKeyScope, len(request.Scopes))
+	for i, scope := range request.Scopes {
+		scopes[i] = schemas.APIKeyScope(scope)
+	}
+
+	key := schemas.APIKey{
+		UserID:    user.ID,
+		Name:      request.Name,
+		Prefix:    rawKey[:apiKeyDisplayPrefixLength],
+		KeyHash:   schemas.HashToken(rawKey),
+		Scopes:    scopes,
+		ExpiresAt: request.ExpiresAt,
+	}
+
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := activeAPIKeys(tx, user).Model(&schemas.APIKey{}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= maxAPIKeysPerUser {
+			return errTooManyAPIKeys
+		}
+		return tx.Create(&key).Error
+	})
+	if err != nil {
+		if errors.Is(err, errTooManyAPIKeys) {
+			respondError(ctx, 409, err.Error(), nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao criar chave", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "chave criada, guarde-a agora: ela não será exibida novamente", CreatedAPIKeyResponse{
+		Key:    rawKey,
+		APIKey: toAPIKeyResponse(&key),
+	})
+}
+
+// ListAPIKeysHandler godoc
+// @Summary Listar chaves de API
+// @Description Lista as chaves de API ativas do usuário autenticado, sem o valor secreto
+// @Tags Auth
+// @Security Bearer
+// @Produce json
+// @Success 200 {object} APIKeyListSuccess
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/api-keys [get]
+func ListAPIKeysHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	var keys []schemas.APIKey
+	if err := activeAPIKeys(getDB(), user).Order("created_at DESC").Find(&keys).Error; err != nil {
+		respondError(ctx, 500, "erro ao listar chaves", err.Error())
+		return
+	}
+
+	responses := make([]APIKeyResponse, len(keys))
+	for i := range keys {
+		responses[i] = toAPIKeyResponse(&keys[i])
+	}
+
+	respondSuccess(ctx, "chaves de API", responses)
+}
+
+// RevokeAPIKeyHandler godoc
+// @Summary Revogar chave de API
+// @Description Revoga imediatamente a chave informada
+// @Tags Auth
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID da chave"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/api-keys/{id} [delete]
+func RevokeAPIKeyHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	keyID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	result := getDB().Model(&schemas.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", keyID, user.ID).
+		Updates(map[string]interface{}{"revoked_at": time.Now()})
+	if result.Error != nil {
+		respondError(ctx, 500, "erro ao revogar chave", result.Error.Error())
+		return
+	}
+	if result.RowsAffected == 0 {
+		respondError(ctx, 404, "chave não encontrada", nil)
+		return
+	}
+
+	respondSuccess(ctx, "chave revogada", nil)
+}
+
+// activeAPIKeys filtra as chaves do usuário que não foram revogadas nem expiraram.
+func activeAPIKeys(tx *gorm.DB, user *schemas.User) *gorm.DB {
+	return tx.Where("user_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", user.ID, time.Now())
+}
+
+// revokeUserAPIKeys revoga todas as chaves do usuário, usado quando a conta pode ter sido comprometida.
+func revokeUserAPIKeys(tx *gorm.DB, userID uuid.UUID) error {
+	return tx.Model(&schemas.APIKey{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{"revoked_at": time.Now()}).Error
+}
+
+// authenticateAPIKey conclui o AuthMiddleware para tokens com o prefixo de chave de API.
+func authenticateAPIKey(ctx *gin.Context, rawKey string) {
+	key := schemas.APIKey{}
+	err := getDB().Preload("User.Config").First(&key, "key_hash = ?", schemas.HashToken(rawKey)).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 401, "chave de API inválida", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao validar chave de API", err.Error())
+		return
+	}
+
+	now := time.Now()
+	if key.RevokedAt != nil {
+		respondError(ctx, 401, "chave de API revogada", nil)
+		return
+	}
+	if key.ExpiresAt != nil && key.ExpiresAt.Before(now) {
+		respondError(ctx, 401, "chave de API expirada", nil)
+		return
+	}
+
+	user := key.User
+	if user == nil {
+		respondError(ctx, 401, "usuário não associado à chave", nil)
+		return
+	}
+	if !user.Active {
+		respondError(ctx, 403, errAccountDisabled.Error(), nil)
+		return
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= getSessionTouchInterval() {
+		if err := getDB().Model(&schemas.APIKey{}).
+			Where("id = ?", key.ID).
+			Updates(map[string]interface{}{"last_used_at": now}).Error; err != nil {
+			getLogger().WarnF("não foi possível atualizar o uso da chave de API: %v", err)
+		}
+		key.LastUsedAt = &now
+	}
+
+	setAuthenticatedUser(ctx, user)
+	setCurrentAPIKey(ctx, &key)
+	ctx.Next()
+}
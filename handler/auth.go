@@ -16,7 +16,7 @@ import (
 
 // RegisterHandler godoc
 // @Summary Registrar novo usuário
-// @Description Cria um usuário, configura dados padrão e retorna a sessão autenticada
+// @Description Cria um usuário, configura dados padrão, envia o email de verificação e retorna a sessão autenticada (ou apenas o usuário quando REQUIRE_EMAIL_VERIFICATION está ativo)
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -55,7 +55,8 @@ func RegisterHandler(ctx *gin.Context) {
 	}
 
 	var createdUser schemas.User
-	var createdSession schemas.Session
+	var createdTokens *issuedTokens
+	var verificationToken string
 
 	err = getDB().Transaction(func(tx *gorm.DB) error {
 		user := schemas.User{
@@ -63,6 +64,7 @@ func RegisterHandler(ctx *gin.Context) {
 			Email:        request.Email,
 			PasswordHash: string(passwordHash),
 			Active:       true,
+			Role:         schemas.UserRoleUser,
 		}
 		if err := tx.Create(&user).Error; err != nil {
 			return err
@@ -85,18 +87,27 @@ func RegisterHandler(ctx *gin.Context) {
 			return err
 		}
 
-		if err := seedDefaultCategories(tx, user.ID); err != nil {
+		if err := seedDefaultCategories(tx, user.ID, nil); err != nil {
 			return err
 		}
 
-		session, err := createSession(tx, user.ID)
+		verificationToken, err = issueUserToken(tx, user.ID, schemas.UserTokenEmailVerification, emailVerificationTTL)
 		if err != nil {
 			return err
 		}
 
 		createdUser = user
 		createdUser.Config = &config
-		createdSession = *session
+
+		if isEmailVerificationRequired() {
+			return nil
+		}
+
+		tokens, err := issueTokens(tx, user.ID, newSessionClient(ctx, request.DeviceName))
+		if err != nil {
+			return err
+		}
+		createdTokens = tokens
 		return nil
 	})
 
@@ -105,16 +116,21 @@ func RegisterHandler(ctx *gin.Context) {
 		return
 	}
 
-	respondSuccess(ctx, "cadastro realizado", AuthResponse{
-		Token:     createdSession.Token,
-		ExpiresAt: createdSession.ExpiresAt,
-		User:      toUserResponse(&createdUser),
-	})
+	if err := sendVerificationEmail(ctx.Request.Context(), &createdUser, verificationToken); err != nil {
+		getLogger().WarnF("não foi possível enviar email de verificação: %v", err)
+	}
+
+	if createdTokens == nil {
+		respondSuccess(ctx, "cadastro realizado, confirme seu email para entrar", toUserResponse(&createdUser))
+		return
+	}
+
+	respondSuccess(ctx, "cadastro realizado", createdTokens.toAuthResponse(&createdUser))
 }
 
 // LoginHandler godoc
 // @Summary Autenticar usuário
-// @Description Valida credenciais e emite um novo token de sessão
+// @Description Valida credenciais e emite um novo token de sessão. Com o segundo fator habilitado, retorna um desafio (status mfa_pending) a ser concluído em /auth/mfa/verify. Falhas repetidas bloqueiam o email ou IP temporariamente (429, code account_locked).
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -122,6 +138,8 @@ func RegisterHandler(ctx *gin.Context) {
 // @Success 200 {object} AuthSuccessResponse
 // @Failure 400 {object} APIError
 // @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 429 {object} APIError
 // @Failure 500 {object} APIError
 // @Router /auth/login [post]
 func LoginHandler(ctx *gin.Context) {
@@ -135,34 +153,75 @@ func LoginHandler(ctx *gin.Context) {
 		return
 	}
 
+	lockedUntil, err := checkLoginLock(ctx.Request.Context(), request.Email, ctx.ClientIP())
+	if err != nil {
+		respondError(ctx, 500, "erro ao verificar tentativas de login", err.Error())
+		return
+	}
+	if lockedUntil != nil {
+		respondAccountLocked(ctx, *lockedUntil)
+		return
+	}
+
 	user := schemas.User{}
 	if err := getDB().Preload("Config").Where("email = ?", request.Email).First(&user).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			respondError(ctx, 401, "credenciais inválidas", nil)
+			respondLoginFailure(ctx, nil, request.Email, "usuario_inexistente")
 			return
 		}
 		respondError(ctx, 500, "erro ao buscar usuário", err.Error())
 		return
 	}
 
+	if user.LockedUntil != nil && user.LockedUntil.After(time.Now()) {
+		respondAccountLocked(ctx, *user.LockedUntil)
+		return
+	}
+
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(request.Password)); err != nil {
-		respondError(ctx, 401, "credenciais inválidas", nil)
+		respondLoginFailure(ctx, &user, request.Email, "senha_invalida")
+		return
+	}
+
+	if !user.Active {
+		respondError(ctx, 403, errAccountDisabled.Error(), nil)
+		return
+	}
+
+	if isEmailVerificationRequired() && user.EmailVerifiedAt == nil {
+		respondError(ctx, 403, "email não verificado", nil)
+		return
+	}
+
+	if user.MFAEnabled {
+		// as falhas só são zeradas após o segundo fator, para que os códigos também contem no bloqueio
+		respondMFAChallenge(ctx, &user)
 		return
 	}
 
-	var session schemas.Session
+	startUserSession(ctx, &user, request.DeviceName, "login realizado")
+}
+
+// startUserSession conclui a autenticação: zera as falhas de login, registra o acesso e emite a sessão.
+func startUserSession(ctx *gin.Context, user *schemas.User, deviceName, message string) {
+	if err := clearLoginFailures(ctx.Request.Context(), user.ID, user.Email); err != nil {
+		getLogger().WarnF("não foi possível limpar tentativas de login: %v", err)
+	}
+	user.LockedUntil = nil
+
+	var tokens *issuedTokens
 	err := getDB().Transaction(func(tx *gorm.DB) error {
 		now := time.Now()
 		user.LastLogin = &now
-		if err := tx.Model(&user).Updates(map[string]interface{}{"last_login": now}).Error; err != nil {
+		if err := tx.Model(user).Updates(map[string]interface{}{"last_login": now}).Error; err != nil {
 			return err
 		}
 
-		newSession, err := createSession(tx, user.ID)
+		issued, err := issueTokens(tx, user.ID, newSessionClient(ctx, deviceName))
 		if err != nil {
 			return err
 		}
-		session = *newSession
+		tokens = issued
 		return nil
 	})
 	if err != nil {
@@ -170,16 +229,92 @@ func LoginHandler(ctx *gin.Context) {
 		return
 	}
 
-	respondSuccess(ctx, "login realizado", AuthResponse{
-		Token:     session.Token,
-		ExpiresAt: session.ExpiresAt,
-		User:      toUserResponse(&user),
+	respondSuccess(ctx, message, tokens.toAuthResponse(user))
+}
+
+func respondLoginFailure(ctx *gin.Context, user *schemas.User, email, reason string) {
+	lockedUntil, err := registerLoginFailure(ctx, user, email, reason)
+	if err != nil {
+		getLogger().WarnF("não foi possível registrar tentativa de login: %v", err)
+	}
+	if lockedUntil != nil {
+		respondAccountLocked(ctx, *lockedUntil)
+		return
+	}
+	respondError(ctx, 401, "credenciais inválidas", nil)
+}
+
+// RefreshTokenHandler godoc
+// @Summary Renovar sessão
+// @Description Troca um refresh token válido por um novo token de acesso e um novo refresh token. A reutilização de um refresh token já trocado revoga toda a família de tokens.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} AuthSuccessResponse
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/refresh [post]
+func RefreshTokenHandler(ctx *gin.Context) {
+	var request RefreshTokenRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	var tokens *issuedTokens
+	var rotateErr error
+	user := schemas.User{}
+	err := getDB().Transaction(func(tx *gorm.DB) error {
+		issued, err := rotateRefreshToken(tx, request.RefreshToken, newSessionClient(ctx, request.DeviceName))
+		if err != nil {
+			if errors.Is(err, errRefreshTokenReused) {
+				// a revogação da família precisa ser persistida mesmo com a falha
+				rotateErr = err
+				return nil
+			}
+			return err
+		}
+		if err := tx.Preload("Config").First(&user, "id = ?", issued.Session.UserID).Error; err != nil {
+			return err
+		}
+		if !user.Active {
+			// desfaz a rotação: contas desativadas não recebem novos tokens
+			return errAccountDisabled
+		}
+		tokens = issued
+		return nil
 	})
+	if rotateErr == nil {
+		rotateErr = err
+	}
+
+	if rotateErr != nil {
+		switch {
+		case errors.Is(rotateErr, errRefreshTokenReused):
+			getLogger().WarnF("reutilização de refresh token detectada; família revogada")
+			respondError(ctx, 401, rotateErr.Error(), nil)
+		case errors.Is(rotateErr, errRefreshTokenInvalid), errors.Is(rotateErr, errRefreshTokenExpired):
+			respondError(ctx, 401, rotateErr.Error(), nil)
+		case errors.Is(rotateErr, errAccountDisabled):
+			respondError(ctx, 403, rotateErr.Error(), nil)
+		default:
+			respondError(ctx, 500, "erro ao renovar sessão", rotateErr.Error())
+		}
+		return
+	}
+
+	respondSuccess(ctx, "sessão renovada", tokens.toAuthResponse(&user))
 }
 
 // LogoutHandler godoc
 // @Summary Encerrar sessão
-// @Description Invalida a sessão atual ou todas as sessões do usuário autenticado
+// @Description Invalida a sessão atual ou todas as sessões do usuário autenticado, revogando também os refresh tokens associados
 // @Tags Auth
 // @Security Bearer
 // @Accept json
@@ -205,6 +340,9 @@ func LogoutHandler(ctx *gin.Context) {
 
 	if err := getDB().Transaction(func(tx *gorm.DB) error {
 		if request.AllDevices {
+			if err := revokeUserRefreshTokens(tx, user.ID); err != nil {
+				return err
+			}
 			return tx.Model(&schemas.Session{}).
 				Where("user_id = ?", user.ID).
 				Updates(map[string]interface{}{"valid": false}).Error
@@ -214,6 +352,9 @@ func LogoutHandler(ctx *gin.Context) {
 		if !ok {
 			return errors.New("sessão atual não encontrada")
 		}
+		if err := revokeRefreshTokensForSession(tx, session.Token); err != nil {
+			return err
+		}
 		return tx.Model(&schemas.Session{}).
 			Where("token = ?", session.Token).
 			Updates(map[string]interface{}{"valid": false}).Error
@@ -244,24 +385,32 @@ func MeHandler(ctx *gin.Context) {
 	respondSuccess(ctx, "perfil", toUserResponse(user))
 }
 
-func createSession(tx *gorm.DB, userID uuid.UUID) (*schemas.Session, error) {
+// createSession persiste a sessão com o digest do token e devolve o token em texto puro,
+// que só existe na resposta entregue ao cliente.
+func createSession(tx *gorm.DB, userID uuid.UUID, client sessionClient) (*schemas.Session, string, error) {
 	token, err := generateSessionToken()
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
+	now := time.Now()
 	session := schemas.Session{
-		Token:     token,
-		UserID:    userID,
-		CreatedAt: time.Now(),
-		ExpiresAt: time.Now().Add(getSessionTTL()),
-		Valid:     true,
+		Token:       schemas.HashToken(token),
+		TokenHashed: true,
+		UserID:      userID,
+		DeviceName:  client.DeviceName,
+		UserAgent:   client.UserAgent,
+		IPAddress:   client.IPAddress,
+		CreatedAt:   now,
+		LastSeenAt:  &now,
+		ExpiresAt:   now.Add(getSessionTTL()),
+		Valid:       true,
 	}
 
 	if err := tx.Create(&session).Error; err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	return &session, nil
+	return &session, token, nil
 }
 
 func generateSessionToken() (string, error) {
@@ -272,30 +421,38 @@ func generateSessionToken() (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
-func seedDefaultCategories(tx *gorm.DB, userID uuid.UUID) error {
+// seedDefaultCategories cria as categorias iniciais do usuário ou, com householdID, do grupo familiar.
+func seedDefaultCategories(tx *gorm.DB, userID uuid.UUID, householdID *uuid.UUID) error {
 	defaults := []struct {
 		Name  string
 		Icon  string
 		Color string
 		Type  schemas.CategoryType
+		Kind  schemas.CategoryKind
 	}{
-		{Name: "Alimentação", Icon: "utensils", Color: "#EF4444", Type: schemas.CategoryTypeVariable},
-		{Name: "Transporte", Icon: "bus", Color: "#3B82F6", Type: schemas.CategoryTypeVariable},
-		{Name: "Moradia", Icon: "home", Color: "#8B5CF6", Type: schemas.CategoryTypeFixed},
-		{Name: "Saúde", Icon: "heart", Color: "#10B981", Type: schemas.CategoryTypeVariable},
-		{Name: "Educação", Icon: "book", Color: "#F59E0B", Type: schemas.CategoryTypeVariable},
-		{Name: "Lazer", Icon: "music", Color: "#6366F1", Type: schemas.CategoryTypeVariable},
+		{Name: "Alimentação", Icon: "utensils", Color: "#EF4444", Type: schemas.CategoryTypeVariable, Kind: schemas.CategoryKindExpense},
+		{Name: "Transporte", Icon: "bus", Color: "#3B82F6", Type: schemas.CategoryTypeVariable, Kind: schemas.CategoryKindExpense},
+		{Name: "Moradia", Icon: "home", Color: "#8B5CF6", Type: schemas.CategoryTypeFixed, Kind: schemas.CategoryKindExpense},
+		{Name: "Saúde", Icon: "heart", Color: "#10B981", Type: schemas.CategoryTypeVariable, Kind: schemas.CategoryKindExpense},
+		{Name: "Educação", Icon: "book", Color: "#F59E0B", Type: schemas.CategoryTypeVariable, Kind: schemas.CategoryKindExpense},
+		{Name: "Lazer", Icon: "music", Color: "#6366F1", Type: schemas.CategoryTypeVariable, Kind: schemas.CategoryKindExpense},
+		{Name: "Salário", Icon: "wallet", Color: "#16A34A", Type: schemas.CategoryTypeFixed, Kind: schemas.CategoryKindIncome},
+		{Name: "Freelance", Icon: "briefcase", Color: "#0EA5E9", Type: schemas.CategoryTypeVariable, Kind: schemas.CategoryKindIncome},
+		{Name: "Reembolsos", Icon: "rotate-ccw", Color: "#14B8A6", Type: schemas.CategoryTypeVariable, Kind: schemas.CategoryKindIncome},
+		{Name: "Outras receitas", Icon: "plus-circle", Color: "#84CC16", Type: schemas.CategoryTypeVariable, Kind: schemas.CategoryKindIncome},
 	}
 
 	for index, item := range defaults {
 		category := schemas.Category{
-			UserID:   userID,
-			Name:     item.Name,
-			Icon:     item.Icon,
-			ColorHex: item.Color,
-			Type:     item.Type,
-			Order:    index,
-			Active:   true,
+			UserID:      userID,
+			HouseholdID: householdID,
+			Name:        item.Name,
+			Icon:        item.Icon,
+			ColorHex:    item.Color,
+			Type:        item.Type,
+			Kind:        item.Kind,
+			Order:       index,
+			Active:      true,
 		}
 		if err := tx.Create(&category).Error; err != nil {
 			return err
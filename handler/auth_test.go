@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/Pmmvito/Golang-Api-Exemple/service/mailer"
+)
+
+// useAdminEmails instala a lista de ADMIN_EMAILS e um mailer descartável durante o teste.
+func useAdminEmails(t *testing.T, emails ...string) {
+	t.Helper()
+
+	previousAdmins, previousMailer := adminEmails, mailSender
+	adminEmails = map[string]bool{}
+	for _, email := range emails {
+		adminEmails[email] = true
+	}
+	mailSender = mailer.NewLogMailer(io.Discard, "no-reply@example.com")
+	t.Cleanup(func() { adminEmails, mailSender = previousAdmins, previousMailer })
+}
+
+func loadUserRole(t *testing.T, email string) schemas.UserRole {
+	t.Helper()
+
+	user := schemas.User{}
+	if err := getDB().First(&user, "email = ?", email).Error; err != nil {
+		t.Fatalf("erro carregando usuário: %v", err)
+	}
+	return user.Role
+}
+
+func TestRegisterGrantsAdminOnlyAfterEmailVerification(t *testing.T) {
+	setupTestDB(t)
+	useAdminEmails(t, "root@example.com")
+
+	status, body := callHandler(t, RegisterHandler, nil, "POST", "/auth/register", RegisterRequest{Name: "Root", Email: "root@example.com", Password: "secret1"})
+	if status != 200 {
+		t.Fatalf("cadastro: status = %d: %v", status, body)
+	}
+	if role := loadUserRole(t, "root@example.com"); role != schemas.UserRoleUser {
+		t.Fatalf("papel após o cadastro = %s, esperado user enquanto o email não for verificado", role)
+	}
+	if data, _ := body["data"].(map[string]interface{}); data != nil {
+		if user, _ := data["user"].(map[string]interface{}); user["role"] != string(schemas.UserRoleUser) {
+			t.Fatalf("resposta do cadastro com papel %v", user["role"])
+		}
+	}
+
+	user := schemas.User{}
+	getDB().First(&user, "email = ?", "root@example.com")
+	raw, err := issueUserToken(getDB(), user.ID, schemas.UserTokenEmailVerification, time.Hour)
+	if err != nil {
+		t.Fatalf("issueUserToken: %v", err)
+	}
+	if status, body := callHandler(t, VerifyEmailHandler, nil, "POST", "/auth/verify-email", VerifyEmailRequest{Token: raw}); status != 200 {
+		t.Fatalf("verificação: status = %d: %v", status, body)
+	}
+	if role := loadUserRole(t, "root@example.com"); role != schemas.UserRoleAdmin {
+		t.Fatalf("papel após verificar o email = %s, esperado admin", role)
+	}
+}
+
+func TestPromoteVerifiedAdminsSkipsUnverifiedAccounts(t *testing.T) {
+	setupTestDB(t)
+	useAdminEmails(t, "root@example.com", "ops@example.com")
+
+	unverified := createTestUser(t, "root@example.com")
+	verified := createTestUser(t, "ops@example.com")
+	unlisted := createTestUser(t, "ana@example.com")
+	for _, user := range []*schemas.User{verified, unlisted} {
+		if err := getDB().Model(user).Update("email_verified_at", time.Now()).Error; err != nil {
+			t.Fatalf("erro verificando email: %v", err)
+		}
+	}
+
+	if err := promoteVerifiedAdmins(getDB()); err != nil {
+		t.Fatalf("promoteVerifiedAdmins: %v", err)
+	}
+
+	tests := []struct {
+		email string
+		want  schemas.UserRole
+	}{
+		{unverified.Email, schemas.UserRoleUser},
+		{verified.Email, schemas.UserRoleAdmin},
+		{unlisted.Email, schemas.UserRoleUser},
+	}
+	for _, tt := range tests {
+		if role := loadUserRole(t, tt.email); role != tt.want {
+			t.Errorf("%s: papel = %s, esperado %s", tt.email, role, tt.want)
+		}
+	}
+}
+
+func TestRefreshTokenRejectsDisabledAccount(t *testing.T) {
+	setupTestDB(t)
+	user := createTestUser(t, "ana@example.com")
+
+	issued, err := issueTokens(getDB(), user.ID, sessionClient{})
+	if err != nil {
+		t.Fatalf("issueTokens: %v", err)
+	}
+	if err := getDB().Model(user).Update("active", false).Error; err != nil {
+		t.Fatalf("erro desativando usuário: %v", err)
+	}
+
+	status, body := callHandler(t, RefreshTokenHandler, nil, "POST", "/auth/refresh", RefreshTokenRequest{RefreshToken: issued.RefreshToken})
+	if status != 403 {
+		t.Fatalf("conta desativada: status = %d, esperado 403: %v", status, body)
+	}
+
+	var sessions int64
+	getDB().Model(&schemas.Session{}).Where("user_id = ?", user.ID).Count(&sessions)
+	if sessions != 1 {
+		t.Fatalf("sessões = %d, esperado 1: nenhum token novo deveria ser emitido", sessions)
+	}
+
+	// A rotação foi desfeita: reativada a conta, o mesmo refresh token continua válido.
+	if err := getDB().Model(user).Update("active", true).Error; err != nil {
+		t.Fatalf("erro reativando usuário: %v", err)
+	}
+	if status, body := callHandler(t, RefreshTokenHandler, nil, "POST", "/auth/refresh", RefreshTokenRequest{RefreshToken: issued.RefreshToken}); status != 200 {
+		t.Fatalf("conta ativa: status = %d, esperado 200: %v", status, body)
+	}
+}
@@ -0,0 +1,594 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// budgetThresholds são os percentuais do limite que geram alertas.
+var budgetThresholds = []int{50, 80, 100}
+
+// maxRolloverMonths limita quantos meses anteriores entram no cálculo do saldo transportado.
+const maxRolloverMonths = 12
+
+// budgetAlertEmailTimeout limita o envio de cada email de alerta, feito fora da requisição.
+const budgetAlertEmailTimeout = 30 * time.Second
+
+// budgetPeriod é a situação de um orçamento em um mês: o limite base somado ao saldo transportado
+// e o total gasto na categoria, já considerando as divisões de despesas.
+type budgetPeriod struct {
+	Budget   *schemas.Budget
+	Rollover float64
+	Spent    float64
+}
+
+func (p budgetPeriod) limit() float64 {
+	return p.Budget.Amount + p.Rollover
+}
+
+func (p budgetPeriod) percentUsed() float64 {
+	limit := p.limit()
+	if limit <= 0 {
+		if p.Spent > 0 {
+			return 100
+		}
+		return 0
+	}
+	return p.Spent / limit * 100
+}
+
+// ListBudgetsHandler godoc
+// @Summary Listar orçamentos
+// @Description Lista os orçamentos mensais por categoria do escopo atual
+// @Tags Orçamentos
+// @Security Bearer
+// @Produce json
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} BudgetListSuccess
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /budgets [get]
+func ListBudgetsHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	var budgets []schemas.Budget
+	if err := getDataScope(ctx, user).apply(getDB().Preload("Category"), "budgets").
+		Order("created_at ASC").
+		Find(&budgets).Error; err != nil {
+		respondError(ctx, 500, "erro ao listar orçamentos", err.Error())
+		return
+	}
+
+	responses := make([]BudgetResponse, len(budgets))
+	for i := range budgets {
+		responses[i] = toBudgetResponse(&budgets[i])
+	}
+
+	respondSuccess(ctx, "orçamentos", responses)
+}
+
+// CreateBudgetHandler godoc
+// @Summary Criar orçamento
+// @Description Define o limite mensal de gastos de uma categoria, válido a partir do mês informado (ou do atual). Rollover: none (padrão), surplus (a sobra do mês anterior aumenta o limite) ou full (sobra e estouro passam para o mês seguinte). Cada categoria aceita um único orçamento.
+// @Tags Orçamentos
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body BudgetRequest true "Dados do orçamento"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} BudgetSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /budgets [post]
+func CreateBudgetHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	var request BudgetRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	categoryID, err := uuid.Parse(request.CategoryID)
+	if err != nil {
+		respondError(ctx, 400, "categoryId inválido", nil)
+		return
+	}
+	if !categoryInScope(getDB(), scope, categoryID, schemas.CategoryKindExpense) {
+		respondError(ctx, 403, "categoria não pertence ao usuário", nil)
+		return
+	}
+
+	var existing int64
+	if err := scope.apply(getDB().Model(&schemas.Budget{}), "budgets").
+		Where("category_id = ?", categoryID).
+		Count(&existing).Error; err != nil {
+		respondError(ctx, 500, "erro ao verificar orçamentos", err.Error())
+		return
+	}
+	if existing > 0 {
+		respondError(ctx, 409, "a categoria já possui um orçamento", nil)
+		return
+	}
+
+	if request.StartMonth == 0 {
+		now := time.Now()
+		request.StartMonth, request.StartYear = int(now.Month()), now.Year()
+	}
+	startMonth, _ := monthInterval(request.StartMonth, request.StartYear)
+
+	budget := schemas.Budget{
+		UserID:      user.ID,
+		HouseholdID: scope.HouseholdID,
+		CategoryID:  categoryID,
+		Amount:      request.Amount,
+		Rollover:    schemas.BudgetRollover(request.Rollover),
+		StartMonth:  startMonth,
+	}
+	if err := getDB().Create(&budget).Error; err != nil {
+		respondError(ctx, 500, "erro ao criar orçamento", err.Error())
+		return
+	}
+	checkBudgetAlerts(ctx.Request.Context(), scope, time.Now())
+
+	if err := getDB().Preload("Category").First(&budget, "id = ?", budget.ID).Error; err != nil {
+		respondError(ctx, 500, "erro ao carregar orçamento", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "orçamento criado", toBudgetResponse(&budget))
+}
+
+// UpdateBudgetHandler godoc
+// @Summary Atualizar orçamento
+// @Description Altera o limite mensal ou o tipo de rollover de um orçamento. O novo limite vale para todos os meses, inclusive no cálculo do saldo transportado.
+// @Tags Orçamentos
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "Identificador do orçamento"
+// @Param body body UpdateBudgetRequest true "Campos para atualização"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} BudgetSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /budgets/{id} [put]
+func UpdateBudgetHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	budgetID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	var request UpdateBudgetRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	budget := schemas.Budget{}
+	if err := scope.apply(getDB(), "budgets").Where("id = ?", budgetID).First(&budget).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "orçamento não encontrado", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao carregar orçamento", err.Error())
+		return
+	}
+
+	updates := map[string]interface{}{}
+	if request.Amount != nil {
+		updates["amount"] = *request.Amount
+	}
+	if request.Rollover != nil {
+		updates["rollover"] = *request.Rollover
+	}
+	if err := getDB().Model(&budget).Updates(updates).Error; err != nil {
+		respondError(ctx, 500, "erro ao atualizar orçamento", err.Error())
+		return
+	}
+	checkBudgetAlerts(ctx.Request.Context(), scope, time.Now())
+
+	if err := getDB().Preload("Category").First(&budget, "id = ?", budget.ID).Error; err != nil {
+		respondError(ctx, 500, "erro ao carregar orçamento", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "orçamento atualizado", toBudgetResponse(&budget))
+}
+
+// DeleteBudgetHandler godoc
+// @Summary Excluir orçamento
+// @Description Remove o orçamento da categoria junto com seus alertas
+// @Tags Orçamentos
+// @Security Bearer
+// @Param id path string true "Identificador do orçamento"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /budgets/{id} [delete]
+func DeleteBudgetHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	budgetID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		result := scope.apply(tx, "budgets").Where("id = ?", budgetID).Delete(&schemas.Budget{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Where("budget_id = ?", budgetID).Delete(&schemas.BudgetAlert{}).Error
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "orçamento não encontrado", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao excluir orçamento", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "orçamento excluído", nil)
+}
+
+// BudgetStatusHandler godoc
+// @Summary Situação dos orçamentos
+// @Description Retorna, para cada orçamento válido no mês, o limite (base + saldo transportado), o gasto na categoria considerando as divisões de despesas, o restante, o percentual usado, a projeção para o fim do mês e os limiares de alerta (50, 80 e 100%) já atingidos. A projeção do mês atual extrapola o ritmo diário de gastos; meses encerrados projetam o próprio gasto.
+// @Tags Orçamentos
+// @Security Bearer
+// @Produce json
+// @Param month query int false "Mês (1-12)"
+// @Param year query int false "Ano"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} BudgetStatusSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /budgets/status [get]
+func BudgetStatusHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	filter, err := buildExpenseFilter(ctx)
+	if err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	scope := getDataScope(ctx, user)
+	start, end := monthInterval(filter.Month, filter.Year)
+	periods, err := budgetPeriods(scope, start)
+	if err != nil {
+		respondError(ctx, 500, "erro ao calcular orçamentos", err.Error())
+		return
+	}
+
+	response := BudgetStatusResponse{
+		Month:   filter.Month,
+		Year:    filter.Year,
+		Budgets: make([]BudgetStatus, 0, len(periods)),
+	}
+	now := time.Now()
+	for _, period := range periods {
+		limit := period.limit()
+		projected := projectMonthEnd(period.Spent, start, end, now)
+		reached := []int{}
+		for _, threshold := range budgetThresholds {
+			if period.percentUsed() >= float64(threshold) {
+				reached = append(reached, threshold)
+			}
+		}
+
+		status := BudgetStatus{
+			BudgetID:          period.Budget.ID.String(),
+			CategoryID:        period.Budget.CategoryID.String(),
+			Amount:            period.Budget.Amount,
+			RolloverAmount:    roundFloat(period.Rollover),
+			Limit:             roundFloat(limit),
+			Spent:             roundFloat(period.Spent),
+			Remaining:         roundFloat(limit - period.Spent),
+			PercentUsed:       roundFloat(period.percentUsed()),
+			Projected:         roundFloat(projected),
+			ProjectedOver:     projected > limit,
+			ThresholdsReached: reached,
+		}
+		if period.Budget.Category != nil {
+			status.Category = toCategoryResponse(period.Budget.Category)
+		}
+		response.Budgets = append(response.Budgets, status)
+		response.TotalLimit += limit
+		response.TotalSpent += period.Spent
+		response.TotalProjected += projected
+	}
+	response.TotalLimit = roundFloat(response.TotalLimit)
+	response.TotalSpent = roundFloat(response.TotalSpent)
+	response.TotalProjected = roundFloat(response.TotalProjected)
+
+	respondSuccess(ctx, "situação dos orçamentos", response)
+}
+
+// ListBudgetAlertsHandler godoc
+// @Summary Listar alertas de orçamento
+// @Description Lista os alertas gerados no mês quando os gastos de uma categoria atingiram 50, 80 ou 100% do orçamento, do mais recente para o mais antigo
+// @Tags Orçamentos
+// @Security Bearer
+// @Produce json
+// @Param month query int false "Mês (1-12)"
+// @Param year query int false "Ano"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} BudgetAlertListSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /budgets/alerts [get]
+func ListBudgetAlertsHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	filter, err := buildExpenseFilter(ctx)
+	if err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	var alerts []schemas.BudgetAlert
+	if err := getDataScope(ctx, user).apply(getDB().Preload("Budget"), "budget_alerts").
+		Where("year = ? AND month = ?", filter.Year, filter.Month).
+		Order("created_at DESC").
+		Find(&alerts).Error; err != nil {
+		respondError(ctx, 500, "erro ao listar alertas", err.Error())
+		return
+	}
+
+	responses := make([]BudgetAlertResponse, len(alerts))
+	for i := range alerts {
+		responses[i] = toBudgetAlertResponse(&alerts[i])
+	}
+
+	respondSuccess(ctx, "alertas de orçamento", responses)
+}
+
+// budgetPeriods calcula a situação dos orçamentos do escopo válidos no mês que começa em monthStart.
+func budgetPeriods(scope dataScope, monthStart time.Time) ([]budgetPeriod, error) {
+	periods, err := budgetPeriodsBetween(scope, monthStart, monthStart)
+	if err != nil {
+		return nil, err
+	}
+	return periods[monthKey(monthStart)], nil
+}
+
+// budgetPeriodsBetween calcula a situação dos orçamentos em cada mês de from a to, indexada por monthKey. O
+// saldo transportado é acumulado mês a mês desde o início de cada orçamento, limitado a maxRolloverMonths
+// antes de from.
+func budgetPeriodsBetween(scope dataScope, from, to time.Time) (map[int][]budgetPeriod, error) {
+	var budgets []schemas.Budget
+	if err := scope.apply(getDB().Preload("Category"), "budgets").
+		Where("start_month <= ?", to).
+		Order("created_at ASC").
+		Find(&budgets).Error; err != nil {
+		return nil, err
+	}
+	result := map[int][]budgetPeriod{}
+	if len(budgets) == 0 {
+		return result, nil
+	}
+
+	first := from
+	for _, budget := range budgets {
+		if budget.Rollover != schemas.BudgetRolloverNone && budget.StartMonth.Before(first) {
+			first = budget.StartMonth
+		}
+	}
+	if earliest := from.AddDate(0, -maxRolloverMonths, 0); first.Before(earliest) {
+		first = earliest
+	}
+
+	carry := make([]float64, len(budgets))
+	for month := first; !month.After(to); month = month.AddDate(0, 1, 0) {
+		totals, err := categoryTotals(scope, month, month.AddDate(0, 1, 0))
+		if err != nil {
+			return nil, err
+		}
+		spent := map[uuid.UUID]float64{}
+		for _, total := range totals {
+			spent[total.CategoryID] = total.Total
+		}
+
+		for i := range budgets {
+			budget := &budgets[i]
+			if budget.StartMonth.After(month) {
+				continue
+			}
+			period := budgetPeriod{Budget: budget, Rollover: carry[i], Spent: spent[budget.CategoryID]}
+			if !month.Before(from) {
+				result[monthKey(month)] = append(result[monthKey(month)], period)
+			}
+
+			left := period.limit() - period.Spent
+			switch budget.Rollover {
+			case schemas.BudgetRolloverSurplus:
+				carry[i] = max(left, 0)
+			case schemas.BudgetRolloverFull:
+				carry[i] = left
+			default:
+				carry[i] = 0
+			}
+		}
+	}
+	return result, nil
+}
+
+func monthKey(date time.Time) int {
+	return date.Year()*12 + int(date.Month()) - 1
+}
+
+// projectMonthEnd estima o gasto no fim do mês. No mês corrente, extrapola o gasto médio por dia decorrido;
+// em meses encerrados ou futuros, o gasto registrado já é a projeção.
+func projectMonthEnd(spent float64, start, end, now time.Time) float64 {
+	if now.Before(start) || !now.Before(end) {
+		return spent
+	}
+	elapsed := now.Day()
+	days := end.AddDate(0, 0, -1).Day()
+	return spent / float64(elapsed) * float64(days)
+}
+
+// checkBudgetAlerts registra os alertas de 50, 80 e 100% ainda não emitidos nos meses das datas informadas
+// e avisa por email o dono do orçamento, em segundo plano. Falhas são apenas registradas no log para não
+// afetar a operação que alterou os gastos.
+func checkBudgetAlerts(ctx context.Context, scope dataScope, dates ...time.Time) {
+	if len(dates) == 0 {
+		return
+	}
+	months := map[int]time.Time{}
+	var from, to time.Time
+	for _, date := range dates {
+		monthStart, _ := monthInterval(int(date.Month()), date.Year())
+		months[monthKey(monthStart)] = monthStart
+		if from.IsZero() || monthStart.Before(from) {
+			from = monthStart
+		}
+		if monthStart.After(to) {
+			to = monthStart
+		}
+	}
+
+	periods, err := budgetPeriodsBetween(scope, from, to)
+	if err != nil {
+		getLogger().WarnF("não foi possível verificar orçamentos: %v", err)
+		return
+	}
+
+	for key, monthStart := range months {
+		for _, period := range periods[key] {
+			for _, threshold := range budgetThresholds {
+				if period.percentUsed() < float64(threshold) {
+					break
+				}
+				alert := schemas.BudgetAlert{
+					UserID:      period.Budget.UserID,
+					HouseholdID: period.Budget.HouseholdID,
+					BudgetID:    period.Budget.ID,
+					Year:        monthStart.Year(),
+					Month:       int(monthStart.Month()),
+					Threshold:   threshold,
+					Spent:       roundFloat(period.Spent),
+					Limit:       roundFloat(period.limit()),
+				}
+				result := getDB().WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&alert)
+				if result.Error != nil {
+					getLogger().WarnF("não foi possível registrar alerta de orçamento: %v", result.Error)
+					continue
+				}
+				if result.RowsAffected == 0 {
+					continue
+				}
+				go notifyBudgetAlert(ctx, period.Budget, alert)
+			}
+		}
+	}
+}
+
+// notifyBudgetAlert envia o email do alerta fora da requisição que o registrou, com prazo próprio: um servidor
+// de email lento não atrasa a resposta e o cancelamento da requisição não interrompe o envio.
+func notifyBudgetAlert(ctx context.Context, budget *schemas.Budget, alert schemas.BudgetAlert) {
+	sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), budgetAlertEmailTimeout)
+	defer cancel()
+	if err := sendBudgetAlertEmail(sendCtx, budget, &alert); err != nil {
+		getLogger().WarnF("não foi possível enviar alerta de orçamento: %v", err)
+	}
+}
+
+func sendBudgetAlertEmail(ctx context.Context, budget *schemas.Budget, alert *schemas.BudgetAlert) error {
+	owner := schemas.User{}
+	if err := getDB().WithContext(ctx).Preload("Config").First(&owner, "id = ?", budget.UserID).Error; err != nil {
+		return err
+	}
+	if owner.Config != nil && !owner.Config.NotificationsEnabled {
+		return nil
+	}
+
+	categoryName := "uma categoria"
+	if budget.Category != nil {
+		categoryName = budget.Category.Name
+	}
+	subject := fmt.Sprintf("Orçamento de %s atingiu %d%%", categoryName, alert.Threshold)
+	body := fmt.Sprintf("Olá, %s!\n\nOs gastos em %s somam %.2f em %02d/%d, %d%% do orçamento de %.2f.\n",
+		owner.Name, categoryName, alert.Spent, alert.Month, alert.Year, alert.Threshold, alert.Limit)
+	if alert.Threshold >= 100 {
+		body += "O limite do mês foi atingido.\n"
+	}
+	return sendMail(ctx, owner.Email, subject, body)
+}
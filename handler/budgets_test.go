@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/Pmmvito/Golang-Api-Exemple/service/mailer"
+)
+
+// blockingMailer segura cada envio até que release seja fechado, simulando um servidor de email lento.
+type blockingMailer struct {
+	release chan struct{}
+	sent    chan mailer.Message
+}
+
+func (m *blockingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	select {
+	case <-m.release:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	m.sent <- msg
+	return nil
+}
+
+func TestCheckBudgetAlertsSendsEmailInBackground(t *testing.T) {
+	setupTestDB(t)
+	user := createTestUser(t, "ana@example.com")
+	scope := personalScope(user.ID)
+
+	slow := &blockingMailer{release: make(chan struct{}), sent: make(chan mailer.Message, len(budgetThresholds))}
+	previousMailer := mailSender
+	mailSender = slow
+	t.Cleanup(func() { mailSender = previousMailer })
+
+	month := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+	category := schemas.Category{UserID: user.ID, Name: "Mercado", Kind: schemas.CategoryKindExpense, Active: true}
+	if err := getDB().Create(&category).Error; err != nil {
+		t.Fatalf("erro criando categoria: %v", err)
+	}
+	budget := schemas.Budget{UserID: user.ID, CategoryID: category.ID, Amount: 100, Rollover: schemas.BudgetRolloverNone, StartMonth: month}
+	if err := getDB().Create(&budget).Error; err != nil {
+		t.Fatalf("erro criando orçamento: %v", err)
+	}
+	expense := schemas.Expense{UserID: user.ID, CategoryID: category.ID, Description: "compra do mês", Amount: 85, Date: month.AddDate(0, 0, 9)}
+	if err := getDB().Create(&expense).Error; err != nil {
+		t.Fatalf("erro criando despesa: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		checkBudgetAlerts(ctx, scope, expense.Date)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("checkBudgetAlerts esperou o envio do email")
+	}
+	// O fim da requisição não pode interromper os envios pendentes.
+	cancel()
+
+	var alerts []schemas.BudgetAlert
+	if err := getDB().Where("budget_id = ?", budget.ID).Order("threshold ASC").Find(&alerts).Error; err != nil {
+		t.Fatalf("erro listando alertas: %v", err)
+	}
+	if len(alerts) != 2 || alerts[0].Threshold != 50 || alerts[1].Threshold != 80 {
+		t.Fatalf("alertas = %+v, esperado 50%% e 80%% registrados antes do envio", alerts)
+	}
+
+	close(slow.release)
+	subjects := map[string]bool{}
+	for range alerts {
+		select {
+		case msg := <-slow.sent:
+			if len(msg.To) != 1 || msg.To[0] != user.Email {
+				t.Fatalf("destinatário inesperado: %v", msg.To)
+			}
+			subjects[msg.Subject] = true
+		case <-time.After(5 * time.Second):
+			t.Fatal("email de alerta não foi enviado")
+		}
+	}
+	for _, want := range []string{"Orçamento de Mercado atingiu 50%", "Orçamento de Mercado atingiu 80%"} {
+		if !subjects[want] {
+			t.Errorf("email %q não enviado; recebidos %v", want, subjects)
+		}
+	}
+
+	// Alertas já registrados não geram novos emails.
+	checkBudgetAlerts(context.Background(), scope, expense.Date)
+	select {
+	case msg := <-slow.sent:
+		t.Fatalf("alerta repetido enviou email: %s", msg.Subject)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -17,8 +17,11 @@ import (
 // @Security Bearer
 // @Produce json
 // @Param status query string false "Filtra por status das categorias. Use true para apenas ativas, false para apenas inativas e deixe em branco ou use all para todas." Enums(true,false,all)
+// @Param kind query string false "Filtra por natureza: despesa ou receita" Enums(despesa,receita)
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
 // @Success 200 {object} CategoryListSuccess
 // @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
 // @Failure 500 {object} APIError
 // @Router /categories [get]
 func ListCategoriesHandler(ctx *gin.Context) {
@@ -30,7 +33,7 @@ func ListCategoriesHandler(ctx *gin.Context) {
 
 	status := strings.TrimSpace(ctx.Query("status"))
 
-	query := getDB().Where("user_id = ?", user.ID)
+	query := getDataScope(ctx, user).apply(getDB(), "categories")
 
 	switch {
 	case status == "" || strings.EqualFold(status, "all"):
@@ -59,6 +62,16 @@ func ListCategoriesHandler(ctx *gin.Context) {
 		}
 	}
 
+	if kind := strings.ToLower(strings.TrimSpace(ctx.Query("kind"))); kind != "" {
+		switch schemas.CategoryKind(kind) {
+		case schemas.CategoryKindExpense, schemas.CategoryKindIncome:
+			query = query.Where("kind = ?", kind)
+		default:
+			respondError(ctx, 400, "kind inválido (use despesa ou receita)", nil)
+			return
+		}
+	}
+
 	var categories []schemas.Category
 	if err := query.Order("\"order\" asc, name asc").Find(&categories).Error; err != nil {
 		respondError(ctx, 500, "erro ao listar categorias", err.Error())
@@ -78,15 +91,17 @@ func ListCategoriesHandler(ctx *gin.Context) {
 
 // CreateCategoryHandler godoc
 // @Summary Criar categoria
-// @Description Cria uma categoria personalizada para o usuário
+// @Description Cria uma categoria personalizada para o usuário. kind define se ela classifica despesas (padrão) ou receitas.
 // @Tags Categorias
 // @Security Bearer
 // @Accept json
 // @Produce json
 // @Param body body CategoryRequest true "Dados da categoria"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
 // @Success 200 {object} CategoryItemSuccess
 // @Failure 400 {object} APIError
 // @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
 // @Failure 500 {object} APIError
 // @Router /categories [post]
 func CreateCategoryHandler(ctx *gin.Context) {
@@ -96,6 +111,11 @@ func CreateCategoryHandler(ctx *gin.Context) {
 		return
 	}
 
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
 	var request CategoryRequest
 	if !bindJSON(ctx, &request) {
 		return
@@ -107,12 +127,14 @@ func CreateCategoryHandler(ctx *gin.Context) {
 	}
 
 	category := schemas.Category{
-		UUIDModel: schemas.UUIDModel{ID: uuid.New()},
-		UserID:    user.ID,
-		Name:      strings.TrimSpace(request.Name),
-		Icon:      strings.TrimSpace(request.Icon),
-		ColorHex:  strings.TrimSpace(request.ColorHex),
-		Type:      schemas.CategoryType(request.Type),
+		UUIDModel:   schemas.UUIDModel{ID: uuid.New()},
+		UserID:      user.ID,
+		HouseholdID: scope.HouseholdID,
+		Name:        strings.TrimSpace(request.Name),
+		Icon:        strings.TrimSpace(request.Icon),
+		ColorHex:    strings.TrimSpace(request.ColorHex),
+		Type:        schemas.CategoryType(request.Type),
+		Kind:        schemas.CategoryKind(request.Kind),
 	}
 
 	if request.Active != nil {
@@ -125,7 +147,7 @@ func CreateCategoryHandler(ctx *gin.Context) {
 		category.Order = *request.Order
 	}
 
-	columns := []string{"id", "user_id", "name", "icon", "color_hex", "type", "order", "active"}
+	columns := []string{"id", "user_id", "household_id", "name", "icon", "color_hex", "type", "kind", "order", "active"}
 	if err := getDB().Select(columns).Create(&category).Error; err != nil {
 		respondError(ctx, 500, "erro ao criar categoria", err.Error())
 		return
@@ -154,9 +176,11 @@ func CreateCategoryHandler(ctx *gin.Context) {
 // @Produce json
 // @Param id path string true "Identificador da categoria"
 // @Param body body CategoryRequest true "Campos para atualização"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
 // @Success 200 {object} CategoryItemSuccess
 // @Failure 400 {object} APIError
 // @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
 // @Failure 404 {object} APIError
 // @Failure 500 {object} APIError
 // @Router /categories/{id} [put]
@@ -167,6 +191,11 @@ func UpdateCategoryHandler(ctx *gin.Context) {
 		return
 	}
 
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
 	idParam := ctx.Param("id")
 	categoryID, err := uuid.Parse(idParam)
 	if err != nil {
@@ -215,7 +244,7 @@ func UpdateCategoryHandler(ctx *gin.Context) {
 	}
 
 	var category schemas.Category
-	if err := getDB().Where("id = ? AND user_id = ?", categoryID, user.ID).First(&category).Error; err != nil {
+	if err := scope.apply(getDB(), "categories").Where("id = ?", categoryID).First(&category).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			respondError(ctx, 404, "categoria não encontrada", nil)
 			return
@@ -243,8 +272,10 @@ func UpdateCategoryHandler(ctx *gin.Context) {
 // @Tags Categorias
 // @Security Bearer
 // @Param id path string true "Identificador da categoria"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
 // @Success 200 {object} APISuccess
 // @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
 // @Failure 404 {object} APIError
 // @Failure 500 {object} APIError
 // @Router /categories/{id} [delete]
@@ -255,6 +286,11 @@ func DeleteCategoryHandler(ctx *gin.Context) {
 		return
 	}
 
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
 	idParam := ctx.Param("id")
 	categoryID, err := uuid.Parse(idParam)
 	if err != nil {
@@ -262,8 +298,8 @@ func DeleteCategoryHandler(ctx *gin.Context) {
 		return
 	}
 
-	result := getDB().Model(&schemas.Category{}).
-		Where("id = ? AND user_id = ?", categoryID, user.ID).
+	result := scope.apply(getDB().Model(&schemas.Category{}), "categories").
+		Where("id = ?", categoryID).
 		Updates(map[string]interface{}{"active": false})
 
 	if result.Error != nil {
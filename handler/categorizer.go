@@ -0,0 +1,480 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/Pmmvito/Golang-Api-Exemple/service/gemini"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	categorySourceHistory = "history"
+	categorySourceKeyword = "keyword"
+	categorySourceLLM     = "llm"
+)
+
+// categoryKeywordHints liga palavras comuns em nomes de estabelecimentos e produtos a trechos do nome das
+// categorias do usuário, sem acentos. Palavras com menos de cinco letras só valem inteiras; as demais também
+// como início de palavra ("drogari" vale para "drogaria" e "drogarias").
+var categoryKeywordHints = []struct {
+	keywords   []string
+	categories []string
+}{
+	{[]string{"supermercado", "mercado", "mercearia", "atacad", "hortifruti", "sacolao", "acougue", "padaria", "panificadora", "feira"}, []string{"mercado", "alimenta", "compras"}},
+	{[]string{"restaurante", "lanchonete", "pizzaria", "hamburgueria", "churrascaria", "ifood", "cafeteria", "sorveteria", "bar"}, []string{"restaurante", "alimenta", "lazer"}},
+	{[]string{"farmacia", "drogari", "drogasil", "hospital", "clinica", "laboratorio", "dentista", "remedio"}, []string{"farmacia", "saude"}},
+	{[]string{"posto", "combustivel", "gasolina", "etanol", "diesel", "uber", "taxi", "estacionamento", "pedagio", "onibus", "metro"}, []string{"transporte", "combustivel", "carro", "veiculo"}},
+	{[]string{"energia", "eletric", "luz", "agua", "saneamento", "gas", "aluguel", "condominio", "internet", "telefon"}, []string{"moradia", "casa", "contas"}},
+	{[]string{"cinema", "teatro", "show", "ingresso", "netflix", "spotify", "streaming"}, []string{"lazer", "entretenimento", "assinatura"}},
+	{[]string{"escola", "faculdade", "curso", "livraria", "livro", "papelaria"}, []string{"educa"}},
+	{[]string{"pet", "petshop", "veterinari", "racao"}, []string{"pet", "animais"}},
+	{[]string{"roupa", "calcado", "vestuario", "sapataria"}, []string{"vestuario", "roupa", "compras"}},
+}
+
+// SuggestCategoryHandler godoc
+// @Summary Sugerir categoria de despesa
+// @Description Sugere, entre as categorias de despesa ativas do escopo, a categoria da descrição informada e de cada item. A sugestão vem, nesta ordem, do histórico confirmado pelo usuário (correções feitas ao editar despesas e despesas anteriores do mesmo estabelecimento ou descrição), de palavras-chave e, quando nada disso resolve e useAi não é false, do Gemini. Itens sem sugestão própria ficam na categoria da descrição. source indica a origem: history, keyword ou llm.
+// @Tags Despesas
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body SuggestCategoryRequest true "Descrição, estabelecimento e itens do lançamento"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} CategorySuggestionSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /expenses/suggest-category [post]
+func SuggestCategoryHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	// A sugestão só lê dados do escopo, então também atende quem apenas visualiza o grupo familiar.
+	scope := getDataScope(ctx, user)
+
+	var request SuggestCategoryRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	db := getDB().WithContext(ctx.Request.Context())
+	c, err := newCategorizer(db, scope)
+	if err != nil {
+		respondError(ctx, 500, "erro ao carregar categorias", err.Error())
+		return
+	}
+
+	var merchantID *uuid.UUID
+	if request.MerchantID != "" {
+		id, err := uuid.Parse(request.MerchantID)
+		if err != nil {
+			respondError(ctx, 400, "merchantId inválido", nil)
+			return
+		}
+		var count int64
+		if err := scope.apply(db.Model(&schemas.Merchant{}), "merchants").Where("merchants.id = ?", id).Count(&count).Error; err != nil {
+			respondError(ctx, 500, "erro ao carregar estabelecimento", err.Error())
+			return
+		}
+		if count == 0 {
+			respondError(ctx, 404, "estabelecimento não encontrado", nil)
+			return
+		}
+		merchantID = &id
+	} else if request.Description != "" {
+		merchant, err := findMerchant(db, scope, request.Description, "")
+		if err != nil {
+			respondError(ctx, 500, "erro ao carregar estabelecimento", err.Error())
+			return
+		}
+		if merchant != nil {
+			merchantID = &merchant.ID
+		}
+	}
+
+	var main *categorySuggestion
+	if request.Description != "" || merchantID != nil {
+		main = c.suggest(merchantID, request.Description)
+	}
+	items := make([]*categorySuggestion, len(request.Items))
+	for i, name := range request.Items {
+		items[i] = c.suggest(nil, name)
+	}
+
+	if request.UseAI == nil || *request.UseAI {
+		// Uma única chamada ao modelo cobre a descrição e os itens que ficaram sem sugestão.
+		var texts []string
+		var targets []**categorySuggestion
+		if main == nil && request.Description != "" {
+			texts = append(texts, request.Description)
+			targets = append(targets, &main)
+		}
+		for i := range items {
+			if items[i] == nil {
+				texts = append(texts, request.Items[i])
+				targets = append(targets, &items[i])
+			}
+		}
+		if len(texts) > 0 {
+			ids, err := suggestCategoriesWithAI(ctx.Request.Context(), user, c, texts)
+			if err != nil {
+				getLogger().WarnF("falha ao sugerir categoria com gemini: %v", err)
+			}
+			for i, id := range ids {
+				if i < len(targets) {
+					*targets[i] = c.fromModel(id)
+				}
+			}
+		}
+	}
+
+	response := CategorySuggestionResponse{Items: []ItemCategorySuggestionResponse{}}
+	if main != nil {
+		response.CategoryID = uuidPtrString(&main.Category.ID)
+		response.Category = toCategoryResponse(main.Category)
+		response.Source = main.Source
+	}
+	for i, name := range request.Items {
+		item := ItemCategorySuggestionResponse{Name: name}
+		suggestion := items[i]
+		if suggestion == nil {
+			suggestion = main
+		}
+		if suggestion != nil {
+			item.CategoryID = uuidPtrString(&suggestion.Category.ID)
+			item.Source = suggestion.Source
+		}
+		response.Items = append(response.Items, item)
+	}
+
+	respondSuccess(ctx, "sugestão de categoria", response)
+}
+
+// categorySuggestion é a categoria escolhida pelo categorizer e a etapa que a escolheu.
+type categorySuggestion struct {
+	Category *schemas.Category
+	Source   string
+}
+
+// categorizer escolhe, entre as categorias de despesa ativas do escopo, a que melhor descreve uma despesa ou um
+// item. A ordem de preferência é o histórico confirmado pelo usuário, depois as palavras-chave e por fim a
+// resposta do modelo, conferida contra as categorias do escopo.
+type categorizer struct {
+	db         *gorm.DB
+	scope      dataScope
+	categories []schemas.Category
+	names      []string
+	rules      []schemas.CategoryRule
+}
+
+func newCategorizer(db *gorm.DB, scope dataScope) (*categorizer, error) {
+	c := &categorizer{db: db, scope: scope}
+	if err := scope.apply(db.Model(&schemas.Category{}), "categories").
+		Where("categories.kind = ? AND categories.active = ?", schemas.CategoryKindExpense, true).
+		Order("categories.name ASC").
+		Find(&c.categories).Error; err != nil {
+		return nil, err
+	}
+	c.names = make([]string, len(c.categories))
+	for i := range c.categories {
+		c.names[i] = normalizeMerchantName(c.categories[i].Name)
+	}
+
+	if err := scope.apply(db.Model(&schemas.CategoryRule{}), "category_rules").
+		Order("category_rules.hits DESC, category_rules.updated_at DESC").
+		Find(&c.rules).Error; err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// suggest consulta o histórico e, sem resultado, as palavras-chave. O modelo fica a cargo de quem chama, que
+// decide se vale a chamada.
+func (c *categorizer) suggest(merchantID *uuid.UUID, text string) *categorySuggestion {
+	if suggestion := c.fromHistory(merchantID, text); suggestion != nil {
+		return suggestion
+	}
+	return c.fromKeywords(text)
+}
+
+// fromHistory usa as regras aprendidas nas correções do usuário, primeiro as do estabelecimento e depois as da
+// descrição, e então a despesa mais recente do mesmo estabelecimento ou com a mesma descrição. Despesas deixadas
+// na categoria genérica de OCR não contam como escolha do usuário.
+func (c *categorizer) fromHistory(merchantID *uuid.UUID, text string) *categorySuggestion {
+	pattern := normalizeMerchantName(text)
+	if merchantID != nil {
+		for _, rule := range c.rules {
+			if rule.MerchantID != nil && *rule.MerchantID == *merchantID {
+				if category := c.category(rule.CategoryID); category != nil {
+					return &categorySuggestion{Category: category, Source: categorySourceHistory}
+				}
+			}
+		}
+	}
+	if pattern != "" {
+		for _, rule := range c.rules {
+			if rule.MerchantID == nil && rule.Pattern == pattern {
+				if category := c.category(rule.CategoryID); category != nil {
+					return &categorySuggestion{Category: category, Source: categorySourceHistory}
+				}
+			}
+		}
+	}
+
+	queries := []*gorm.DB{}
+	if merchantID != nil {
+		queries = append(queries, c.scope.apply(c.db.Model(&schemas.Expense{}), "expenses").Where("expenses.merchant_id = ?", *merchantID))
+	}
+	if description := strings.ToLower(strings.TrimSpace(text)); description != "" {
+		queries = append(queries, c.scope.apply(c.db.Model(&schemas.Expense{}), "expenses").Where("LOWER(expenses.description) = ?", description))
+	}
+	for _, query := range queries {
+		var expenses []schemas.Expense
+		if err := query.Order("expenses.date DESC, expenses.created_at DESC").Limit(5).Find(&expenses).Error; err != nil {
+			continue
+		}
+		for _, expense := range expenses {
+			if category := c.category(expense.CategoryID); category != nil && category.Name != defaultOcrCategoryName {
+				return &categorySuggestion{Category: category, Source: categorySourceHistory}
+			}
+		}
+	}
+	return nil
+}
+
+// fromKeywords procura primeiro o nome de uma categoria do usuário no texto, preferindo o nome mais longo, e
+// depois as palavras de categoryKeywordHints.
+func (c *categorizer) fromKeywords(text string) *categorySuggestion {
+	words := strings.Fields(normalizeMerchantName(text))
+	if len(words) == 0 {
+		return nil
+	}
+
+	joined := " " + strings.Join(words, " ") + " "
+	best := -1
+	for i, name := range c.names {
+		if len(name) < 3 || c.categories[i].Name == defaultOcrCategoryName {
+			continue
+		}
+		if strings.Contains(joined, " "+name+" ") && (best < 0 || len(name) > len(c.names[best])) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		return &categorySuggestion{Category: &c.categories[best], Source: categorySourceKeyword}
+	}
+
+	for _, hint := range categoryKeywordHints {
+		if !containsKeyword(words, hint.keywords) {
+			continue
+		}
+		for _, fragment := range hint.categories {
+			for i, name := range c.names {
+				if strings.Contains(name, fragment) && c.categories[i].Name != defaultOcrCategoryName {
+					return &categorySuggestion{Category: &c.categories[i], Source: categorySourceKeyword}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// fromModel aceita a categoria escolhida pelo modelo apenas se for uma das categorias oferecidas no prompt.
+func (c *categorizer) fromModel(rawID string) *categorySuggestion {
+	id, err := uuid.Parse(strings.TrimSpace(rawID))
+	if err != nil {
+		return nil
+	}
+	category := c.category(id)
+	if category == nil || category.Name == defaultOcrCategoryName {
+		return nil
+	}
+	return &categorySuggestion{Category: category, Source: categorySourceLLM}
+}
+
+func (c *categorizer) category(id uuid.UUID) *schemas.Category {
+	for i := range c.categories {
+		if c.categories[i].ID == id {
+			return &c.categories[i]
+		}
+	}
+	return nil
+}
+
+// promptCategories lista as categorias que o modelo pode escolher, uma por linha com id e nome.
+func (c *categorizer) promptCategories() string {
+	var builder strings.Builder
+	for _, category := range c.categories {
+		if category.Name == defaultOcrCategoryName {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("- %s: %s\n", category.ID, category.Name))
+	}
+	return builder.String()
+}
+
+func containsKeyword(words, keywords []string) bool {
+	for _, word := range words {
+		for _, keyword := range keywords {
+			if word == keyword || (len(keyword) >= 5 && strings.HasPrefix(word, keyword)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// categorizeReceipt escolhe a categoria do recibo e de cada item, usando a resposta do scan como etapa do modelo.
+// Itens sem sugestão própria ficam na categoria do recibo; sem categoria do recibo, a despesa irá para "Compras OCR".
+func categorizeReceipt(c *categorizer, response *ReceiptScanResponse, result *receiptLLMResult) {
+	var merchantID *uuid.UUID
+	if merchant, err := findMerchant(c.db, c.scope, response.Merchant, response.MerchantCNPJ); err != nil {
+		getLogger().WarnF("não foi possível buscar estabelecimento do recibo: %v", err)
+	} else if merchant != nil {
+		merchantID = &merchant.ID
+	}
+
+	var main *categorySuggestion
+	if response.Merchant != "" || merchantID != nil {
+		main = c.suggest(merchantID, response.Merchant)
+	}
+	if main == nil && result != nil {
+		main = c.fromModel(result.CategoryID)
+	}
+	response.CategoryID, response.CategorySource = nil, ""
+	if main != nil {
+		response.CategoryID = uuidPtrString(&main.Category.ID)
+		response.CategorySource = main.Source
+	}
+
+	for i := range response.Items {
+		suggestion := c.suggest(nil, response.Items[i].Description)
+		if suggestion == nil {
+			suggestion = c.fromModel(response.Items[i].CategoryID)
+		}
+		if suggestion == nil {
+			suggestion = main
+		}
+		response.Items[i].CategoryID = ""
+		if suggestion != nil {
+			response.Items[i].CategoryID = suggestion.Category.ID.String()
+		}
+	}
+}
+
+// learnCategoryRule registra a categoria escolhida pelo usuário ao corrigir uma despesa: pelo estabelecimento,
+// quando a despesa tem um, ou pela descrição normalizada.
+func learnCategoryRule(tx *gorm.DB, user *schemas.User, scope dataScope, merchantID *uuid.UUID, description string, categoryID uuid.UUID) error {
+	pattern := normalizeMerchantName(description)
+	if merchantID == nil && pattern == "" {
+		return nil
+	}
+
+	query := scope.apply(tx.Model(&schemas.CategoryRule{}), "category_rules")
+	if merchantID != nil {
+		query = query.Where("category_rules.merchant_id = ?", *merchantID)
+	} else {
+		query = query.Where("category_rules.merchant_id IS NULL AND category_rules.pattern = ?", pattern)
+	}
+	var rules []schemas.CategoryRule
+	if err := query.Limit(1).Find(&rules).Error; err != nil {
+		return err
+	}
+
+	if len(rules) > 0 {
+		updates := map[string]interface{}{"category_id": categoryID, "hits": 1}
+		if rules[0].CategoryID == categoryID {
+			updates["hits"] = gorm.Expr("hits + 1")
+		}
+		return tx.Model(&rules[0]).Updates(updates).Error
+	}
+
+	return tx.Create(&schemas.CategoryRule{
+		UserID:      user.ID,
+		HouseholdID: scope.HouseholdID,
+		MerchantID:  merchantID,
+		Pattern:     pattern,
+		CategoryID:  categoryID,
+		Hits:        1,
+	}).Error
+}
+
+// suggestCategoriesWithAI pede ao Gemini a categoria de cada texto entre as do escopo e registra o consumo de
+// tokens. Devolve um id por texto, na mesma ordem; vazio quando o modelo não escolheu nenhuma.
+func suggestCategoriesWithAI(ctx context.Context, user *schemas.User, c *categorizer, texts []string) ([]string, error) {
+	categories := c.promptCategories()
+	if categories == "" {
+		return nil, nil
+	}
+
+	client, err := gemini.NewClientFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	model := detectModelName()
+	request := gemini.GenerateContentRequest{
+		Contents: []gemini.Content{
+			{
+				Role:  "user",
+				Parts: []gemini.ContentPart{gemini.NewTextPart(buildCategorizePrompt(categories, texts))},
+			},
+		},
+	}
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	result, err := client.GenerateContent(ctxTimeout, request)
+	if err != nil {
+		return nil, err
+	}
+
+	recordCtx, cancelRecord := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelRecord()
+	if _, logErr := recordTokenUsage(recordCtx, user.ID, schemas.RequestTypeCategorize, result.Usage, datatypes.JSONMap{
+		"texts": len(texts),
+		"model": model,
+	}); logErr != nil {
+		getLogger().WarnF("não foi possível registrar uso de tokens: %v", logErr)
+	}
+
+	var payload struct {
+		Categories []string `json:"categories"`
+	}
+	if err := json.Unmarshal([]byte(gemini.SanitizeJSON(result.Text)), &payload); err != nil {
+		return nil, fmt.Errorf("resposta do modelo inválida: %w", err)
+	}
+	return payload.Categories, nil
+}
+
+func buildCategorizePrompt(categories string, texts []string) string {
+	var builder strings.Builder
+	builder.WriteString("Você é um assistente de finanças que classifica despesas.\n")
+	builder.WriteString("Para cada lançamento abaixo, escolha a categoria que melhor o descreve entre as categorias disponíveis.\n")
+	builder.WriteString("Retorne apenas JSON, sem comentários nem texto adicional, no formato {\"categories\": [string]}, com o id da categoria de cada lançamento na mesma ordem e string vazia quando nenhuma servir.\n")
+	builder.WriteString("Categorias disponíveis (id: nome):\n")
+	builder.WriteString(categories)
+	builder.WriteString("Lançamentos:\n")
+	for i, text := range texts {
+		builder.WriteString(fmt.Sprintf("%d. %s\n", i+1, text))
+	}
+	return builder.String()
+}
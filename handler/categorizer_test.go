@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestSuggestCategoryAllowsHouseholdViewers(t *testing.T) {
+	setupTestDB(t)
+	owner := createTestUser(t, "ana@example.com")
+	viewer := createTestUser(t, "bia@example.com")
+	householdID := uuid.New()
+
+	category := schemas.Category{UserID: owner.ID, HouseholdID: &householdID, Name: "Padaria", Type: schemas.CategoryTypeVariable, Kind: schemas.CategoryKindExpense, Active: true}
+	if err := getDB().Create(&category).Error; err != nil {
+		t.Fatalf("erro criando categoria: %v", err)
+	}
+	expense := schemas.Expense{UserID: owner.ID, HouseholdID: &householdID, CategoryID: category.ID, Description: "Pão de queijo da esquina", Amount: 12, Date: time.Now()}
+	if err := getDB().Create(&expense).Error; err != nil {
+		t.Fatalf("erro criando despesa: %v", err)
+	}
+
+	asViewer := func(ctx *gin.Context) {
+		ctx.Set(contextScopeKey, dataScope{UserID: viewer.ID, HouseholdID: &householdID, Role: schemas.HouseholdRoleViewer})
+		SuggestCategoryHandler(ctx)
+	}
+	useAI := false
+	status, body := callHandler(t, asViewer, viewer, "POST", "/expenses/suggest-category", SuggestCategoryRequest{Description: "Pão de queijo da esquina", UseAI: &useAI})
+	if status != 200 {
+		t.Fatalf("status = %d, esperado 200: %v", status, body)
+	}
+
+	data, _ := body["data"].(map[string]interface{})
+	if data["categoryId"] != category.ID.String() || data["source"] != categorySourceHistory {
+		t.Fatalf("sugestão = %v, esperado a categoria do grupo familiar %s pelo histórico", data, category.ID)
+	}
+}
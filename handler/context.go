@@ -10,6 +10,8 @@ import (
 const (
 	contextUserKey    = "currentUser"
 	contextSessionKey = "currentSession"
+	contextAPIKeyKey  = "currentAPIKey"
+	contextScopeKey   = "currentDataScope"
 )
 
 var errUserNotFound = errors.New("usuário não encontrado no contexto")
@@ -46,3 +48,34 @@ func getCurrentSession(ctx *gin.Context) (*schemas.Session, bool) {
 	}
 	return session, true
 }
+
+func setCurrentAPIKey(ctx *gin.Context, key *schemas.APIKey) {
+	ctx.Set(contextAPIKeyKey, key)
+}
+
+// getCurrentAPIKey retorna a chave de API usada na requisição; requisições autenticadas por sessão não têm chave.
+func getCurrentAPIKey(ctx *gin.Context) (*schemas.APIKey, bool) {
+	value, exists := ctx.Get(contextAPIKeyKey)
+	if !exists {
+		return nil, false
+	}
+	key, ok := value.(*schemas.APIKey)
+	if !ok {
+		return nil, false
+	}
+	return key, true
+}
+
+func setDataScope(ctx *gin.Context, scope dataScope) {
+	ctx.Set(contextScopeKey, scope)
+}
+
+// getDataScope retorna o escopo definido por HouseholdContext ou, na ausência dele, os dados pessoais do usuário.
+func getDataScope(ctx *gin.Context, user *schemas.User) dataScope {
+	if value, exists := ctx.Get(contextScopeKey); exists {
+		if scope, ok := value.(dataScope); ok {
+			return scope
+		}
+	}
+	return personalScope(user.ID)
+}
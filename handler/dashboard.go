@@ -15,15 +15,18 @@ type categoryTotal struct {
 
 // DashboardSummaryHandler godoc
 // @Summary Resumo do dashboard
-// @Description Retorna totais do mês atual e comparação com o mês anterior
+// @Description Retorna totais do mês atual, comparação com o mês anterior e o saldo do mês (receitas menos despesas, com a taxa de poupança)
 // @Tags Dashboard
 // @Security Bearer
 // @Produce json
 // @Param month query int false "Mês (1-12)"
 // @Param year query int false "Ano"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
 // @Success 200 {object} DashboardSummaryResponse
 // @Failure 400 {object} APIError
 // @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
 // @Router /dashboard/summary [get]
 func DashboardSummaryHandler(ctx *gin.Context) {
 	user, err := getAuthenticatedUser(ctx)
@@ -42,10 +45,16 @@ func DashboardSummaryHandler(ctx *gin.Context) {
 	previousStart := currentStart.AddDate(0, -1, 0)
 	previousEnd := currentStart
 
-	currentTotal := aggregateTotal(user.ID, currentStart, currentEnd)
-	previousTotal := aggregateTotal(user.ID, previousStart, previousEnd)
+	scope := getDataScope(ctx, user)
+	currentTotal := aggregateTotal(scope, currentStart, currentEnd)
+	previousTotal := aggregateTotal(scope, previousStart, previousEnd)
 
-	topCategories := fetchTopCategories(user.ID, currentStart, currentEnd)
+	topCategories, err := fetchTopCategories(scope, currentStart, currentEnd)
+	if err != nil {
+		respondError(ctx, 500, "erro ao calcular gastos por categoria", err.Error())
+		return
+	}
+	income := aggregateIncome(scope, currentStart, currentEnd)
 
 	variation := 0.0
 	if previousTotal > 0 {
@@ -59,29 +68,113 @@ func DashboardSummaryHandler(ctx *gin.Context) {
 		PreviousTotal: roundFloat(previousTotal),
 		VariationPct:  roundFloat(variation),
 		TopCategories: topCategories,
+		TotalIncome:   roundFloat(income),
+		Net:           roundFloat(income - currentTotal),
+		SavingsRate:   savingsRate(income, currentTotal),
 	}
 
 	respondSuccess(ctx, "dashboard", response)
 }
 
-func aggregateTotal(userID uuid.UUID, start, end time.Time) float64 {
+// CashFlowHandler godoc
+// @Summary Fluxo de caixa mensal
+// @Description Retorna receitas, despesas, saldo e taxa de poupança (saldo / receitas) de cada mês, terminando no mês informado (ou no atual), e os totais do período
+// @Tags Dashboard
+// @Security Bearer
+// @Produce json
+// @Param month query int false "Último mês do período (1-12)"
+// @Param year query int false "Ano do último mês"
+// @Param months query int false "Quantidade de meses (padrão 6, máximo 24)"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} CashFlowSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Router /dashboard/cash-flow [get]
+func CashFlowHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	filter, err := buildExpenseFilter(ctx)
+	if err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	months := parseIntDefault(ctx.Query("months"), 6)
+	if months <= 0 {
+		months = 6
+	}
+	if months > 24 {
+		months = 24
+	}
+
+	scope := getDataScope(ctx, user)
+	lastStart, _ := monthInterval(filter.Month, filter.Year)
+
+	response := CashFlowResponse{Months: make([]CashFlowMonth, 0, months)}
+	var totalIncome, totalExpenses float64
+	for i := months - 1; i >= 0; i-- {
+		start := lastStart.AddDate(0, -i, 0)
+		end := start.AddDate(0, 1, 0)
+		income := aggregateIncome(scope, start, end)
+		expenses := aggregateTotal(scope, start, end)
+		totalIncome += income
+		totalExpenses += expenses
+		response.Months = append(response.Months, CashFlowMonth{
+			Month:       int(start.Month()),
+			Year:        start.Year(),
+			Income:      roundFloat(income),
+			Expenses:    roundFloat(expenses),
+			Net:         roundFloat(income - expenses),
+			SavingsRate: savingsRate(income, expenses),
+		})
+	}
+	response.TotalIncome = roundFloat(totalIncome)
+	response.TotalExpenses = roundFloat(totalExpenses)
+	response.Net = roundFloat(totalIncome - totalExpenses)
+	response.SavingsRate = savingsRate(totalIncome, totalExpenses)
+
+	respondSuccess(ctx, "fluxo de caixa", response)
+}
+
+func aggregateTotal(scope dataScope, start, end time.Time) float64 {
+	var total float64
+	scope.apply(getDB().Model(&schemas.Expense{}), "expenses").
+		Select("COALESCE(SUM(amount),0)").
+		Where("date >= ? AND date < ?", start, end).
+		Scan(&total)
+	return total
+}
+
+func aggregateIncome(scope dataScope, start, end time.Time) float64 {
 	var total float64
-	getDB().Model(&schemas.Expense{}).
+	scope.apply(getDB().Model(&schemas.Income{}), "incomes").
 		Select("COALESCE(SUM(amount),0)").
-		Where("user_id = ? AND date >= ? AND date < ?", userID, start, end).
+		Where("date >= ? AND date < ?", start, end).
 		Scan(&total)
 	return total
 }
 
-func fetchTopCategories(userID uuid.UUID, start, end time.Time) []CategoryAggregate {
-	totals := []categoryTotal{}
-	getDB().Model(&schemas.Expense{}).
-		Select("category_id, SUM(amount) as total").
-		Where("user_id = ? AND date >= ? AND date < ?", userID, start, end).
-		Group("category_id").
-		Order("total DESC").
-		Limit(5).
-		Scan(&totals)
+// savingsRate é o percentual da receita que sobrou após as despesas; sem receita, é zero.
+func savingsRate(income, expenses float64) float64 {
+	if income <= 0 {
+		return 0
+	}
+	return roundFloat((income - expenses) / income * 100)
+}
+
+func fetchTopCategories(scope dataScope, start, end time.Time) ([]CategoryAggregate, error) {
+	totals, err := categoryTotals(scope, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(totals) > 5 {
+		totals = totals[:5]
+	}
 
 	responses := make([]CategoryAggregate, 0, len(totals))
 	for _, total := range totals {
@@ -94,5 +187,5 @@ func fetchTopCategories(userID uuid.UUID, start, end time.Time) []CategoryAggreg
 			Total:    roundFloat(total.Total),
 		})
 	}
-	return responses
+	return responses, nil
 }
@@ -3,6 +3,7 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -18,22 +19,114 @@ type RegisterRequest struct {
 	MonthlyLimit *float64 `json:"monthlyLimit,omitempty"`
 	Language     string   `json:"language"`
 	Theme        string   `json:"theme"`
+	DeviceName   string   `json:"deviceName,omitempty"`
 }
 
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	DeviceName string `json:"deviceName,omitempty"`
 }
 
 type LogoutRequest struct {
 	AllDevices bool `json:"allDevices"`
 }
 
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken"`
+	DeviceName   string `json:"deviceName,omitempty"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+type UpdateProfileRequest struct {
+	Name            *string `json:"name,omitempty"`
+	Email           *string `json:"email,omitempty"`
+	CurrentPassword string  `json:"currentPassword,omitempty"`
+}
+
+type UpdateConfigRequest struct {
+	Currency             string   `json:"currency"`
+	MonthlyLimit         *float64 `json:"monthlyLimit,omitempty"`
+	Language             string   `json:"language"`
+	Theme                string   `json:"theme"`
+	NotificationsEnabled *bool    `json:"notificationsEnabled,omitempty"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+type OIDCLoginRequest struct {
+	IDToken    string `json:"idToken"`
+	DeviceName string `json:"deviceName,omitempty"`
+}
+
+type HouseholdRequest struct {
+	Name string `json:"name"`
+}
+
+type HouseholdInvitationRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+type AcceptHouseholdInvitationRequest struct {
+	Token string `json:"token"`
+}
+
+type UpdateHouseholdMemberRequest struct {
+	Role string `json:"role"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type AccountDeletionRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code,omitempty"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role"`
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code"`
+}
+
+type MFADisableRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+type MFAVerifyRequest struct {
+	MFAToken   string `json:"mfaToken"`
+	Code       string `json:"code"`
+	DeviceName string `json:"deviceName,omitempty"`
+}
+
 type CategoryRequest struct {
 	Name     string `json:"name"`
 	Icon     string `json:"icon"`
 	ColorHex string `json:"colorHex"`
 	Type     string `json:"type"`
+	Kind     string `json:"kind"`
 	Order    *int   `json:"order,omitempty"`
 	Active   *bool  `json:"active,omitempty"`
 }
@@ -45,6 +138,7 @@ type ExpenseRequest struct {
 	Date        string        `json:"date"`
 	Recurring   bool          `json:"recurring"`
 	Origin      string        `json:"origin"`
+	AccountID   string        `json:"accountId,omitempty"`
 	Receipt     *ReceiptInput `json:"receipt,omitempty"`
 }
 
@@ -55,10 +149,147 @@ type UpdateExpenseRequest struct {
 	Date          *string       `json:"date,omitempty"`
 	Recurring     *bool         `json:"recurring,omitempty"`
 	Origin        *string       `json:"origin,omitempty"`
+	AccountID     *string       `json:"accountId,omitempty"`
+	RemoveAccount bool          `json:"removeAccount,omitempty"`
 	Receipt       *ReceiptInput `json:"receipt,omitempty"`
 	RemoveReceipt bool          `json:"removeReceipt,omitempty"`
 }
 
+type SuggestCategoryRequest struct {
+	Description string   `json:"description"`
+	MerchantID  string   `json:"merchantId,omitempty"`
+	Items       []string `json:"items,omitempty"`
+	UseAI       *bool    `json:"useAi,omitempty"`
+}
+
+type ExpenseAllocationInput struct {
+	CategoryID string   `json:"categoryId"`
+	Amount     *float64 `json:"amount,omitempty"`
+	ItemIDs    []string `json:"itemIds,omitempty"`
+}
+
+type ExpenseAllocationsRequest struct {
+	Allocations []ExpenseAllocationInput `json:"allocations"`
+}
+
+type IncomeRequest struct {
+	CategoryID  string  `json:"categoryId"`
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+	Date        string  `json:"date"`
+	Recurring   bool    `json:"recurring"`
+	AccountID   string  `json:"accountId,omitempty"`
+}
+
+type UpdateIncomeRequest struct {
+	CategoryID    *string  `json:"categoryId,omitempty"`
+	Description   *string  `json:"description,omitempty"`
+	Amount        *float64 `json:"amount,omitempty"`
+	Date          *string  `json:"date,omitempty"`
+	Recurring     *bool    `json:"recurring,omitempty"`
+	AccountID     *string  `json:"accountId,omitempty"`
+	RemoveAccount bool     `json:"removeAccount,omitempty"`
+}
+
+type AccountRequest struct {
+	Name           string  `json:"name"`
+	Type           string  `json:"type"`
+	Currency       string  `json:"currency"`
+	OpeningBalance float64 `json:"openingBalance"`
+	ClosingDay     int     `json:"closingDay,omitempty"`
+	DueDay         int     `json:"dueDay,omitempty"`
+}
+
+type BudgetRequest struct {
+	CategoryID string  `json:"categoryId"`
+	Amount     float64 `json:"amount"`
+	Rollover   string  `json:"rollover,omitempty"`
+	StartMonth int     `json:"startMonth,omitempty"`
+	StartYear  int     `json:"startYear,omitempty"`
+}
+
+type UpdateBudgetRequest struct {
+	Amount   *float64 `json:"amount,omitempty"`
+	Rollover *string  `json:"rollover,omitempty"`
+}
+
+type GoalRequest struct {
+	Name         string  `json:"name"`
+	TargetAmount float64 `json:"targetAmount"`
+	Deadline     string  `json:"deadline"`
+	AccountID    string  `json:"accountId,omitempty"`
+}
+
+type UpdateGoalRequest struct {
+	Name          *string  `json:"name,omitempty"`
+	TargetAmount  *float64 `json:"targetAmount,omitempty"`
+	Deadline      *string  `json:"deadline,omitempty"`
+	AccountID     *string  `json:"accountId,omitempty"`
+	RemoveAccount bool     `json:"removeAccount,omitempty"`
+	Archived      *bool    `json:"archived,omitempty"`
+}
+
+type GoalContributionRequest struct {
+	Amount        float64 `json:"amount"`
+	Date          string  `json:"date"`
+	Note          string  `json:"note,omitempty"`
+	FromAccountID string  `json:"fromAccountId,omitempty"`
+}
+
+type UpdateAccountRequest struct {
+	Name           *string  `json:"name,omitempty"`
+	Type           *string  `json:"type,omitempty"`
+	OpeningBalance *float64 `json:"openingBalance,omitempty"`
+	ClosingDay     *int     `json:"closingDay,omitempty"`
+	DueDay         *int     `json:"dueDay,omitempty"`
+	Active         *bool    `json:"active,omitempty"`
+}
+
+type InstallmentPurchaseRequest struct {
+	AccountID    string  `json:"accountId"`
+	CategoryID   string  `json:"categoryId"`
+	Description  string  `json:"description"`
+	TotalAmount  float64 `json:"totalAmount"`
+	Installments int     `json:"installments"`
+	PurchaseDate string  `json:"purchaseDate"`
+}
+
+type TransferRequest struct {
+	FromAccountID string   `json:"fromAccountId"`
+	ToAccountID   string   `json:"toAccountId"`
+	Amount        float64  `json:"amount"`
+	ToAmount      *float64 `json:"toAmount,omitempty"`
+	Date          string   `json:"date"`
+	Description   string   `json:"description"`
+}
+
+type RecurrenceRuleRequest struct {
+	Frequency  string  `json:"frequency"`
+	Interval   int     `json:"interval"`
+	DayOfMonth int     `json:"dayOfMonth"`
+	EndDate    *string `json:"endDate,omitempty"`
+	Count      *int    `json:"count,omitempty"`
+}
+
+type RecurrenceOccurrenceRequest struct {
+	CategoryID  *string  `json:"categoryId,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Amount      *float64 `json:"amount,omitempty"`
+	Date        *string  `json:"date,omitempty"`
+}
+
+// RecurrenceFutureRequest altera a ocorrência e as seguintes. endDate vazio e count 0 removem o limite.
+type RecurrenceFutureRequest struct {
+	CategoryID  *string  `json:"categoryId,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Amount      *float64 `json:"amount,omitempty"`
+	Frequency   *string  `json:"frequency,omitempty"`
+	Interval    *int     `json:"interval,omitempty"`
+	DayOfMonth  *int     `json:"dayOfMonth,omitempty"`
+	EndDate     *string  `json:"endDate,omitempty"`
+	Count       *int     `json:"count,omitempty"`
+}
+
 type ReceiptInput struct {
 	FilePath      string   `json:"filePath"`
 	ExtractedText string   `json:"extractedText"`
@@ -71,6 +302,18 @@ type ReceiptScanRequest struct {
 	AmountHint  *float64 `json:"amountHint,omitempty"`
 	Locale      string   `json:"locale,omitempty"`
 	ReturnRaw   bool     `json:"returnRaw,omitempty"`
+	Async       bool     `json:"async,omitempty"`
+	Mode        string   `json:"mode,omitempty"`
+}
+
+type UpdateReceiptDraftRequest struct {
+	Merchant        *string        `json:"merchant,omitempty"`
+	MerchantCNPJ    *string        `json:"merchantCnpj,omitempty"`
+	MerchantAddress *string        `json:"merchantAddress,omitempty"`
+	Date            *string        `json:"date,omitempty"`
+	Amount          *float64       `json:"amount,omitempty"`
+	CategoryID      *string        `json:"categoryId,omitempty"`
+	Items           *[]ReceiptItem `json:"items,omitempty"`
 }
 
 type GenerateMealPlanRequest struct {
@@ -87,30 +330,150 @@ type ReceiptItem struct {
 	Quantity    float64 `json:"quantity"`
 	UnitPrice   float64 `json:"unitPrice"`
 	Total       float64 `json:"total"`
+	CategoryID  string  `json:"categoryId,omitempty"`
 }
 
 type ExpenseFilter struct {
-	Month      int
-	Year       int
-	CategoryID *uuid.UUID
-	Origin     *schemas.ExpenseOrigin
+	Month       int
+	Year        int
+	From        time.Time
+	To          time.Time
+	CategoryIDs []uuid.UUID
+	AccountID   *uuid.UUID
+	MerchantID  *uuid.UUID
+	Origin      *schemas.ExpenseOrigin
+	Recurring   *bool
+	MinAmount   *float64
+	MaxAmount   *float64
+	Query       string
+	Sort        string
+	Descending  bool
+	Limit       int
+	Cursor      *expenseCursor
 }
 
 type AuthResponse struct {
-	Token     string       `json:"token"`
-	ExpiresAt time.Time    `json:"expiresAt"`
-	User      UserResponse `json:"user"`
+	Token            string       `json:"token"`
+	ExpiresAt        time.Time    `json:"expiresAt"`
+	RefreshToken     string       `json:"refreshToken"`
+	RefreshExpiresAt time.Time    `json:"refreshExpiresAt"`
+	User             UserResponse `json:"user"`
+}
+
+type MFAChallengeResponse struct {
+	Status    string    `json:"status"`
+	MFAToken  string    `json:"mfaToken"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type MFAEnrollResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauthUri"`
+}
+
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type AccountDeletionResponse struct {
+	DeletionDueAt time.Time `json:"deletionDueAt"`
+}
+
+type IdentityResponse struct {
+	ID          string     `json:"id"`
+	Provider    string     `json:"provider"`
+	Email       string     `json:"email"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	LastLoginAt *time.Time `json:"lastLoginAt,omitempty"`
 }
 
 type UserResponse struct {
-	ID        string              `json:"id"`
-	Name      string              `json:"name"`
-	Email     string              `json:"email"`
-	Active    bool                `json:"active"`
-	LastLogin *time.Time          `json:"lastLogin,omitempty"`
-	CreatedAt time.Time           `json:"createdAt"`
-	UpdatedAt time.Time           `json:"updatedAt"`
-	Config    *UserConfigResponse `json:"config,omitempty"`
+	ID              string              `json:"id"`
+	Name            string              `json:"name"`
+	Email           string              `json:"email"`
+	Active          bool                `json:"active"`
+	Role            string              `json:"role"`
+	EmailVerified   bool                `json:"emailVerified"`
+	EmailVerifiedAt *time.Time          `json:"emailVerifiedAt,omitempty"`
+	PendingEmail    string              `json:"pendingEmail,omitempty"`
+	MFAEnabled      bool                `json:"mfaEnabled"`
+	DeletionDueAt   *time.Time          `json:"deletionDueAt,omitempty"`
+	LastLogin       *time.Time          `json:"lastLogin,omitempty"`
+	CreatedAt       time.Time           `json:"createdAt"`
+	UpdatedAt       time.Time           `json:"updatedAt"`
+	Config          *UserConfigResponse `json:"config,omitempty"`
+}
+
+type HouseholdMemberResponse struct {
+	UserID   string    `json:"userId"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joinedAt"`
+}
+
+type HouseholdResponse struct {
+	ID        string                    `json:"id"`
+	Name      string                    `json:"name"`
+	Role      string                    `json:"role"`
+	CreatedAt time.Time                 `json:"createdAt"`
+	Members   []HouseholdMemberResponse `json:"members,omitempty"`
+}
+
+type HouseholdInvitationResponse struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type APIKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+type CreatedAPIKeyResponse struct {
+	Key    string         `json:"key"`
+	APIKey APIKeyResponse `json:"apiKey"`
+}
+
+type AdminUserListResponse struct {
+	Users      []UserResponse       `json:"users"`
+	Pagination TokenUsagePagination `json:"pagination"`
+}
+
+type AdminUserDetailResponse struct {
+	User           UserResponse       `json:"user"`
+	LockedUntil    *time.Time         `json:"lockedUntil,omitempty"`
+	ActiveSessions int64              `json:"activeSessions"`
+	Identities     []IdentityResponse `json:"identities"`
+	TokenUsage     TokenUsageSummary  `json:"tokenUsage"`
+}
+
+type AdminTokenUsageByType struct {
+	RequestType string `json:"requestType"`
+	TokenUsageSummary
+}
+
+type AdminTokenUsageByUser struct {
+	UserID string `json:"userId"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	TokenUsageSummary
+}
+
+type AdminTokenUsageResponse struct {
+	From          *time.Time              `json:"from,omitempty"`
+	To            *time.Time              `json:"to,omitempty"`
+	Summary       TokenUsageSummary       `json:"summary"`
+	ByRequestType []AdminTokenUsageByType `json:"byRequestType"`
+	TopUsers      []AdminTokenUsageByUser `json:"topUsers"`
 }
 
 type UserConfigResponse struct {
@@ -121,37 +484,332 @@ type UserConfigResponse struct {
 	Theme                string  `json:"theme"`
 }
 
+type SessionResponse struct {
+	ID         string     `json:"id"`
+	DeviceName string     `json:"deviceName"`
+	UserAgent  string     `json:"userAgent"`
+	IPAddress  string     `json:"ipAddress"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastSeenAt *time.Time `json:"lastSeenAt,omitempty"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	Current    bool       `json:"current"`
+}
+
 type CategoryResponse struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	Icon      string    `json:"icon"`
-	ColorHex  string    `json:"colorHex"`
-	Type      string    `json:"type"`
-	Order     int       `json:"order"`
-	Active    bool      `json:"active"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID          string    `json:"id"`
+	HouseholdID *string   `json:"householdId,omitempty"`
+	Name        string    `json:"name"`
+	Icon        string    `json:"icon"`
+	ColorHex    string    `json:"colorHex"`
+	Type        string    `json:"type"`
+	Kind        string    `json:"kind"`
+	Order       int       `json:"order"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
 }
 
 type ReceiptResponse struct {
 	ID            string  `json:"id"`
 	FilePath      string  `json:"filePath"`
+	HasImage      bool    `json:"hasImage"`
 	ExtractedText string  `json:"extractedText"`
 	OcrConfidence float64 `json:"ocrConfidence"`
 }
 
 type ExpenseResponse struct {
+	ID                    string                      `json:"id"`
+	HouseholdID           *string                     `json:"householdId,omitempty"`
+	CreatedBy             string                      `json:"createdBy"`
+	CategoryID            string                      `json:"categoryId"`
+	Description           string                      `json:"description"`
+	Amount                float64                     `json:"amount"`
+	Date                  time.Time                   `json:"date"`
+	Recurring             bool                        `json:"recurring"`
+	Origin                string                      `json:"origin"`
+	AccountID             *string                     `json:"accountId,omitempty"`
+	MerchantID            *string                     `json:"merchantId,omitempty"`
+	CreatedAt             time.Time                   `json:"createdAt"`
+	UpdatedAt             time.Time                   `json:"updatedAt"`
+	Category              *CategoryResponse           `json:"category,omitempty"`
+	Receipt               *ReceiptResponse            `json:"receipt,omitempty"`
+	RecurrenceRuleID      *string                     `json:"recurrenceRuleId,omitempty"`
+	RecurrenceIndex       *int                        `json:"recurrenceIndex,omitempty"`
+	InstallmentPurchaseID *string                     `json:"installmentPurchaseId,omitempty"`
+	Installment           string                      `json:"installment,omitempty"`
+	Items                 []ExpenseItemResponse       `json:"items,omitempty"`
+	Allocations           []ExpenseAllocationResponse `json:"allocations,omitempty"`
+}
+
+type ExpenseItemResponse struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Quantity   float64 `json:"quantity"`
+	UnitPrice  float64 `json:"unitPrice"`
+	TotalPrice float64 `json:"totalPrice"`
+	CategoryID *string `json:"categoryId,omitempty"`
+}
+
+type CategorySuggestionResponse struct {
+	CategoryID *string                          `json:"categoryId,omitempty"`
+	Category   *CategoryResponse                `json:"category,omitempty"`
+	Source     string                           `json:"source,omitempty"`
+	Items      []ItemCategorySuggestionResponse `json:"items"`
+}
+
+type ItemCategorySuggestionResponse struct {
+	Name       string  `json:"name"`
+	CategoryID *string `json:"categoryId,omitempty"`
+	Source     string  `json:"source,omitempty"`
+}
+
+type ExpenseAllocationResponse struct {
+	CategoryID string   `json:"categoryId"`
+	Amount     float64  `json:"amount"`
+	Primary    bool     `json:"primary"`
+	ItemIDs    []string `json:"itemIds,omitempty"`
+}
+
+type IncomeResponse struct {
+	ID               string            `json:"id"`
+	HouseholdID      *string           `json:"householdId,omitempty"`
+	CreatedBy        string            `json:"createdBy"`
+	CategoryID       string            `json:"categoryId"`
+	Description      string            `json:"description"`
+	Amount           float64           `json:"amount"`
+	Date             time.Time         `json:"date"`
+	Recurring        bool              `json:"recurring"`
+	AccountID        *string           `json:"accountId,omitempty"`
+	CreatedAt        time.Time         `json:"createdAt"`
+	UpdatedAt        time.Time         `json:"updatedAt"`
+	Category         *CategoryResponse `json:"category,omitempty"`
+	RecurrenceRuleID *string           `json:"recurrenceRuleId,omitempty"`
+	RecurrenceIndex  *int              `json:"recurrenceIndex,omitempty"`
+}
+
+type IncomeSummary struct {
+	TotalCount  int     `json:"totalCount"`
+	TotalAmount float64 `json:"totalAmount"`
+}
+
+type IncomesListResponse struct {
+	Incomes []IncomeResponse `json:"incomes"`
+	Summary IncomeSummary    `json:"summary"`
+}
+
+type AccountResponse struct {
+	ID             string    `json:"id"`
+	HouseholdID    *string   `json:"householdId,omitempty"`
+	CreatedBy      string    `json:"createdBy"`
+	Name           string    `json:"name"`
+	Type           string    `json:"type"`
+	Currency       string    `json:"currency"`
+	OpeningBalance float64   `json:"openingBalance"`
+	Balance        float64   `json:"balance"`
+	ClosingDay     int       `json:"closingDay,omitempty"`
+	DueDay         int       `json:"dueDay,omitempty"`
+	Active         bool      `json:"active"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type BudgetResponse struct {
 	ID          string            `json:"id"`
+	HouseholdID *string           `json:"householdId,omitempty"`
+	CreatedBy   string            `json:"createdBy"`
 	CategoryID  string            `json:"categoryId"`
-	Description string            `json:"description"`
+	Category    *CategoryResponse `json:"category,omitempty"`
 	Amount      float64           `json:"amount"`
-	Date        time.Time         `json:"date"`
-	Recurring   bool              `json:"recurring"`
-	Origin      string            `json:"origin"`
+	Rollover    string            `json:"rollover"`
+	StartMonth  int               `json:"startMonth"`
+	StartYear   int               `json:"startYear"`
 	CreatedAt   time.Time         `json:"createdAt"`
 	UpdatedAt   time.Time         `json:"updatedAt"`
-	Category    *CategoryResponse `json:"category,omitempty"`
-	Receipt     *ReceiptResponse  `json:"receipt,omitempty"`
+}
+
+type BudgetStatus struct {
+	BudgetID          string            `json:"budgetId"`
+	CategoryID        string            `json:"categoryId"`
+	Category          *CategoryResponse `json:"category,omitempty"`
+	Amount            float64           `json:"amount"`
+	RolloverAmount    float64           `json:"rolloverAmount"`
+	Limit             float64           `json:"limit"`
+	Spent             float64           `json:"spent"`
+	Remaining         float64           `json:"remaining"`
+	PercentUsed       float64           `json:"percentUsed"`
+	Projected         float64           `json:"projected"`
+	ProjectedOver     bool              `json:"projectedOver"`
+	ThresholdsReached []int             `json:"thresholdsReached"`
+}
+
+type BudgetStatusResponse struct {
+	Month          int            `json:"month"`
+	Year           int            `json:"year"`
+	TotalLimit     float64        `json:"totalLimit"`
+	TotalSpent     float64        `json:"totalSpent"`
+	TotalProjected float64        `json:"totalProjected"`
+	Budgets        []BudgetStatus `json:"budgets"`
+}
+
+type BudgetAlertResponse struct {
+	ID         string    `json:"id"`
+	BudgetID   string    `json:"budgetId"`
+	CategoryID string    `json:"categoryId,omitempty"`
+	Month      int       `json:"month"`
+	Year       int       `json:"year"`
+	Threshold  int       `json:"threshold"`
+	Spent      float64   `json:"spent"`
+	Limit      float64   `json:"limit"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type GoalProgress struct {
+	Saved               float64    `json:"saved"`
+	Remaining           float64    `json:"remaining"`
+	PercentComplete     float64    `json:"percentComplete"`
+	MonthsLeft          int        `json:"monthsLeft"`
+	RequiredMonthly     float64    `json:"requiredMonthly"`
+	ExpectedSaved       float64    `json:"expectedSaved"`
+	AverageMonthly      float64    `json:"averageMonthly"`
+	ProjectedCompletion *time.Time `json:"projectedCompletion,omitempty"`
+	Status              string     `json:"status"`
+}
+
+type GoalContributionResponse struct {
+	ID         string    `json:"id"`
+	Amount     float64   `json:"amount"`
+	Date       time.Time `json:"date"`
+	Note       string    `json:"note,omitempty"`
+	TransferID *string   `json:"transferId,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type GoalResponse struct {
+	ID            string                     `json:"id"`
+	HouseholdID   *string                    `json:"householdId,omitempty"`
+	CreatedBy     string                     `json:"createdBy"`
+	Name          string                     `json:"name"`
+	TargetAmount  float64                    `json:"targetAmount"`
+	Deadline      time.Time                  `json:"deadline"`
+	AccountID     *string                    `json:"accountId,omitempty"`
+	Archived      bool                       `json:"archived"`
+	CreatedAt     time.Time                  `json:"createdAt"`
+	UpdatedAt     time.Time                  `json:"updatedAt"`
+	Progress      GoalProgress               `json:"progress"`
+	Contributions []GoalContributionResponse `json:"contributions,omitempty"`
+}
+
+type GoalProgressSummary struct {
+	TotalTarget          float64        `json:"totalTarget"`
+	TotalSaved           float64        `json:"totalSaved"`
+	TotalRequiredMonthly float64        `json:"totalRequiredMonthly"`
+	OnTrack              int            `json:"onTrack"`
+	Behind               int            `json:"behind"`
+	Goals                []GoalResponse `json:"goals"`
+}
+
+type TransferResponse struct {
+	ID            string    `json:"id"`
+	HouseholdID   *string   `json:"householdId,omitempty"`
+	CreatedBy     string    `json:"createdBy"`
+	FromAccountID string    `json:"fromAccountId"`
+	ToAccountID   string    `json:"toAccountId"`
+	Amount        float64   `json:"amount"`
+	ToAmount      float64   `json:"toAmount"`
+	Date          time.Time `json:"date"`
+	Description   string    `json:"description"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type InstallmentResponse struct {
+	Number         int       `json:"number"`
+	Label          string    `json:"label"`
+	Date           time.Time `json:"date"`
+	Amount         float64   `json:"amount"`
+	StatementYear  int       `json:"statementYear"`
+	StatementMonth int       `json:"statementMonth"`
+	ExpenseID      *string   `json:"expenseId,omitempty"`
+}
+
+type InstallmentPurchaseResponse struct {
+	ID           string                `json:"id"`
+	HouseholdID  *string               `json:"householdId,omitempty"`
+	CreatedBy    string                `json:"createdBy"`
+	AccountID    string                `json:"accountId"`
+	CategoryID   string                `json:"categoryId"`
+	Description  string                `json:"description"`
+	TotalAmount  float64               `json:"totalAmount"`
+	Installments int                   `json:"installments"`
+	PurchaseDate time.Time             `json:"purchaseDate"`
+	CreatedAt    time.Time             `json:"createdAt"`
+	Items        []InstallmentResponse `json:"items"`
+}
+
+type StatementItem struct {
+	ExpenseID             string    `json:"expenseId"`
+	Date                  time.Time `json:"date"`
+	Description           string    `json:"description"`
+	CategoryID            string    `json:"categoryId"`
+	Amount                float64   `json:"amount"`
+	InstallmentPurchaseID *string   `json:"installmentPurchaseId,omitempty"`
+	Installment           string    `json:"installment,omitempty"`
+}
+
+type StatementResponse struct {
+	AccountID   string          `json:"accountId"`
+	Year        int             `json:"year"`
+	Month       int             `json:"month"`
+	PeriodStart time.Time       `json:"periodStart"`
+	ClosingDate time.Time       `json:"closingDate"`
+	DueDate     time.Time       `json:"dueDate"`
+	Total       float64         `json:"total"`
+	Items       []StatementItem `json:"items"`
+}
+
+type AccountBalanceEntry struct {
+	Date        time.Time `json:"date"`
+	Type        string    `json:"type"`
+	ReferenceID string    `json:"referenceId"`
+	Description string    `json:"description"`
+	Amount      float64   `json:"amount"`
+	Balance     float64   `json:"balance"`
+}
+
+type AccountBalanceHistory struct {
+	Account      AccountResponse       `json:"account"`
+	StartBalance float64               `json:"startBalance"`
+	EndBalance   float64               `json:"endBalance"`
+	Entries      []AccountBalanceEntry `json:"entries"`
+}
+
+type RecurrenceRuleResponse struct {
+	ID               string     `json:"id"`
+	HouseholdID      *string    `json:"householdId,omitempty"`
+	CreatedBy        string     `json:"createdBy"`
+	Kind             string     `json:"kind"`
+	TemplateID       string     `json:"templateId"`
+	CategoryID       string     `json:"categoryId"`
+	Description      string     `json:"description"`
+	Amount           float64    `json:"amount"`
+	AccountID        *string    `json:"accountId,omitempty"`
+	Frequency        string     `json:"frequency"`
+	Interval         int        `json:"interval"`
+	DayOfMonth       int        `json:"dayOfMonth,omitempty"`
+	StartDate        time.Time  `json:"startDate"`
+	EndDate          *time.Time `json:"endDate,omitempty"`
+	Count            *int       `json:"count,omitempty"`
+	NextOccurrenceAt *time.Time `json:"nextOccurrenceAt,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+type RecurrenceOccurrenceResponse struct {
+	Index       int       `json:"index"`
+	Date        time.Time `json:"date"`
+	CategoryID  string    `json:"categoryId"`
+	Description string    `json:"description"`
+	Amount      float64   `json:"amount"`
+	Skipped     bool      `json:"skipped"`
+	EntryID     *string   `json:"entryId,omitempty"`
 }
 
 type ExpenseSummary struct {
@@ -161,8 +819,15 @@ type ExpenseSummary struct {
 }
 
 type ExpensesListResponse struct {
-	Expenses []ExpenseResponse `json:"expenses"`
-	Summary  ExpenseSummary    `json:"summary"`
+	Expenses   []ExpenseResponse `json:"expenses"`
+	Summary    ExpenseSummary    `json:"summary"`
+	Pagination ExpensePagination `json:"pagination"`
+}
+
+type ExpensePagination struct {
+	Limit      int    `json:"limit"`
+	HasMore    bool   `json:"hasMore"`
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 type CategoryAggregate struct {
@@ -177,20 +842,91 @@ type DashboardSummaryResponse struct {
 	PreviousTotal float64             `json:"previousTotal"`
 	VariationPct  float64             `json:"variationPct"`
 	TopCategories []CategoryAggregate `json:"topCategories"`
+	TotalIncome   float64             `json:"totalIncome"`
+	Net           float64             `json:"net"`
+	SavingsRate   float64             `json:"savingsRate"`
+}
+
+type CashFlowMonth struct {
+	Month       int     `json:"month"`
+	Year        int     `json:"year"`
+	Income      float64 `json:"income"`
+	Expenses    float64 `json:"expenses"`
+	Net         float64 `json:"net"`
+	SavingsRate float64 `json:"savingsRate"`
+}
+
+type CashFlowResponse struct {
+	Months        []CashFlowMonth `json:"months"`
+	TotalIncome   float64         `json:"totalIncome"`
+	TotalExpenses float64         `json:"totalExpenses"`
+	Net           float64         `json:"net"`
+	SavingsRate   float64         `json:"savingsRate"`
 }
 
 type ReceiptScanResponse struct {
-	SuggestedAmount float64          `json:"suggestedAmount"`
-	SuggestedDate   string           `json:"suggestedDate"`
-	Currency        string           `json:"currency"`
-	ExtractedText   string           `json:"extractedText"`
-	Items           []ReceiptItem    `json:"items"`
-	Confidence      float64          `json:"confidence"`
-	TokensUsed      int64            `json:"tokensUsed"`
-	TokenCostCents  int64            `json:"tokenCostCents"`
-	Model           string           `json:"model"`
-	RawModelOutput  string           `json:"rawModelOutput,omitempty"`
-	SavedExpense    *ExpenseResponse `json:"savedExpense,omitempty"`
+	SuggestedAmount float64               `json:"suggestedAmount"`
+	SuggestedDate   string                `json:"suggestedDate"`
+	Currency        string                `json:"currency"`
+	ExtractedText   string                `json:"extractedText"`
+	Items           []ReceiptItem         `json:"items"`
+	Confidence      float64               `json:"confidence"`
+	TokensUsed      int64                 `json:"tokensUsed"`
+	TokenCostCents  int64                 `json:"tokenCostCents"`
+	Model           string                `json:"model"`
+	RawModelOutput  string                `json:"rawModelOutput,omitempty"`
+	Merchant        string                `json:"merchant,omitempty"`
+	MerchantCNPJ    string                `json:"merchantCnpj,omitempty"`
+	MerchantAddress string                `json:"merchantAddress,omitempty"`
+	CategoryID      *string               `json:"categoryId,omitempty"`
+	CategorySource  string                `json:"categorySource,omitempty"`
+	ReviewRequired  bool                  `json:"reviewRequired"`
+	SavedExpense    *ExpenseResponse      `json:"savedExpense,omitempty"`
+	Draft           *ReceiptDraftResponse `json:"draft,omitempty"`
+}
+
+type ReceiptDraftResponse struct {
+	ID              string            `json:"id"`
+	HouseholdID     *string           `json:"householdId,omitempty"`
+	CreatedBy       string            `json:"createdBy"`
+	Merchant        string            `json:"merchant"`
+	MerchantCNPJ    string            `json:"merchantCnpj,omitempty"`
+	MerchantAddress string            `json:"merchantAddress,omitempty"`
+	Date            string            `json:"date"`
+	Amount          float64           `json:"amount"`
+	Currency        string            `json:"currency"`
+	CategoryID      *string           `json:"categoryId,omitempty"`
+	Category        *CategoryResponse `json:"category,omitempty"`
+	Items           []ReceiptItem     `json:"items"`
+	ExtractedText   string            `json:"extractedText"`
+	OcrConfidence   float64           `json:"ocrConfidence"`
+	HasImage        bool              `json:"hasImage"`
+	CreatedAt       time.Time         `json:"createdAt"`
+}
+
+type MerchantResponse struct {
+	ID           string     `json:"id"`
+	HouseholdID  *string    `json:"householdId,omitempty"`
+	Name         string     `json:"name"`
+	CNPJ         string     `json:"cnpj,omitempty"`
+	Address      string     `json:"address,omitempty"`
+	Total        float64    `json:"total"`
+	ExpenseCount int        `json:"expenseCount"`
+	LastPurchase *time.Time `json:"lastPurchase,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+type ReceiptJobResponse struct {
+	ID         string               `json:"id"`
+	Status     string               `json:"status"`
+	Attempts   int                  `json:"attempts"`
+	Error      string               `json:"error,omitempty"`
+	ExpenseID  *string              `json:"expenseId,omitempty"`
+	DraftID    *string              `json:"draftId,omitempty"`
+	Result     *ReceiptScanResponse `json:"result,omitempty"`
+	CreatedAt  time.Time            `json:"createdAt"`
+	StartedAt  *time.Time           `json:"startedAt,omitempty"`
+	FinishedAt *time.Time           `json:"finishedAt,omitempty"`
 }
 
 type TokenUsageEntryResponse struct {
@@ -268,24 +1004,7 @@ type SyncRequest struct {
 func (r *RegisterRequest) Normalize() {
 	r.Email = strings.TrimSpace(strings.ToLower(r.Email))
 	r.Name = strings.TrimSpace(r.Name)
-
-	currency := strings.ToUpper(strings.TrimSpace(r.Currency))
-	if currency == "" {
-		currency = "BRL"
-	}
-	r.Currency = currency
-
-	language := strings.TrimSpace(r.Language)
-	if language == "" {
-		language = "pt-BR"
-	}
-	r.Language = language
-
-	theme := strings.TrimSpace(r.Theme)
-	if theme == "" {
-		theme = string(schemas.ThemeSystem)
-	}
-	r.Theme = theme
+	r.Currency, r.Language, r.Theme = normalizeConfigFields(r.Currency, r.Language, r.Theme)
 }
 
 func (r *RegisterRequest) Validate() error {
@@ -298,24 +1017,93 @@ func (r *RegisterRequest) Validate() error {
 	if !strings.Contains(r.Email, "@") {
 		return errors.New("email inválido")
 	}
-	if len(r.Password) < 6 {
-		return errors.New("senha deve ter pelo menos 6 caracteres")
+	if err := validatePassword(r.Password); err != nil {
+		return err
+	}
+	return validateConfigFields(r.Currency, r.Language, r.Theme, r.MonthlyLimit)
+}
+
+func (r *UpdateProfileRequest) Normalize() {
+	if r.Name != nil {
+		name := strings.TrimSpace(*r.Name)
+		r.Name = &name
+	}
+	if r.Email != nil {
+		email := strings.TrimSpace(strings.ToLower(*r.Email))
+		r.Email = &email
+	}
+}
+
+func (r *UpdateProfileRequest) Validate() error {
+	if r.Name == nil && r.Email == nil {
+		return errors.New("informe ao menos um campo para atualizar")
+	}
+	if r.Name != nil && *r.Name == "" {
+		return errors.New("nome é obrigatório")
+	}
+	if r.Email != nil && (*r.Email == "" || !strings.Contains(*r.Email, "@")) {
+		return errors.New("email inválido")
 	}
+	return nil
+}
+
+func (r *UpdateConfigRequest) Normalize() {
+	r.Currency, r.Language, r.Theme = normalizeConfigFields(r.Currency, r.Language, r.Theme)
+}
+
+func (r *UpdateConfigRequest) Validate() error {
+	return validateConfigFields(r.Currency, r.Language, r.Theme, r.MonthlyLimit)
+}
+
+func (r *ChangePasswordRequest) Validate() error {
+	return validatePassword(r.NewPassword)
+}
 
-	if len(r.Currency) != 3 {
+func validateCurrency(currency string) error {
+	if len(currency) != 3 {
 		return errors.New("currency deve conter exatamente 3 letras (ex: BRL)")
 	}
-	for _, ch := range r.Currency {
+	for _, ch := range currency {
 		if ch < 'A' || ch > 'Z' {
 			return errors.New("currency deve conter apenas letras A-Z")
 		}
 	}
+	return nil
+}
+
+// normalizeConfigFields aplica os padrões de moeda, idioma e tema usados no cadastro.
+func normalizeConfigFields(currency, language, theme string) (string, string, string) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		currency = "BRL"
+	}
+
+	language = strings.TrimSpace(language)
+	if language == "" {
+		language = "pt-BR"
+	}
+
+	theme = strings.TrimSpace(theme)
+	if theme == "" {
+		theme = string(schemas.ThemeSystem)
+	}
+	return currency, language, theme
+}
+
+func validateConfigFields(currency, language, theme string, monthlyLimit *float64) error {
+	if err := validateCurrency(currency); err != nil {
+		return err
+	}
+
+	if monthlyLimit != nil && *monthlyLimit < 0 {
+		return errors.New("monthlyLimit não pode ser negativo")
+	}
 
-	if len(r.Language) > 5 {
+	if len(language) > 5 {
 		return errors.New("language deve ter no máximo 5 caracteres (ex: pt-BR)")
 	}
 
-	switch schemas.Theme(r.Theme) {
+	switch schemas.Theme(theme) {
 	case schemas.ThemeLight, schemas.ThemeDark, schemas.ThemeSystem:
 	default:
 		return errors.New("theme inválido")
@@ -335,27 +1123,216 @@ func (r *LoginRequest) Validate() error {
 	return nil
 }
 
-func (r *CategoryRequest) Validate() error {
-	if strings.TrimSpace(r.Name) == "" {
-		return errors.New("nome é obrigatório")
-	}
-	if strings.TrimSpace(r.Type) == "" {
-		return errors.New("tipo é obrigatório")
+func (r *ForgotPasswordRequest) Normalize() {
+	r.Email = strings.TrimSpace(strings.ToLower(r.Email))
+}
+
+func (r *ForgotPasswordRequest) Validate() error {
+	if r.Email == "" || !strings.Contains(r.Email, "@") {
+		return errors.New("email inválido")
 	}
-	switch schemas.CategoryType(r.Type) {
-	case schemas.CategoryTypeFixed, schemas.CategoryTypeVariable:
-	default:
-		return errors.New("tipo inválido")
+	return nil
+}
+
+func (r *ResetPasswordRequest) Validate() error {
+	r.Token = strings.TrimSpace(r.Token)
+	if r.Token == "" {
+		return errors.New("token é obrigatório")
 	}
-	if r.ColorHex != "" && !strings.HasPrefix(r.ColorHex, "#") {
-		return errors.New("cor deve estar em formato hexadecimal (#RRGGBB)")
+	return validatePassword(r.Password)
+}
+
+func (r *VerifyEmailRequest) Validate() error {
+	r.Token = strings.TrimSpace(r.Token)
+	if r.Token == "" {
+		return errors.New("token é obrigatório")
 	}
 	return nil
 }
 
-func (r *ExpenseRequest) Validate() error {
-	if r.CategoryID == "" {
-		return errors.New("categoryId é obrigatório")
+func (r *OIDCLoginRequest) Validate() error {
+	r.IDToken = strings.TrimSpace(r.IDToken)
+	if r.IDToken == "" {
+		return errors.New("idToken é obrigatório")
+	}
+	return nil
+}
+
+func (r *HouseholdRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return errors.New("nome é obrigatório")
+	}
+	if len(r.Name) > 80 {
+		return errors.New("nome deve ter no máximo 80 caracteres")
+	}
+	return nil
+}
+
+func (r *HouseholdInvitationRequest) Validate() error {
+	r.Email = strings.TrimSpace(strings.ToLower(r.Email))
+	if r.Email == "" {
+		return errors.New("email é obrigatório")
+	}
+	if !strings.Contains(r.Email, "@") {
+		return errors.New("email inválido")
+	}
+	r.Role = strings.ToLower(strings.TrimSpace(r.Role))
+	if r.Role == "" {
+		r.Role = string(schemas.HouseholdRoleEditor)
+	}
+	switch schemas.HouseholdRole(r.Role) {
+	case schemas.HouseholdRoleEditor, schemas.HouseholdRoleViewer:
+		return nil
+	default:
+		return errors.New("papel inválido (use editor ou viewer)")
+	}
+}
+
+func (r *AcceptHouseholdInvitationRequest) Validate() error {
+	r.Token = strings.TrimSpace(r.Token)
+	if r.Token == "" {
+		return errors.New("token é obrigatório")
+	}
+	return nil
+}
+
+func (r *UpdateHouseholdMemberRequest) Validate() error {
+	r.Role = strings.ToLower(strings.TrimSpace(r.Role))
+	switch schemas.HouseholdRole(r.Role) {
+	case schemas.HouseholdRoleOwner, schemas.HouseholdRoleEditor, schemas.HouseholdRoleViewer:
+		return nil
+	default:
+		return errors.New("papel inválido")
+	}
+}
+
+func (r *CreateAPIKeyRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return errors.New("nome é obrigatório")
+	}
+	if len(r.Name) > 80 {
+		return errors.New("nome deve ter no máximo 80 caracteres")
+	}
+
+	if len(r.Scopes) == 0 {
+		return errors.New("informe ao menos um escopo")
+	}
+	seen := map[string]bool{}
+	scopes := make([]string, 0, len(r.Scopes))
+	for _, scope := range r.Scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !isValidAPIKeyScope(scope) {
+			return errors.New("escopo inválido: " + scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	r.Scopes = scopes
+
+	if r.ExpiresAt != nil && !r.ExpiresAt.After(time.Now()) {
+		return errors.New("expiresAt deve estar no futuro")
+	}
+	return nil
+}
+
+func isValidAPIKeyScope(scope string) bool {
+	for _, valid := range schemas.APIKeyScopes {
+		if string(valid) == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *AccountDeletionRequest) Validate() error {
+	r.Code = strings.TrimSpace(r.Code)
+	return nil
+}
+
+func (r *UpdateUserRoleRequest) Validate() error {
+	r.Role = strings.ToLower(strings.TrimSpace(r.Role))
+	switch schemas.UserRole(r.Role) {
+	case schemas.UserRoleUser, schemas.UserRoleSupport, schemas.UserRoleAdmin:
+		return nil
+	default:
+		return errors.New("papel inválido")
+	}
+}
+
+func (r *MFACodeRequest) Validate() error {
+	r.Code = strings.TrimSpace(r.Code)
+	if r.Code == "" {
+		return errors.New("código é obrigatório")
+	}
+	return nil
+}
+
+func (r *MFADisableRequest) Validate() error {
+	r.Code = strings.TrimSpace(r.Code)
+	if r.Code == "" {
+		return errors.New("código é obrigatório")
+	}
+	return nil
+}
+
+func (r *MFAVerifyRequest) Validate() error {
+	r.MFAToken = strings.TrimSpace(r.MFAToken)
+	r.Code = strings.TrimSpace(r.Code)
+	if r.MFAToken == "" || r.Code == "" {
+		return errors.New("mfaToken e código são obrigatórios")
+	}
+	return nil
+}
+
+func validatePassword(password string) error {
+	if len(password) < 6 {
+		return errors.New("senha deve ter pelo menos 6 caracteres")
+	}
+	return nil
+}
+
+func (r *RefreshTokenRequest) Validate() error {
+	r.RefreshToken = strings.TrimSpace(r.RefreshToken)
+	if r.RefreshToken == "" {
+		return errors.New("refreshToken é obrigatório")
+	}
+	return nil
+}
+
+func (r *CategoryRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("nome é obrigatório")
+	}
+	if strings.TrimSpace(r.Type) == "" {
+		return errors.New("tipo é obrigatório")
+	}
+	switch schemas.CategoryType(r.Type) {
+	case schemas.CategoryTypeFixed, schemas.CategoryTypeVariable:
+	default:
+		return errors.New("tipo inválido")
+	}
+	r.Kind = strings.ToLower(strings.TrimSpace(r.Kind))
+	if r.Kind == "" {
+		r.Kind = string(schemas.CategoryKindExpense)
+	}
+	switch schemas.CategoryKind(r.Kind) {
+	case schemas.CategoryKindExpense, schemas.CategoryKindIncome:
+	default:
+		return errors.New("natureza inválida (use despesa ou receita)")
+	}
+	if r.ColorHex != "" && !strings.HasPrefix(r.ColorHex, "#") {
+		return errors.New("cor deve estar em formato hexadecimal (#RRGGBB)")
+	}
+	return nil
+}
+
+func (r *ExpenseRequest) Validate() error {
+	if r.CategoryID == "" {
+		return errors.New("categoryId é obrigatório")
 	}
 	if r.Description == "" {
 		return errors.New("descrição é obrigatória")
@@ -378,7 +1355,7 @@ func (r *ExpenseRequest) Validate() error {
 }
 
 func (r *UpdateExpenseRequest) Validate() error {
-	if r.CategoryID == nil && r.Description == nil && r.Amount == nil && r.Date == nil && r.Recurring == nil && r.Origin == nil && r.Receipt == nil && !r.RemoveReceipt {
+	if r.CategoryID == nil && r.Description == nil && r.Amount == nil && r.Date == nil && r.Recurring == nil && r.Origin == nil && r.AccountID == nil && !r.RemoveAccount && r.Receipt == nil && !r.RemoveReceipt {
 		return errors.New("nenhum campo para atualizar")
 	}
 	if r.CategoryID != nil && *r.CategoryID == "" {
@@ -397,6 +1374,374 @@ func (r *UpdateExpenseRequest) Validate() error {
 	return nil
 }
 
+func (r *ExpenseAllocationsRequest) Validate() error {
+	if len(r.Allocations) > maxExpenseAllocations {
+		return fmt.Errorf("no máximo %d divisões por despesa", maxExpenseAllocations)
+	}
+	for _, allocation := range r.Allocations {
+		if allocation.CategoryID == "" {
+			return errors.New("categoryId é obrigatório em cada divisão")
+		}
+		if (allocation.Amount == nil) == (len(allocation.ItemIDs) == 0) {
+			return errors.New("cada divisão deve informar amount ou itemIds")
+		}
+		if allocation.Amount != nil && *allocation.Amount <= 0 {
+			return errors.New("valor da divisão deve ser maior que zero")
+		}
+	}
+	return nil
+}
+
+func (r *IncomeRequest) Validate() error {
+	if r.CategoryID == "" {
+		return errors.New("categoryId é obrigatório")
+	}
+	if r.Description == "" {
+		return errors.New("descrição é obrigatória")
+	}
+	if r.Amount <= 0 {
+		return errors.New("valor deve ser maior que zero")
+	}
+	if r.Date == "" {
+		return errors.New("data é obrigatória")
+	}
+	return nil
+}
+
+func (r *UpdateIncomeRequest) Validate() error {
+	if r.CategoryID == nil && r.Description == nil && r.Amount == nil && r.Date == nil && r.Recurring == nil && r.AccountID == nil && !r.RemoveAccount {
+		return errors.New("nenhum campo para atualizar")
+	}
+	if r.CategoryID != nil && *r.CategoryID == "" {
+		return errors.New("categoryId inválido")
+	}
+	if r.Description != nil && *r.Description == "" {
+		return errors.New("descrição é obrigatória")
+	}
+	if r.Amount != nil && *r.Amount <= 0 {
+		return errors.New("valor deve ser maior que zero")
+	}
+	return nil
+}
+
+func (r *AccountRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return errors.New("nome é obrigatório")
+	}
+	if err := validateAccountType(r.Type); err != nil {
+		return err
+	}
+	if schemas.AccountType(r.Type) == schemas.AccountTypeCreditCard {
+		if r.ClosingDay == 0 || r.DueDay == 0 {
+			return errors.New("closingDay e dueDay são obrigatórios para cartões de crédito")
+		}
+		if err := validateBillingDays(r.ClosingDay, r.DueDay); err != nil {
+			return err
+		}
+	} else if r.ClosingDay != 0 || r.DueDay != 0 {
+		return errors.New("closingDay e dueDay se aplicam apenas a cartões de crédito")
+	}
+	r.Currency = strings.ToUpper(strings.TrimSpace(r.Currency))
+	if r.Currency != "" {
+		return validateCurrency(r.Currency)
+	}
+	return nil
+}
+
+func (r *BudgetRequest) Validate() error {
+	if r.CategoryID == "" {
+		return errors.New("categoryId é obrigatório")
+	}
+	if r.Amount <= 0 {
+		return errors.New("valor deve ser maior que zero")
+	}
+	if r.Rollover == "" {
+		r.Rollover = string(schemas.BudgetRolloverNone)
+	}
+	if err := validateBudgetRollover(r.Rollover); err != nil {
+		return err
+	}
+	if (r.StartMonth == 0) != (r.StartYear == 0) {
+		return errors.New("informe startMonth e startYear juntos")
+	}
+	if r.StartMonth != 0 && (r.StartMonth < 1 || r.StartMonth > 12) {
+		return errors.New("startMonth deve estar entre 1 e 12")
+	}
+	return nil
+}
+
+func (r *UpdateBudgetRequest) Validate() error {
+	if r.Amount == nil && r.Rollover == nil {
+		return errors.New("nenhum campo para atualizar")
+	}
+	if r.Amount != nil && *r.Amount <= 0 {
+		return errors.New("valor deve ser maior que zero")
+	}
+	if r.Rollover != nil {
+		return validateBudgetRollover(*r.Rollover)
+	}
+	return nil
+}
+
+func validateBudgetRollover(value string) error {
+	switch schemas.BudgetRollover(value) {
+	case schemas.BudgetRolloverNone, schemas.BudgetRolloverSurplus, schemas.BudgetRolloverFull:
+		return nil
+	default:
+		return errors.New("rollover deve ser none, surplus ou full")
+	}
+}
+
+func (r *GoalRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return errors.New("nome é obrigatório")
+	}
+	if r.TargetAmount <= 0 {
+		return errors.New("targetAmount deve ser maior que zero")
+	}
+	if r.Deadline == "" {
+		return errors.New("deadline é obrigatório")
+	}
+	return nil
+}
+
+func (r *UpdateGoalRequest) Validate() error {
+	if r.Name == nil && r.TargetAmount == nil && r.Deadline == nil && r.AccountID == nil && !r.RemoveAccount && r.Archived == nil {
+		return errors.New("nenhum campo para atualizar")
+	}
+	if r.Name != nil && strings.TrimSpace(*r.Name) == "" {
+		return errors.New("nome é obrigatório")
+	}
+	if r.TargetAmount != nil && *r.TargetAmount <= 0 {
+		return errors.New("targetAmount deve ser maior que zero")
+	}
+	return nil
+}
+
+func (r *ReceiptScanRequest) Validate() error {
+	r.Mode = strings.ToLower(strings.TrimSpace(r.Mode))
+	switch r.Mode {
+	case "", receiptScanModeAuto, receiptScanModeDraft:
+		return nil
+	default:
+		return errors.New("mode deve ser auto ou draft")
+	}
+}
+
+func (r *UpdateReceiptDraftRequest) Validate() error {
+	if r.Merchant == nil && r.MerchantCNPJ == nil && r.MerchantAddress == nil && r.Date == nil && r.Amount == nil && r.CategoryID == nil && r.Items == nil {
+		return errors.New("nenhum campo para atualizar")
+	}
+	if r.Merchant != nil {
+		merchant := strings.TrimSpace(*r.Merchant)
+		if len(merchant) > 180 {
+			return errors.New("merchant deve ter no máximo 180 caracteres")
+		}
+		r.Merchant = &merchant
+	}
+	if r.MerchantCNPJ != nil && strings.TrimSpace(*r.MerchantCNPJ) != "" {
+		cnpj := normalizeCNPJ(*r.MerchantCNPJ)
+		if cnpj == "" {
+			return errors.New("merchantCnpj inválido")
+		}
+		r.MerchantCNPJ = &cnpj
+	}
+	if r.MerchantAddress != nil {
+		address := strings.TrimSpace(*r.MerchantAddress)
+		if len(address) > 255 {
+			return errors.New("merchantAddress deve ter no máximo 255 caracteres")
+		}
+		r.MerchantAddress = &address
+	}
+	if r.Amount != nil && *r.Amount < 0 {
+		return errors.New("amount não pode ser negativo")
+	}
+	if r.Items != nil {
+		for i, item := range *r.Items {
+			if strings.TrimSpace(item.Description) == "" {
+				return fmt.Errorf("item %d sem descrição", i+1)
+			}
+			if item.Total < 0 {
+				return fmt.Errorf("item %d com total negativo", i+1)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *SuggestCategoryRequest) Validate() error {
+	r.Description = strings.TrimSpace(r.Description)
+	r.MerchantID = strings.TrimSpace(r.MerchantID)
+	if len(r.Description) > 200 {
+		return errors.New("descrição deve ter no máximo 200 caracteres")
+	}
+	if len(r.Items) > 100 {
+		return errors.New("informe no máximo 100 itens")
+	}
+	for i := range r.Items {
+		r.Items[i] = strings.TrimSpace(r.Items[i])
+		if r.Items[i] == "" {
+			return fmt.Errorf("item %d sem descrição", i+1)
+		}
+	}
+	if r.Description == "" && r.MerchantID == "" && len(r.Items) == 0 {
+		return errors.New("informe a descrição, o estabelecimento ou os itens")
+	}
+	return nil
+}
+
+func (r *GoalContributionRequest) Validate() error {
+	if r.Amount == 0 {
+		return errors.New("valor não pode ser zero")
+	}
+	if r.Date == "" {
+		return errors.New("data é obrigatória")
+	}
+	r.Note = strings.TrimSpace(r.Note)
+	return nil
+}
+
+func (r *UpdateAccountRequest) Validate() error {
+	if r.Name == nil && r.Type == nil && r.OpeningBalance == nil && r.ClosingDay == nil && r.DueDay == nil && r.Active == nil {
+		return errors.New("nenhum campo para atualizar")
+	}
+	if r.Name != nil && strings.TrimSpace(*r.Name) == "" {
+		return errors.New("nome é obrigatório")
+	}
+	if r.Type != nil {
+		if err := validateAccountType(*r.Type); err != nil {
+			return err
+		}
+	}
+	closingDay, dueDay := 1, 1
+	if r.ClosingDay != nil {
+		closingDay = *r.ClosingDay
+	}
+	if r.DueDay != nil {
+		dueDay = *r.DueDay
+	}
+	return validateBillingDays(closingDay, dueDay)
+}
+
+func validateBillingDays(closingDay, dueDay int) error {
+	if closingDay < 1 || closingDay > 31 || dueDay < 1 || dueDay > 31 {
+		return errors.New("closingDay e dueDay devem estar entre 1 e 31")
+	}
+	return nil
+}
+
+func (r *InstallmentPurchaseRequest) Validate() error {
+	if r.AccountID == "" {
+		return errors.New("accountId é obrigatório")
+	}
+	if r.CategoryID == "" {
+		return errors.New("categoryId é obrigatório")
+	}
+	r.Description = strings.TrimSpace(r.Description)
+	if r.Description == "" {
+		return errors.New("descrição é obrigatória")
+	}
+	if r.TotalAmount <= 0 {
+		return errors.New("valor deve ser maior que zero")
+	}
+	if r.Installments < 2 || r.Installments > maxInstallments {
+		return fmt.Errorf("installments deve estar entre 2 e %d", maxInstallments)
+	}
+	if r.PurchaseDate == "" {
+		return errors.New("purchaseDate é obrigatória")
+	}
+	return nil
+}
+
+func validateAccountType(value string) error {
+	switch schemas.AccountType(value) {
+	case schemas.AccountTypeChecking, schemas.AccountTypeSavings, schemas.AccountTypeCreditCard, schemas.AccountTypeCash:
+		return nil
+	default:
+		return errors.New("tipo de conta inválido (use corrente, poupanca, cartao_credito ou dinheiro)")
+	}
+}
+
+func (r *TransferRequest) Validate() error {
+	if r.FromAccountID == "" || r.ToAccountID == "" {
+		return errors.New("fromAccountId e toAccountId são obrigatórios")
+	}
+	if r.FromAccountID == r.ToAccountID {
+		return errors.New("as contas de origem e destino devem ser diferentes")
+	}
+	if r.Amount <= 0 {
+		return errors.New("valor deve ser maior que zero")
+	}
+	if r.ToAmount != nil && *r.ToAmount <= 0 {
+		return errors.New("toAmount deve ser maior que zero")
+	}
+	if r.Date == "" {
+		return errors.New("data é obrigatória")
+	}
+	return nil
+}
+
+func (r *RecurrenceRuleRequest) Validate() error {
+	r.Frequency = strings.ToLower(strings.TrimSpace(r.Frequency))
+	switch schemas.RecurrenceFrequency(r.Frequency) {
+	case schemas.RecurrenceFrequencyWeekly:
+		if r.DayOfMonth != 0 {
+			return errors.New("dayOfMonth não se aplica à frequência semanal")
+		}
+	case schemas.RecurrenceFrequencyMonthly, schemas.RecurrenceFrequencyYearly:
+		if r.DayOfMonth < 0 || r.DayOfMonth > 31 {
+			return errors.New("dayOfMonth deve estar entre 1 e 31")
+		}
+	default:
+		return errors.New("frequência inválida (use weekly, monthly ou yearly)")
+	}
+	if r.Interval == 0 {
+		r.Interval = 1
+	}
+	if r.Interval < 1 || r.Interval > maxRecurrenceInterval {
+		return fmt.Errorf("interval deve estar entre 1 e %d", maxRecurrenceInterval)
+	}
+	if r.Count != nil && *r.Count < 1 {
+		return errors.New("count deve ser maior que zero")
+	}
+	return nil
+}
+
+func (r *RecurrenceOccurrenceRequest) Validate() error {
+	if r.CategoryID == nil && r.Description == nil && r.Amount == nil && r.Date == nil {
+		return errors.New("nenhum campo para atualizar")
+	}
+	if r.CategoryID != nil && *r.CategoryID == "" {
+		return errors.New("categoryId inválido")
+	}
+	if r.Description != nil && strings.TrimSpace(*r.Description) == "" {
+		return errors.New("descrição é obrigatória")
+	}
+	if r.Amount != nil && *r.Amount <= 0 {
+		return errors.New("valor deve ser maior que zero")
+	}
+	return nil
+}
+
+func (r *RecurrenceFutureRequest) Validate() error {
+	if r.CategoryID == nil && r.Description == nil && r.Amount == nil && r.Frequency == nil &&
+		r.Interval == nil && r.DayOfMonth == nil && r.EndDate == nil && r.Count == nil {
+		return errors.New("nenhum campo para atualizar")
+	}
+	occurrence := RecurrenceOccurrenceRequest{CategoryID: r.CategoryID, Description: r.Description, Amount: r.Amount}
+	if occurrence.CategoryID != nil || occurrence.Description != nil || occurrence.Amount != nil {
+		if err := occurrence.Validate(); err != nil {
+			return err
+		}
+	}
+	if r.Count != nil && *r.Count < 0 {
+		return errors.New("count não pode ser negativo")
+	}
+	return nil
+}
+
 func (r *SyncRequest) Validate() error {
 	if r.Origin == "" {
 		r.Origin = string(schemas.SyncOriginMobile)
@@ -415,13 +1760,19 @@ func toUserResponse(user *schemas.User) UserResponse {
 	}
 
 	response := UserResponse{
-		ID:        user.ID.String(),
-		Name:      user.Name,
-		Email:     user.Email,
-		Active:    user.Active,
-		LastLogin: user.LastLogin,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:              user.ID.String(),
+		Name:            user.Name,
+		Email:           user.Email,
+		Active:          user.Active,
+		Role:            string(user.Role),
+		EmailVerified:   user.EmailVerifiedAt != nil,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		PendingEmail:    user.PendingEmail,
+		MFAEnabled:      user.MFAEnabled,
+		DeletionDueAt:   user.DeletionDueAt,
+		LastLogin:       user.LastLogin,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
 	}
 
 	if user.Config != nil {
@@ -437,47 +1788,151 @@ func toUserResponse(user *schemas.User) UserResponse {
 	return response
 }
 
+func toHouseholdMemberResponse(member *schemas.HouseholdMember) HouseholdMemberResponse {
+	response := HouseholdMemberResponse{
+		UserID:   member.UserID.String(),
+		Role:     string(member.Role),
+		JoinedAt: member.CreatedAt,
+	}
+	if member.User != nil {
+		response.Name = member.User.Name
+		response.Email = member.User.Email
+	}
+	return response
+}
+
+func toHouseholdInvitationResponse(invitation *schemas.HouseholdInvitation) HouseholdInvitationResponse {
+	return HouseholdInvitationResponse{
+		ID:        invitation.ID.String(),
+		Email:     invitation.Email,
+		Role:      string(invitation.Role),
+		ExpiresAt: invitation.ExpiresAt,
+		CreatedAt: invitation.CreatedAt,
+	}
+}
+
+func toAPIKeyResponse(key *schemas.APIKey) APIKeyResponse {
+	scopes := make([]string, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = string(scope)
+	}
+	return APIKeyResponse{
+		ID:         key.ID.String(),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+func toIdentityResponse(identity *schemas.UserIdentity) IdentityResponse {
+	return IdentityResponse{
+		ID:          identity.ID.String(),
+		Provider:    identity.Provider,
+		Email:       identity.Email,
+		CreatedAt:   identity.CreatedAt,
+		LastLoginAt: identity.LastLoginAt,
+	}
+}
+
+func toSessionResponse(session *schemas.Session, current bool) SessionResponse {
+	return SessionResponse{
+		ID:         session.ID.String(),
+		DeviceName: session.DeviceName,
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.IPAddress,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		ExpiresAt:  session.ExpiresAt,
+		Current:    current,
+	}
+}
+
 func toCategoryResponse(category *schemas.Category) *CategoryResponse {
 	if category == nil {
 		return nil
 	}
 	return &CategoryResponse{
-		ID:        category.ID.String(),
-		Name:      category.Name,
-		Icon:      category.Icon,
-		ColorHex:  category.ColorHex,
-		Type:      string(category.Type),
-		Order:     category.Order,
-		Active:    category.Active,
-		CreatedAt: category.CreatedAt,
-		UpdatedAt: category.UpdatedAt,
+		ID:          category.ID.String(),
+		HouseholdID: uuidPtrString(category.HouseholdID),
+		Name:        category.Name,
+		Icon:        category.Icon,
+		ColorHex:    category.ColorHex,
+		Type:        string(category.Type),
+		Kind:        string(category.Kind),
+		Order:       category.Order,
+		Active:      category.Active,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
 	}
 }
 
+func uuidPtrString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	value := id.String()
+	return &value
+}
+
+// parseOptionalUUID é o inverso de uuidPtrString: ausente, vazio ou inválido vira nil.
+func parseOptionalUUID(value *string) *uuid.UUID {
+	if value == nil {
+		return nil
+	}
+	id, err := uuid.Parse(strings.TrimSpace(*value))
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
 func toExpenseResponse(expense *schemas.Expense) *ExpenseResponse {
 	if expense == nil {
 		return nil
 	}
 
 	resp := &ExpenseResponse{
-		ID:          expense.ID.String(),
-		CategoryID:  expense.CategoryID.String(),
-		Description: expense.Description,
-		Amount:      expense.Amount,
-		Date:        expense.Date,
-		Recurring:   expense.Recurring,
-		Origin:      string(expense.Origin),
-		CreatedAt:   expense.CreatedAt,
-		UpdatedAt:   expense.UpdatedAt,
+		ID:                    expense.ID.String(),
+		HouseholdID:           uuidPtrString(expense.HouseholdID),
+		CreatedBy:             expense.UserID.String(),
+		CategoryID:            expense.CategoryID.String(),
+		Description:           expense.Description,
+		Amount:                expense.Amount,
+		Date:                  expense.Date,
+		Recurring:             expense.Recurring,
+		Origin:                string(expense.Origin),
+		AccountID:             uuidPtrString(expense.AccountID),
+		MerchantID:            uuidPtrString(expense.MerchantID),
+		CreatedAt:             expense.CreatedAt,
+		UpdatedAt:             expense.UpdatedAt,
+		RecurrenceRuleID:      uuidPtrString(expense.RecurrenceRuleID),
+		RecurrenceIndex:       expense.RecurrenceIndex,
+		InstallmentPurchaseID: uuidPtrString(expense.InstallmentPurchaseID),
+		Installment:           installmentLabel(expense),
+		Allocations:           expenseAllocationBreakdown(expense),
 	}
 
 	if expense.Category != nil {
 		resp.Category = toCategoryResponse(expense.Category)
 	}
+	for _, item := range expense.Items {
+		resp.Items = append(resp.Items, ExpenseItemResponse{
+			ID:         item.ID.String(),
+			Name:       item.Name,
+			Quantity:   item.Quantity,
+			UnitPrice:  item.UnitPrice,
+			TotalPrice: item.TotalPrice,
+			CategoryID: uuidPtrString(item.CategoryID),
+		})
+	}
 	if expense.Receipt != nil {
 		resp.Receipt = &ReceiptResponse{
 			ID:            expense.Receipt.ID.String(),
 			FilePath:      expense.Receipt.FilePath,
+			HasImage:      expense.Receipt.ContentType != "",
 			ExtractedText: expense.Receipt.ExtractedText,
 			OcrConfidence: expense.Receipt.OcrConfidence,
 		}
@@ -485,6 +1940,123 @@ func toExpenseResponse(expense *schemas.Expense) *ExpenseResponse {
 	return resp
 }
 
+func toIncomeResponse(income *schemas.Income) *IncomeResponse {
+	if income == nil {
+		return nil
+	}
+
+	resp := &IncomeResponse{
+		ID:               income.ID.String(),
+		HouseholdID:      uuidPtrString(income.HouseholdID),
+		CreatedBy:        income.UserID.String(),
+		CategoryID:       income.CategoryID.String(),
+		Description:      income.Description,
+		Amount:           income.Amount,
+		Date:             income.Date,
+		Recurring:        income.Recurring,
+		AccountID:        uuidPtrString(income.AccountID),
+		CreatedAt:        income.CreatedAt,
+		UpdatedAt:        income.UpdatedAt,
+		RecurrenceRuleID: uuidPtrString(income.RecurrenceRuleID),
+		RecurrenceIndex:  income.RecurrenceIndex,
+	}
+	if income.Category != nil {
+		resp.Category = toCategoryResponse(income.Category)
+	}
+	return resp
+}
+
+func toAccountResponse(account *schemas.Account, balance float64) AccountResponse {
+	return AccountResponse{
+		ID:             account.ID.String(),
+		HouseholdID:    uuidPtrString(account.HouseholdID),
+		CreatedBy:      account.UserID.String(),
+		Name:           account.Name,
+		Type:           string(account.Type),
+		Currency:       account.Currency,
+		OpeningBalance: account.OpeningBalance,
+		Balance:        roundFloat(balance),
+		ClosingDay:     account.ClosingDay,
+		DueDay:         account.DueDay,
+		Active:         account.Active,
+		CreatedAt:      account.CreatedAt,
+		UpdatedAt:      account.UpdatedAt,
+	}
+}
+
+func toBudgetResponse(budget *schemas.Budget) BudgetResponse {
+	resp := BudgetResponse{
+		ID:          budget.ID.String(),
+		HouseholdID: uuidPtrString(budget.HouseholdID),
+		CreatedBy:   budget.UserID.String(),
+		CategoryID:  budget.CategoryID.String(),
+		Amount:      budget.Amount,
+		Rollover:    string(budget.Rollover),
+		StartMonth:  int(budget.StartMonth.Month()),
+		StartYear:   budget.StartMonth.Year(),
+		CreatedAt:   budget.CreatedAt,
+		UpdatedAt:   budget.UpdatedAt,
+	}
+	if budget.Category != nil {
+		resp.Category = toCategoryResponse(budget.Category)
+	}
+	return resp
+}
+
+func toBudgetAlertResponse(alert *schemas.BudgetAlert) BudgetAlertResponse {
+	resp := BudgetAlertResponse{
+		ID:        alert.ID.String(),
+		BudgetID:  alert.BudgetID.String(),
+		Month:     alert.Month,
+		Year:      alert.Year,
+		Threshold: alert.Threshold,
+		Spent:     alert.Spent,
+		Limit:     alert.Limit,
+		CreatedAt: alert.CreatedAt,
+	}
+	if alert.Budget != nil {
+		resp.CategoryID = alert.Budget.CategoryID.String()
+	}
+	return resp
+}
+
+func toTransferResponse(transfer *schemas.Transfer) TransferResponse {
+	return TransferResponse{
+		ID:            transfer.ID.String(),
+		HouseholdID:   uuidPtrString(transfer.HouseholdID),
+		CreatedBy:     transfer.UserID.String(),
+		FromAccountID: transfer.FromAccountID.String(),
+		ToAccountID:   transfer.ToAccountID.String(),
+		Amount:        transfer.Amount,
+		ToAmount:      transfer.ToAmount,
+		Date:          transfer.Date,
+		Description:   transfer.Description,
+		CreatedAt:     transfer.CreatedAt,
+	}
+}
+
+func toRecurrenceRuleResponse(rule *schemas.RecurrenceRule) RecurrenceRuleResponse {
+	return RecurrenceRuleResponse{
+		ID:               rule.ID.String(),
+		HouseholdID:      uuidPtrString(rule.HouseholdID),
+		CreatedBy:        rule.UserID.String(),
+		Kind:             string(rule.Kind),
+		TemplateID:       rule.TemplateID.String(),
+		CategoryID:       rule.CategoryID.String(),
+		Description:      rule.Description,
+		Amount:           rule.Amount,
+		AccountID:        uuidPtrString(rule.AccountID),
+		Frequency:        string(rule.Frequency),
+		Interval:         rule.Interval,
+		DayOfMonth:       rule.DayOfMonth,
+		StartDate:        rule.StartDate,
+		EndDate:          rule.EndDate,
+		Count:            rule.Count,
+		NextOccurrenceAt: rule.NextOccurrenceAt,
+		CreatedAt:        rule.CreatedAt,
+	}
+}
+
 func toTipResponse(tip *schemas.GeneratedTip) TipResponse {
 	return TipResponse{
 		ID:        tip.ID.String(),
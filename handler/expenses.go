@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
@@ -20,6 +23,7 @@ import (
 // @Accept json
 // @Produce json
 // @Param body body ExpenseRequest true "Dados da despesa"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
 // @Success 200 {object} ExpenseResponse
 // @Failure 400 {object} APIError
 // @Failure 401 {object} APIError
@@ -33,6 +37,11 @@ func CreateExpenseHandler(ctx *gin.Context) {
 		return
 	}
 
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
 	var request ExpenseRequest
 	if !bindJSON(ctx, &request) {
 		return
@@ -48,11 +57,16 @@ func CreateExpenseHandler(ctx *gin.Context) {
 		return
 	}
 
-	if !categoryBelongsToUser(getDB(), user.ID, categoryID) {
+	if !categoryInScope(getDB(), scope, categoryID, schemas.CategoryKindExpense) {
 		respondError(ctx, 403, "categoria não pertence ao usuário", nil)
 		return
 	}
 
+	accountID, ok := parseRequestAccount(ctx, getDB(), scope, request.AccountID)
+	if !ok {
+		return
+	}
+
 	date, err := parseDate(request.Date)
 	if err != nil {
 		respondError(ctx, 400, "data inválida", err.Error())
@@ -64,12 +78,14 @@ func CreateExpenseHandler(ctx *gin.Context) {
 	if err := getDB().Transaction(func(tx *gorm.DB) error {
 		expense := schemas.Expense{
 			UserID:      user.ID,
+			HouseholdID: scope.HouseholdID,
 			CategoryID:  categoryID,
 			Description: request.Description,
 			Amount:      request.Amount,
 			Date:        date,
 			Recurring:   request.Recurring,
 			Origin:      schemas.ExpenseOrigin(request.Origin),
+			AccountID:   accountID,
 		}
 		if err := tx.Create(&expense).Error; err != nil {
 			return err
@@ -98,8 +114,9 @@ func CreateExpenseHandler(ctx *gin.Context) {
 		respondError(ctx, 500, "erro ao criar despesa", err.Error())
 		return
 	}
+	checkBudgetAlerts(ctx.Request.Context(), scope, createdExpense.Date)
 
-	if err := getDB().Preload("Category").Preload("Receipt").First(&createdExpense, "id = ?", createdExpense.ID).Error; err != nil {
+	if err := getDB().Preload("Category").Preload("Receipt").Preload("InstallmentPurchase").Preload("Allocations").First(&createdExpense, "id = ?", createdExpense.ID).Error; err != nil {
 		respondError(ctx, 500, "erro ao carregar despesa criada", err.Error())
 		return
 	}
@@ -109,17 +126,32 @@ func CreateExpenseHandler(ctx *gin.Context) {
 
 // ListExpensesHandler godoc
 // @Summary Listar despesas
-// @Description Lista despesas filtradas por mês e ano
+// @Description Lista despesas com filtros, ordenação e paginação por cursor. Sem from/to, o período é o mês informado (ou o atual). O resumo considera todas as despesas filtradas, não apenas a página retornada. Para a próxima página, repita a consulta com o nextCursor recebido.
 // @Tags Despesas
 // @Security Bearer
 // @Produce json
-// @Param month query int false "Mês (1-12)"
-// @Param year query int false "Ano"
-// @Param categoryId query string false "Filtro por categoria"
+// @Param month query int false "Mês (1-12), ignorado quando from/to são informados"
+// @Param year query int false "Ano, ignorado quando from/to são informados"
+// @Param from query string false "Data inicial (inclusiva), ex.: 2024-01-01"
+// @Param to query string false "Data final (inclusiva), ex.: 2024-03-31"
+// @Param categoryId query []string false "Filtro por categoria; repita o parâmetro ou separe por vírgula para várias. Despesas divididas entram também pelas categorias das divisões" collectionFormat(multi)
+// @Param accountId query string false "Filtro por conta"
+// @Param merchantId query string false "Filtro por estabelecimento"
 // @Param origin query string false "Origem: manual|ocr|ia"
-// @Success 200 {object} ExpensesListResponse
+// @Param recurring query bool false "Apenas despesas recorrentes (true) ou não recorrentes (false)"
+// @Param minAmount query number false "Valor mínimo"
+// @Param maxAmount query number false "Valor máximo"
+// @Param q query string false "Busca na descrição e nos nomes dos itens"
+// @Param sort query string false "Ordenação: date|amount|description (padrão date)" Enums(date,amount,description)
+// @Param order query string false "Direção: asc|desc (padrão desc)" Enums(asc,desc)
+// @Param limit query int false "Itens por página (padrão 50, máximo 200)"
+// @Param cursor query string false "Cursor devolvido em pagination.nextCursor"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} ExpenseListSuccess
 // @Failure 400 {object} APIError
 // @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
 // @Router /expenses [get]
 func ListExpensesHandler(ctx *gin.Context) {
 	user, err := getAuthenticatedUser(ctx)
@@ -134,49 +166,73 @@ func ListExpensesHandler(ctx *gin.Context) {
 		return
 	}
 
-	start, end := monthInterval(filter.Month, filter.Year)
+	scope := getDataScope(ctx, user)
 
-	query := getDB().Preload("Category").Preload("Receipt").
-		Where("user_id = ?", user.ID).
-		Where("date >= ? AND date < ?", start, end)
-	if filter.CategoryID != nil {
-		query = query.Where("category_id = ?", *filter.CategoryID)
+	var totals struct {
+		TotalCount  int64   `gorm:"column:total_count"`
+		TotalAmount float64 `gorm:"column:total_amount"`
 	}
-	if filter.Origin != nil {
-		query = query.Where("origin = ?", *filter.Origin)
+	if err := applyExpenseFilter(getDB().Model(&schemas.Expense{}), scope, filter).
+		Select("COUNT(*) AS total_count, COALESCE(SUM(expenses.amount),0) AS total_amount").
+		Scan(&totals).Error; err != nil {
+		respondError(ctx, 500, "erro ao resumir despesas", err.Error())
+		return
+	}
+
+	query := applyExpenseFilter(getDB().Preload("Category").Preload("Receipt").Preload("InstallmentPurchase").Preload("Allocations"), scope, filter)
+	query, err = applyExpenseCursor(query, filter)
+	if err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
 	}
 
+	direction := "DESC"
+	if !filter.Descending {
+		direction = "ASC"
+	}
+	column := expenseSortColumns[filter.Sort]
+
 	var expenses []schemas.Expense
-	if err := query.Order("date DESC").Find(&expenses).Error; err != nil {
+	if err := query.Order(column + " " + direction).
+		Order("expenses.id " + direction).
+		Limit(filter.Limit + 1).
+		Find(&expenses).Error; err != nil {
 		respondError(ctx, 500, "erro ao listar despesas", err.Error())
 		return
 	}
 
+	pagination := ExpensePagination{Limit: filter.Limit}
+	if len(expenses) > filter.Limit {
+		expenses = expenses[:filter.Limit]
+		pagination.HasMore = true
+		pagination.NextCursor = encodeExpenseCursor(filter.Sort, &expenses[len(expenses)-1])
+	}
+
 	responses := make([]ExpenseResponse, len(expenses))
-	total := 0.0
 	for i := range expenses {
 		responses[i] = *toExpenseResponse(&expenses[i])
-		total += expenses[i].Amount
 	}
 
-	summary := ExpenseSummary{TotalCount: len(expenses), TotalAmount: total}
-	if len(expenses) > 0 {
-		summary.AverageValue = roundFloat(total / float64(len(expenses)))
+	summary := ExpenseSummary{TotalCount: int(totals.TotalCount), TotalAmount: roundFloat(totals.TotalAmount)}
+	if totals.TotalCount > 0 {
+		summary.AverageValue = roundFloat(totals.TotalAmount / float64(totals.TotalCount))
 	}
 
-	respondSuccess(ctx, "despesas", ExpensesListResponse{Expenses: responses, Summary: summary})
+	respondSuccess(ctx, "despesas", ExpensesListResponse{Expenses: responses, Summary: summary, Pagination: pagination})
 }
 
 // GetExpenseHandler godoc
 // @Summary Buscar despesa
-// @Description Retorna os detalhes de uma despesa específica
+// @Description Retorna os detalhes de uma despesa específica, com os itens do cupom e a divisão entre categorias
 // @Tags Despesas
 // @Security Bearer
 // @Produce json
 // @Param id path string true "Identificador da despesa"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
 // @Success 200 {object} ExpenseResponse
 // @Failure 400 {object} APIError
 // @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
 // @Failure 404 {object} APIError
 // @Router /expenses/{id} [get]
 func GetExpenseHandler(ctx *gin.Context) {
@@ -193,8 +249,8 @@ func GetExpenseHandler(ctx *gin.Context) {
 	}
 
 	expense := schemas.Expense{}
-	if err := getDB().Preload("Category").Preload("Receipt").
-		Where("id = ? AND user_id = ?", expenseID, user.ID).
+	if err := getDataScope(ctx, user).apply(getDB().Preload("Category").Preload("Receipt").Preload("Items").Preload("InstallmentPurchase").Preload("Allocations"), "expenses").
+		Where("id = ?", expenseID).
 		First(&expense).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			respondError(ctx, 404, "despesa não encontrada", nil)
@@ -209,13 +265,14 @@ func GetExpenseHandler(ctx *gin.Context) {
 
 // UpdateExpenseHandler godoc
 // @Summary Atualizar despesa
-// @Description Atualiza campos de uma despesa existente
+// @Description Atualiza campos de uma despesa existente. Em despesas divididas entre categorias, o novo valor não pode ser menor que a soma das divisões. Trocar a categoria ensina o categorizer: despesas futuras do mesmo estabelecimento ou com a mesma descrição passam a ser sugeridas na nova categoria
 // @Tags Despesas
 // @Security Bearer
 // @Accept json
 // @Produce json
 // @Param id path string true "Identificador da despesa"
 // @Param body body UpdateExpenseRequest true "Campos para atualização"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
 // @Success 200 {object} ExpenseResponse
 // @Failure 400 {object} APIError
 // @Failure 401 {object} APIError
@@ -229,6 +286,11 @@ func UpdateExpenseHandler(ctx *gin.Context) {
 		return
 	}
 
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
 	expenseID, err := parseUUIDParam(ctx.Param("id"))
 	if err != nil {
 		respondError(ctx, 400, "id inválido", nil)
@@ -244,11 +306,13 @@ func UpdateExpenseHandler(ctx *gin.Context) {
 		return
 	}
 
+	var previousDate time.Time
 	err = getDB().Transaction(func(tx *gorm.DB) error {
 		expense := schemas.Expense{}
-		if err := tx.Where("id = ? AND user_id = ?", expenseID, user.ID).First(&expense).Error; err != nil {
+		if err := scope.apply(tx, "expenses").Where("id = ?", expenseID).First(&expense).Error; err != nil {
 			return err
 		}
+		previousDate = expense.Date
 
 		updates := map[string]interface{}{}
 		if request.CategoryID != nil {
@@ -256,15 +320,31 @@ func UpdateExpenseHandler(ctx *gin.Context) {
 			if err != nil {
 				return err
 			}
-			if !categoryBelongsToUser(tx, user.ID, categoryUUID) {
+			if !categoryInScope(tx, scope, categoryUUID, schemas.CategoryKindExpense) {
 				return gorm.ErrInvalidData
 			}
 			updates["category_id"] = categoryUUID
+			if categoryUUID != expense.CategoryID {
+				description := expense.Description
+				if request.Description != nil {
+					description = *request.Description
+				}
+				if err := learnCategoryRule(tx, user, scope, expense.MerchantID, description, categoryUUID); err != nil {
+					return err
+				}
+			}
 		}
 		if request.Description != nil {
 			updates["description"] = *request.Description
 		}
 		if request.Amount != nil {
+			allocated, err := allocatedAmount(tx, expense.ID)
+			if err != nil {
+				return err
+			}
+			if roundFloat(allocated) > *request.Amount {
+				return errAllocationsExceedAmount
+			}
 			updates["amount"] = *request.Amount
 		}
 		if request.Date != nil {
@@ -280,6 +360,18 @@ func UpdateExpenseHandler(ctx *gin.Context) {
 		if request.Origin != nil {
 			updates["origin"] = schemas.ExpenseOrigin(*request.Origin)
 		}
+		if request.RemoveAccount {
+			updates["account_id"] = nil
+		} else if request.AccountID != nil {
+			accountUUID, err := uuid.Parse(*request.AccountID)
+			if err != nil {
+				return err
+			}
+			if !accountInScope(tx, scope, accountUUID) {
+				return errAccountScope
+			}
+			updates["account_id"] = accountUUID
+		}
 
 		if len(updates) > 0 {
 			if err := tx.Model(&expense).Updates(updates).Error; err != nil {
@@ -312,11 +404,16 @@ func UpdateExpenseHandler(ctx *gin.Context) {
 					return err
 				}
 			} else {
-				if err := tx.Model(&receipt).Updates(map[string]interface{}{
+				updates := map[string]interface{}{
 					"file_path":      request.Receipt.FilePath,
 					"extracted_text": request.Receipt.ExtractedText,
 					"ocr_confidence": confidence,
-				}).Error; err != nil {
+				}
+				// A imagem enviada pelo scan fica atrelada ao recibo e não pode ser trocada por outro caminho.
+				if receipt.ContentType != "" {
+					delete(updates, "file_path")
+				}
+				if err := tx.Model(&receipt).Updates(updates).Error; err != nil {
 					return err
 				}
 			}
@@ -334,17 +431,26 @@ func UpdateExpenseHandler(ctx *gin.Context) {
 			respondError(ctx, 403, "categoria não pertence ao usuário", nil)
 			return
 		}
+		if err == errAccountScope {
+			respondError(ctx, 403, err.Error(), nil)
+			return
+		}
+		if err == errAllocationsExceedAmount {
+			respondError(ctx, 400, "o novo valor é menor que a soma das divisões da despesa; ajuste a divisão antes", nil)
+			return
+		}
 		respondError(ctx, 400, "erro ao atualizar despesa", err.Error())
 		return
 	}
 
 	updated := schemas.Expense{}
-	if err := getDB().Preload("Category").Preload("Receipt").
-		Where("id = ? AND user_id = ?", expenseID, user.ID).
+	if err := getDB().Preload("Category").Preload("Receipt").Preload("InstallmentPurchase").Preload("Allocations").
+		Where("id = ?", expenseID).
 		First(&updated).Error; err != nil {
 		respondError(ctx, 500, "erro ao recarregar despesa", err.Error())
 		return
 	}
+	checkBudgetAlerts(ctx.Request.Context(), scope, previousDate, updated.Date)
 
 	respondSuccess(ctx, "despesa atualizada", toExpenseResponse(&updated))
 }
@@ -355,9 +461,11 @@ func UpdateExpenseHandler(ctx *gin.Context) {
 // @Tags Despesas
 // @Security Bearer
 // @Param id path string true "Identificador da despesa"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
 // @Success 200 {object} APISuccess
 // @Failure 400 {object} APIError
 // @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
 // @Failure 404 {object} APIError
 // @Router /expenses/{id} [delete]
 func DeleteExpenseHandler(ctx *gin.Context) {
@@ -367,13 +475,18 @@ func DeleteExpenseHandler(ctx *gin.Context) {
 		return
 	}
 
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
 	expenseID, err := parseUUIDParam(ctx.Param("id"))
 	if err != nil {
 		respondError(ctx, 400, "id inválido", nil)
 		return
 	}
 
-	result := getDB().Where("id = ? AND user_id = ?", expenseID, user.ID).Delete(&schemas.Expense{})
+	result := scope.apply(getDB(), "expenses").Where("id = ?", expenseID).Delete(&schemas.Expense{})
 	if result.Error != nil {
 		respondError(ctx, 500, "erro ao remover despesa", result.Error.Error())
 		return
@@ -391,14 +504,60 @@ func buildExpenseFilter(ctx *gin.Context) (ExpenseFilter, error) {
 	month := parseIntDefault(ctx.Query("month"), int(now.Month()))
 	year := parseIntDefault(ctx.Query("year"), now.Year())
 
-	filter := ExpenseFilter{Month: month, Year: year}
+	filter := ExpenseFilter{Month: month, Year: year, Sort: "date", Descending: true}
+	filter.From, filter.To = monthInterval(month, year)
+
+	fromParam, toParam := ctx.Query("from"), ctx.Query("to")
+	if fromParam != "" || toParam != "" {
+		filter.From, filter.To = time.Time{}, time.Time{}
+		if fromParam != "" {
+			from, err := parseDate(fromParam)
+			if err != nil {
+				return filter, fmt.Errorf("from inválido")
+			}
+			filter.From = from
+		}
+		if toParam != "" {
+			to, err := parseDate(toParam)
+			if err != nil {
+				return filter, fmt.Errorf("to inválido")
+			}
+			// to é inclusivo: a consulta usa o início do dia seguinte como limite aberto.
+			filter.To = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, to.Location()).AddDate(0, 0, 1)
+		}
+		if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+			return filter, fmt.Errorf("from deve ser anterior ou igual a to")
+		}
+	}
+
+	for _, param := range ctx.QueryArray("categoryId") {
+		for _, raw := range strings.Split(param, ",") {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				continue
+			}
+			categoryID, err := uuid.Parse(raw)
+			if err != nil {
+				return filter, fmt.Errorf("categoryId inválido")
+			}
+			filter.CategoryIDs = append(filter.CategoryIDs, categoryID)
+		}
+	}
+
+	if accountParam := strings.TrimSpace(ctx.Query("accountId")); accountParam != "" {
+		accountID, err := uuid.Parse(accountParam)
+		if err != nil {
+			return filter, fmt.Errorf("accountId inválido")
+		}
+		filter.AccountID = &accountID
+	}
 
-	if categoryParam := ctx.Query("categoryId"); categoryParam != "" {
-		categoryID, err := uuid.Parse(categoryParam)
+	if merchantParam := strings.TrimSpace(ctx.Query("merchantId")); merchantParam != "" {
+		merchantID, err := uuid.Parse(merchantParam)
 		if err != nil {
-			return filter, err
+			return filter, fmt.Errorf("merchantId inválido")
 		}
-		filter.CategoryID = &categoryID
+		filter.MerchantID = &merchantID
 	}
 
 	if originParam := ctx.Query("origin"); originParam != "" {
@@ -411,9 +570,177 @@ func buildExpenseFilter(ctx *gin.Context) (ExpenseFilter, error) {
 		}
 	}
 
+	if recurringParam := ctx.Query("recurring"); recurringParam != "" {
+		recurring, err := strconv.ParseBool(recurringParam)
+		if err != nil {
+			return filter, fmt.Errorf("recurring inválido")
+		}
+		filter.Recurring = &recurring
+	}
+
+	for name, target := range map[string]**float64{"minAmount": &filter.MinAmount, "maxAmount": &filter.MaxAmount} {
+		raw := ctx.Query(name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || value < 0 {
+			return filter, fmt.Errorf("%s inválido", name)
+		}
+		*target = &value
+	}
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return filter, fmt.Errorf("minAmount deve ser menor ou igual a maxAmount")
+	}
+
+	filter.Query = strings.ToLower(strings.TrimSpace(ctx.Query("q")))
+
+	if sort := strings.ToLower(strings.TrimSpace(ctx.Query("sort"))); sort != "" {
+		if _, ok := expenseSortColumns[sort]; !ok {
+			return filter, fmt.Errorf("sort inválido (use date, amount ou description)")
+		}
+		filter.Sort = sort
+	}
+	switch strings.ToLower(strings.TrimSpace(ctx.Query("order"))) {
+	case "", "desc":
+	case "asc":
+		filter.Descending = false
+	default:
+		return filter, fmt.Errorf("order inválido (use asc ou desc)")
+	}
+
+	filter.Limit = parseIntDefault(ctx.Query("limit"), 50)
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+	if filter.Limit > 200 {
+		filter.Limit = 200
+	}
+
+	if cursorParam := ctx.Query("cursor"); cursorParam != "" {
+		cursor, err := decodeExpenseCursor(cursorParam)
+		if err != nil || cursor.Sort != filter.Sort {
+			return filter, fmt.Errorf("cursor inválido")
+		}
+		filter.Cursor = cursor
+	}
+
 	return filter, nil
 }
 
+// expenseSortColumns mapeia as ordenações aceitas em ListExpensesHandler para as colunas da tabela.
+var expenseSortColumns = map[string]string{
+	"date":        "expenses.date",
+	"amount":      "expenses.amount",
+	"description": "expenses.description",
+}
+
+// applyExpenseFilter aplica o escopo e os filtros da listagem, sem cursor, ordenação ou limite,
+// para que o resumo e a página usem exatamente o mesmo conjunto de despesas.
+func applyExpenseFilter(db *gorm.DB, scope dataScope, filter ExpenseFilter) *gorm.DB {
+	query := scope.apply(db, "expenses")
+	if !filter.From.IsZero() {
+		query = query.Where("expenses.date >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("expenses.date < ?", filter.To)
+	}
+	if len(filter.CategoryIDs) > 0 {
+		query = query.Where("expenses.category_id IN ? OR EXISTS (SELECT 1 FROM expense_allocations WHERE expense_allocations.expense_id = expenses.id AND expense_allocations.deleted_at IS NULL AND expense_allocations.category_id IN ?)", filter.CategoryIDs, filter.CategoryIDs)
+	}
+	if filter.AccountID != nil {
+		query = query.Where("expenses.account_id = ?", *filter.AccountID)
+	}
+	if filter.MerchantID != nil {
+		query = query.Where("expenses.merchant_id = ?", *filter.MerchantID)
+	}
+	if filter.Origin != nil {
+		query = query.Where("expenses.origin = ?", *filter.Origin)
+	}
+	if filter.Recurring != nil {
+		query = query.Where("expenses.recurring = ?", *filter.Recurring)
+	}
+	if filter.MinAmount != nil {
+		query = query.Where("expenses.amount >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("expenses.amount <= ?", *filter.MaxAmount)
+	}
+	if filter.Query != "" {
+		pattern := "%" + filter.Query + "%"
+		query = query.Where("LOWER(expenses.description) LIKE ? OR EXISTS (SELECT 1 FROM expense_items WHERE expense_items.expense_id = expenses.id AND LOWER(expense_items.name) LIKE ?)", pattern, pattern)
+	}
+	return query
+}
+
+// expenseCursor guarda o valor da coluna de ordenação e o ID da última despesa da página;
+// o ID desempata valores iguais e mantém a paginação estável.
+type expenseCursor struct {
+	Sort  string    `json:"s"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+func encodeExpenseCursor(sort string, expense *schemas.Expense) string {
+	cursor := expenseCursor{Sort: sort, ID: expense.ID}
+	switch sort {
+	case "amount":
+		cursor.Value = strconv.FormatFloat(expense.Amount, 'f', -1, 64)
+	case "description":
+		cursor.Value = expense.Description
+	default:
+		cursor.Value = expense.Date.UTC().Format(time.RFC3339Nano)
+	}
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeExpenseCursor(value string) (*expenseCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	cursor := expenseCursor{}
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+func applyExpenseCursor(query *gorm.DB, filter ExpenseFilter) (*gorm.DB, error) {
+	if filter.Cursor == nil {
+		return query, nil
+	}
+
+	var value interface{}
+	switch filter.Sort {
+	case "amount":
+		amount, err := strconv.ParseFloat(filter.Cursor.Value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cursor inválido")
+		}
+		value = amount
+	case "description":
+		value = filter.Cursor.Value
+	default:
+		date, err := time.Parse(time.RFC3339Nano, filter.Cursor.Value)
+		if err != nil {
+			return nil, fmt.Errorf("cursor inválido")
+		}
+		value = date
+	}
+
+	operator := ">"
+	if filter.Descending {
+		operator = "<"
+	}
+	column := expenseSortColumns[filter.Sort]
+	return query.Where(
+		fmt.Sprintf("%s %s ? OR (%s = ? AND expenses.id %s ?)", column, operator, column, operator),
+		value, value, filter.Cursor.ID,
+	), nil
+}
+
 func monthInterval(month, year int) (time.Time, time.Time) {
 	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
 	end := start.AddDate(0, 1, 0)
@@ -444,10 +771,11 @@ func parseIntDefault(value string, fallback int) int {
 	return fallback
 }
 
-func categoryBelongsToUser(db *gorm.DB, userID uuid.UUID, categoryID uuid.UUID) bool {
+// categoryInScope confere se a categoria pertence ao escopo e é da natureza esperada (despesa ou receita).
+func categoryInScope(db *gorm.DB, scope dataScope, categoryID uuid.UUID, kind schemas.CategoryKind) bool {
 	var count int64
-	if err := db.Model(&schemas.Category{}).
-		Where("id = ? AND user_id = ?", categoryID, userID).
+	if err := scope.apply(db.Model(&schemas.Category{}), "categories").
+		Where("id = ? AND kind = ?", categoryID, kind).
 		Count(&count).Error; err != nil {
 		return false
 	}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/base64"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestExpenseCursorRoundTrip(t *testing.T) {
+	setupTestDB(t)
+	expense := &schemas.Expense{
+		Description: "Mercado São José",
+		Amount:      123.45,
+		Date:        time.Date(2024, 2, 29, 13, 45, 10, 123456789, time.FixedZone("BRT", -3*60*60)),
+	}
+	expense.ID = uuid.New()
+
+	tests := []struct {
+		sort  string
+		value string
+	}{
+		{"date", "2024-02-29T16:45:10.123456789Z"},
+		{"amount", "123.45"},
+		{"description", "Mercado São José"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			encoded := encodeExpenseCursor(tt.sort, expense)
+			cursor, err := decodeExpenseCursor(encoded)
+			if err != nil {
+				t.Fatalf("decodeExpenseCursor: %v", err)
+			}
+			if cursor.Sort != tt.sort || cursor.Value != tt.value || cursor.ID != expense.ID {
+				t.Fatalf("cursor = %+v, esperado sort %s, valor %s e id %s", cursor, tt.sort, tt.value, expense.ID)
+			}
+			if _, err := applyExpenseCursor(getDB(), ExpenseFilter{Sort: tt.sort, Cursor: cursor}); err != nil {
+				t.Fatalf("applyExpenseCursor: %v", err)
+			}
+		})
+	}
+}
+
+func TestExpenseCursorRejectsTampering(t *testing.T) {
+	setupTestDB(t)
+	expense := &schemas.Expense{Amount: 10, Date: time.Now()}
+	expense.ID = uuid.New()
+	dateCursor := encodeExpenseCursor("date", expense)
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"base64 inválido", "cursor=%25%25%25"},
+		{"json inválido", "cursor=" + base64.RawURLEncoding.EncodeToString([]byte("{nao-json"))},
+		{"ordenação diferente da pedida", "sort=amount&cursor=" + dateCursor},
+		{"cursor truncado", "cursor=" + dateCursor[:len(dateCursor)-5]},
+		{"id inválido", "cursor=" + base64.RawURLEncoding.EncodeToString([]byte(`{"s":"date","v":"2024-01-01T00:00:00Z","id":"x"}`))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest("GET", "/expenses?"+tt.query, nil)
+			if _, err := buildExpenseFilter(ctx); err == nil {
+				t.Fatal("cursor adulterado deveria ser recusado")
+			}
+		})
+	}
+
+	// Um cursor bem formado cujo valor não combina com a ordenação é recusado ao montar a consulta.
+	for _, sort := range []string{"date", "amount"} {
+		filter := ExpenseFilter{Sort: sort, Cursor: &expenseCursor{Sort: sort, Value: "não é data nem número", ID: uuid.New()}}
+		if _, err := applyExpenseCursor(getDB(), filter); err == nil {
+			t.Fatalf("valor inválido para %s deveria ser recusado", sort)
+		}
+	}
+}
+
+func TestExpenseCursorPagination(t *testing.T) {
+	setupTestDB(t)
+	user := createTestUser(t, "ana@example.com")
+	scope := personalScope(user.ID)
+
+	// Datas repetidas obrigam o desempate pelo id.
+	date := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		expense := schemas.Expense{UserID: user.ID, CategoryID: uuid.New(), Description: "compra", Amount: float64(i + 1), Date: date.AddDate(0, 0, -(i / 2))}
+		if err := getDB().Create(&expense).Error; err != nil {
+			t.Fatalf("erro criando despesa: %v", err)
+		}
+	}
+
+	filter := ExpenseFilter{Sort: "date", Descending: true, Limit: 2}
+	seen := map[uuid.UUID]bool{}
+	var previous *schemas.Expense
+	for page := 0; page < 5; page++ {
+		query, err := applyExpenseCursor(applyExpenseFilter(getDB().Model(&schemas.Expense{}), scope, filter), filter)
+		if err != nil {
+			t.Fatalf("applyExpenseCursor: %v", err)
+		}
+		var expenses []schemas.Expense
+		if err := query.Order("expenses.date DESC").Order("expenses.id DESC").Limit(filter.Limit).Find(&expenses).Error; err != nil {
+			t.Fatalf("erro listando despesas: %v", err)
+		}
+		if len(expenses) == 0 {
+			break
+		}
+		for i := range expenses {
+			if seen[expenses[i].ID] {
+				t.Fatalf("despesa %s repetida entre páginas", expenses[i].ID)
+			}
+			if previous != nil && expenses[i].Date.After(previous.Date) {
+				t.Fatalf("ordem quebrada: %v depois de %v", expenses[i].Date, previous.Date)
+			}
+			seen[expenses[i].ID] = true
+			previous = &expenses[i]
+		}
+
+		cursor, err := decodeExpenseCursor(encodeExpenseCursor(filter.Sort, previous))
+		if err != nil {
+			t.Fatalf("decodeExpenseCursor: %v", err)
+		}
+		filter.Cursor = cursor
+	}
+
+	if len(seen) != 5 {
+		t.Fatalf("páginas cobriram %d despesas, esperado 5", len(seen))
+	}
+}
@@ -0,0 +1,744 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// goalPaceMonths é a janela, em meses, usada para medir o ritmo recente de aportes.
+const goalPaceMonths = 3
+
+const (
+	goalStatusAchieved = "achieved"
+	goalStatusOverdue  = "overdue"
+	goalStatusOnTrack  = "on_track"
+	goalStatusBehind   = "behind"
+)
+
+// goalStatusLabels descreve a situação das metas nos prompts de dicas.
+var goalStatusLabels = map[string]string{
+	goalStatusAchieved: "concluída",
+	goalStatusOverdue:  "prazo vencido",
+	goalStatusOnTrack:  "no ritmo",
+	goalStatusBehind:   "atrasada",
+}
+
+var errGoalWithoutAccount = errors.New("a meta não tem conta vinculada para receber o aporte")
+
+// ListGoalsHandler godoc
+// @Summary Listar metas
+// @Description Lista as metas de economia do escopo atual com o progresso de cada uma
+// @Tags Metas
+// @Security Bearer
+// @Produce json
+// @Param includeArchived query bool false "Inclui metas arquivadas (padrão false)"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} GoalListSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /goals [get]
+func ListGoalsHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	includeArchived := false
+	if raw := ctx.Query("includeArchived"); raw != "" {
+		includeArchived, err = strconv.ParseBool(raw)
+		if err != nil {
+			respondError(ctx, 400, "includeArchived inválido", nil)
+			return
+		}
+	}
+
+	goals, err := loadGoals(getDataScope(ctx, user), includeArchived)
+	if err != nil {
+		respondError(ctx, 500, "erro ao listar metas", err.Error())
+		return
+	}
+
+	now := time.Now()
+	responses := make([]GoalResponse, len(goals))
+	for i := range goals {
+		responses[i] = toGoalResponse(&goals[i], now, false)
+	}
+
+	respondSuccess(ctx, "metas", responses)
+}
+
+// GoalProgressHandler godoc
+// @Summary Progresso das metas
+// @Description Resume as metas ativas: total a atingir, total guardado, aporte mensal necessário somado e quantas estão no ritmo ou atrasadas. Para cada meta, o aporte mensal necessário divide o que falta pelos meses até o prazo (incluindo o atual); a meta está no ritmo quando o valor guardado alcança o esperado numa evolução linear entre a criação e o prazo.
+// @Tags Metas
+// @Security Bearer
+// @Produce json
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} GoalProgressSuccess
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /goals/progress [get]
+func GoalProgressHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	goals, err := loadGoals(getDataScope(ctx, user), false)
+	if err != nil {
+		respondError(ctx, 500, "erro ao carregar metas", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "progresso das metas", summarizeGoals(goals, time.Now()))
+}
+
+// CreateGoalHandler godoc
+// @Summary Criar meta
+// @Description Cria uma meta de economia com valor alvo, prazo e, opcionalmente, a conta onde o dinheiro fica reservado
+// @Tags Metas
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body GoalRequest true "Dados da meta"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} GoalSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /goals [post]
+func CreateGoalHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	var request GoalRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	deadline, err := parseGoalDeadline(request.Deadline)
+	if err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	accountID, ok := parseRequestAccount(ctx, getDB(), scope, request.AccountID)
+	if !ok {
+		return
+	}
+
+	goal := schemas.Goal{
+		UserID:       user.ID,
+		HouseholdID:  scope.HouseholdID,
+		Name:         request.Name,
+		TargetAmount: request.TargetAmount,
+		Deadline:     deadline,
+		AccountID:    accountID,
+	}
+	if err := getDB().Create(&goal).Error; err != nil {
+		respondError(ctx, 500, "erro ao criar meta", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "meta criada", toGoalResponse(&goal, time.Now(), false))
+}
+
+// GetGoalHandler godoc
+// @Summary Buscar meta
+// @Description Retorna uma meta com o progresso e o histórico de aportes e resgates
+// @Tags Metas
+// @Security Bearer
+// @Produce json
+// @Param id path string true "Identificador da meta"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} GoalSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /goals/{id} [get]
+func GetGoalHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	goalID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	goal, err := loadGoal(getDB(), getDataScope(ctx, user), goalID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "meta não encontrada", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao carregar meta", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "meta", toGoalResponse(goal, time.Now(), true))
+}
+
+// UpdateGoalHandler godoc
+// @Summary Atualizar meta
+// @Description Atualiza nome, valor alvo, prazo, conta vinculada ou arquivamento de uma meta
+// @Tags Metas
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "Identificador da meta"
+// @Param body body UpdateGoalRequest true "Campos para atualização"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} GoalSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /goals/{id} [put]
+func UpdateGoalHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	goalID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	var request UpdateGoalRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	goal, err := loadGoal(getDB(), scope, goalID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "meta não encontrada", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao carregar meta", err.Error())
+		return
+	}
+
+	updates := map[string]interface{}{}
+	if request.Name != nil {
+		updates["name"] = strings.TrimSpace(*request.Name)
+	}
+	if request.TargetAmount != nil {
+		updates["target_amount"] = *request.TargetAmount
+	}
+	if request.Deadline != nil {
+		deadline, err := parseGoalDeadline(*request.Deadline)
+		if err != nil {
+			respondError(ctx, 400, err.Error(), nil)
+			return
+		}
+		updates["deadline"] = deadline
+	}
+	if request.RemoveAccount {
+		updates["account_id"] = nil
+	} else if request.AccountID != nil {
+		accountID, ok := parseRequestAccount(ctx, getDB(), scope, *request.AccountID)
+		if !ok {
+			return
+		}
+		updates["account_id"] = accountID
+	}
+	if request.Archived != nil {
+		updates["archived"] = *request.Archived
+	}
+
+	if err := getDB().Model(goal).Updates(updates).Error; err != nil {
+		respondError(ctx, 500, "erro ao atualizar meta", err.Error())
+		return
+	}
+	goal, err = loadGoal(getDB(), scope, goalID)
+	if err != nil {
+		respondError(ctx, 500, "erro ao carregar meta atualizada", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "meta atualizada", toGoalResponse(goal, time.Now(), true))
+}
+
+// DeleteGoalHandler godoc
+// @Summary Excluir meta
+// @Description Exclui a meta e seu histórico de aportes. Transferências geradas pelos aportes são mantidas, pois o dinheiro continua na conta de destino.
+// @Tags Metas
+// @Security Bearer
+// @Param id path string true "Identificador da meta"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /goals/{id} [delete]
+func DeleteGoalHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	goalID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		result := scope.apply(tx, "goals").Where("id = ?", goalID).Delete(&schemas.Goal{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Where("goal_id = ?", goalID).Delete(&schemas.GoalContribution{}).Error
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "meta não encontrada", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao excluir meta", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "meta excluída", nil)
+}
+
+// CreateGoalContributionHandler godoc
+// @Summary Registrar aporte
+// @Description Registra um aporte na meta ou, com valor negativo, um resgate. Com fromAccountId, o aporte também gera uma transferência da conta informada para a conta vinculada à meta e o resgate, uma transferência da conta da meta de volta para a conta informada; as duas contas devem usar a mesma moeda.
+// @Tags Metas
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "Identificador da meta"
+// @Param body body GoalContributionRequest true "Dados do aporte"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} GoalSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /goals/{id}/contributions [post]
+func CreateGoalContributionHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	goalID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	var request GoalContributionRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	goal, err := loadGoal(getDB(), scope, goalID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "meta não encontrada", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao carregar meta", err.Error())
+		return
+	}
+
+	date, err := parseDate(request.Date)
+	if err != nil {
+		respondError(ctx, 400, "data inválida", err.Error())
+		return
+	}
+
+	var transfer *schemas.Transfer
+	if request.FromAccountID != "" {
+		if goal.AccountID == nil {
+			respondError(ctx, 400, errGoalWithoutAccount.Error(), nil)
+			return
+		}
+		fromID, err := uuid.Parse(request.FromAccountID)
+		if err != nil {
+			respondError(ctx, 400, "fromAccountId inválido", nil)
+			return
+		}
+		if fromID == *goal.AccountID {
+			respondError(ctx, 400, "a conta de origem deve ser diferente da conta da meta", nil)
+			return
+		}
+
+		var accounts []schemas.Account
+		if err := scope.apply(getDB(), "accounts").
+			Where("id IN ? AND active = ?", []uuid.UUID{fromID, *goal.AccountID}, true).
+			Find(&accounts).Error; err != nil {
+			respondError(ctx, 500, "erro ao carregar contas", err.Error())
+			return
+		}
+		if len(accounts) != 2 {
+			respondError(ctx, 403, errAccountScope.Error(), nil)
+			return
+		}
+		if accounts[0].Currency != accounts[1].Currency {
+			respondError(ctx, 400, "a conta de origem e a conta da meta devem usar a mesma moeda", nil)
+			return
+		}
+
+		// Transferências têm sempre valor positivo: o resgate faz o caminho inverso, da conta da meta para a
+		// conta informada.
+		transfer = &schemas.Transfer{
+			UserID:        user.ID,
+			HouseholdID:   scope.HouseholdID,
+			FromAccountID: fromID,
+			ToAccountID:   *goal.AccountID,
+			Amount:        request.Amount,
+			ToAmount:      request.Amount,
+			Date:          date,
+			Description:   "Aporte: " + goal.Name,
+		}
+		if request.Amount < 0 {
+			transfer.FromAccountID, transfer.ToAccountID = *goal.AccountID, fromID
+			transfer.Amount, transfer.ToAmount = -request.Amount, -request.Amount
+			transfer.Description = "Resgate: " + goal.Name
+		}
+	}
+
+	contribution := schemas.GoalContribution{
+		GoalID: goal.ID,
+		Amount: request.Amount,
+		Date:   date,
+		Note:   request.Note,
+	}
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		if transfer != nil {
+			if err := tx.Create(transfer).Error; err != nil {
+				return err
+			}
+			contribution.TransferID = &transfer.ID
+		}
+		return tx.Create(&contribution).Error
+	})
+	if err != nil {
+		respondError(ctx, 500, "erro ao registrar aporte", err.Error())
+		return
+	}
+
+	goal, err = loadGoal(getDB(), scope, goalID)
+	if err != nil {
+		respondError(ctx, 500, "erro ao carregar meta", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "aporte registrado", toGoalResponse(goal, time.Now(), true))
+}
+
+// DeleteGoalContributionHandler godoc
+// @Summary Remover aporte
+// @Description Remove um aporte ou resgate da meta, junto com a transferência que ele gerou
+// @Tags Metas
+// @Security Bearer
+// @Produce json
+// @Param id path string true "Identificador da meta"
+// @Param contributionId path string true "Identificador do aporte"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} GoalSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /goals/{id}/contributions/{contributionId} [delete]
+func DeleteGoalContributionHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	goalID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+	contributionID, err := parseUUIDParam(ctx.Param("contributionId"))
+	if err != nil {
+		respondError(ctx, 400, "contributionId inválido", nil)
+		return
+	}
+
+	goal, err := loadGoal(getDB(), scope, goalID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "meta não encontrada", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao carregar meta", err.Error())
+		return
+	}
+
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		contribution := schemas.GoalContribution{}
+		if err := tx.Where("id = ? AND goal_id = ?", contributionID, goal.ID).First(&contribution).Error; err != nil {
+			return err
+		}
+		if contribution.TransferID != nil {
+			if err := tx.Where("id = ?", *contribution.TransferID).Delete(&schemas.Transfer{}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&contribution).Error
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "aporte não encontrado", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao remover aporte", err.Error())
+		return
+	}
+
+	goal, err = loadGoal(getDB(), scope, goalID)
+	if err != nil {
+		respondError(ctx, 500, "erro ao carregar meta", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "aporte removido", toGoalResponse(goal, time.Now(), true))
+}
+
+func loadGoal(db *gorm.DB, scope dataScope, goalID uuid.UUID) (*schemas.Goal, error) {
+	goal := schemas.Goal{}
+	if err := scope.apply(db.Preload("Contributions", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("date ASC, created_at ASC")
+	}), "goals").Where("id = ?", goalID).First(&goal).Error; err != nil {
+		return nil, err
+	}
+	return &goal, nil
+}
+
+func loadGoals(scope dataScope, includeArchived bool) ([]schemas.Goal, error) {
+	query := scope.apply(getDB().Preload("Contributions", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("date ASC, created_at ASC")
+	}), "goals")
+	if !includeArchived {
+		query = query.Where("archived = ?", false)
+	}
+
+	var goals []schemas.Goal
+	if err := query.Order("deadline ASC").Find(&goals).Error; err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+// parseGoalDeadline aceita os formatos de parseDate e exige que o prazo não esteja no passado.
+func parseGoalDeadline(value string) (time.Time, error) {
+	deadline, err := parseDate(value)
+	if err != nil {
+		return time.Time{}, errors.New("deadline inválido")
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if deadline.Before(today) {
+		return time.Time{}, errors.New("deadline não pode estar no passado")
+	}
+	return deadline, nil
+}
+
+// goalProgress calcula o progresso da meta a partir das contribuições carregadas. O ritmo médio considera os
+// aportes dos últimos goalPaceMonths meses (ou desde a criação, para metas mais novas) e projeta a data em que
+// o valor alvo seria alcançado mantendo esse ritmo.
+func goalProgress(goal *schemas.Goal, now time.Time) GoalProgress {
+	paceStart := now.AddDate(0, -goalPaceMonths, 0)
+	recentOnly := true
+	if goal.CreatedAt.After(paceStart) {
+		paceStart = goal.CreatedAt
+		recentOnly = false
+	}
+
+	saved, recent := 0.0, 0.0
+	for _, contribution := range goal.Contributions {
+		saved += contribution.Amount
+		if contribution.Date.After(now) {
+			continue
+		}
+		if !recentOnly || !contribution.Date.Before(paceStart) {
+			recent += contribution.Amount
+		}
+	}
+
+	progress := GoalProgress{
+		Saved:           roundFloat(saved),
+		PercentComplete: roundFloat(saved / goal.TargetAmount * 100),
+	}
+	remaining := math.Max(goal.TargetAmount-saved, 0)
+	progress.Remaining = roundFloat(remaining)
+
+	today := now.UTC().Truncate(24 * time.Hour)
+	if !goal.Deadline.Before(today) {
+		progress.MonthsLeft = monthKey(goal.Deadline) - monthKey(now) + 1
+	}
+	if remaining > 0 {
+		progress.RequiredMonthly = roundFloat(remaining / math.Max(float64(progress.MonthsLeft), 1))
+	}
+
+	ratio := 1.0
+	if total := goal.Deadline.Sub(goal.CreatedAt); total > 0 {
+		ratio = math.Min(math.Max(now.Sub(goal.CreatedAt).Seconds()/total.Seconds(), 0), 1)
+	}
+	expected := goal.TargetAmount * ratio
+	progress.ExpectedSaved = roundFloat(expected)
+
+	paceMonths := math.Max(now.Sub(paceStart).Hours()/24/30.44, 1)
+	average := recent / paceMonths
+	progress.AverageMonthly = roundFloat(average)
+	if remaining > 0 && average > 0 {
+		projected := now.AddDate(0, int(math.Ceil(remaining/average)), 0)
+		progress.ProjectedCompletion = &projected
+	}
+
+	switch {
+	case remaining <= 0:
+		progress.Status = goalStatusAchieved
+	case goal.Deadline.Before(today):
+		progress.Status = goalStatusOverdue
+	case saved >= expected-0.005:
+		progress.Status = goalStatusOnTrack
+	default:
+		progress.Status = goalStatusBehind
+	}
+	return progress
+}
+
+func summarizeGoals(goals []schemas.Goal, now time.Time) GoalProgressSummary {
+	summary := GoalProgressSummary{Goals: make([]GoalResponse, len(goals))}
+	for i := range goals {
+		response := toGoalResponse(&goals[i], now, false)
+		summary.Goals[i] = response
+		summary.TotalTarget += goals[i].TargetAmount
+		summary.TotalSaved += response.Progress.Saved
+		summary.TotalRequiredMonthly += response.Progress.RequiredMonthly
+		switch response.Progress.Status {
+		case goalStatusOnTrack, goalStatusAchieved:
+			summary.OnTrack++
+		default:
+			summary.Behind++
+		}
+	}
+	summary.TotalTarget = roundFloat(summary.TotalTarget)
+	summary.TotalSaved = roundFloat(summary.TotalSaved)
+	summary.TotalRequiredMonthly = roundFloat(summary.TotalRequiredMonthly)
+	return summary
+}
+
+// fetchGoalProgress devolve as metas ativas do escopo com o progresso; falhas resultam em lista vazia,
+// já que as metas apenas enriquecem as dicas.
+func fetchGoalProgress(scope dataScope) []GoalResponse {
+	goals, err := loadGoals(scope, false)
+	if err != nil {
+		getLogger().WarnF("não foi possível carregar metas: %v", err)
+		return nil
+	}
+	return summarizeGoals(goals, time.Now()).Goals
+}
+
+func toGoalResponse(goal *schemas.Goal, now time.Time, withContributions bool) GoalResponse {
+	response := GoalResponse{
+		ID:           goal.ID.String(),
+		HouseholdID:  uuidPtrString(goal.HouseholdID),
+		CreatedBy:    goal.UserID.String(),
+		Name:         goal.Name,
+		TargetAmount: goal.TargetAmount,
+		Deadline:     goal.Deadline,
+		AccountID:    uuidPtrString(goal.AccountID),
+		Archived:     goal.Archived,
+		CreatedAt:    goal.CreatedAt,
+		UpdatedAt:    goal.UpdatedAt,
+		Progress:     goalProgress(goal, now),
+	}
+	if withContributions {
+		response.Contributions = make([]GoalContributionResponse, len(goal.Contributions))
+		for i, contribution := range goal.Contributions {
+			response.Contributions[i] = GoalContributionResponse{
+				ID:         contribution.ID.String(),
+				Amount:     contribution.Amount,
+				Date:       contribution.Date,
+				Note:       contribution.Note,
+				TransferID: uuidPtrString(contribution.TransferID),
+				CreatedAt:  contribution.CreatedAt,
+			}
+		}
+	}
+	return response
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+)
+
+func TestGoalContributionTransfers(t *testing.T) {
+	setupTestDB(t)
+	user := createTestUser(t, "ana@example.com")
+
+	checking := schemas.Account{UserID: user.ID, Name: "Corrente", Type: schemas.AccountTypeChecking, Currency: "BRL", Active: true}
+	savings := schemas.Account{UserID: user.ID, Name: "Reserva", Type: schemas.AccountTypeSavings, Currency: "BRL", Active: true}
+	for _, account := range []*schemas.Account{&checking, &savings} {
+		if err := getDB().Create(account).Error; err != nil {
+			t.Fatalf("erro criando conta: %v", err)
+		}
+	}
+	goal := schemas.Goal{UserID: user.ID, Name: "Viagem", TargetAmount: 1000, Deadline: time.Now().AddDate(1, 0, 0), AccountID: &savings.ID}
+	if err := getDB().Create(&goal).Error; err != nil {
+		t.Fatalf("erro criando meta: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		amount   float64
+		wantFrom string
+		wantTo   string
+		want     float64
+		wantDesc string
+	}{
+		{"aporte sai da conta informada", 300, checking.ID.String(), savings.ID.String(), 300, "Aporte: Viagem"},
+		{"resgate volta para a conta informada", -120.5, savings.ID.String(), checking.ID.String(), 120.5, "Resgate: Viagem"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, response := callHandler(t, CreateGoalContributionHandler, user, "POST", "/goals/"+goal.ID.String()+"/contributions",
+				map[string]interface{}{"amount": tt.amount, "date": "2024-06-10", "fromAccountId": checking.ID.String()},
+				gin.Param{Key: "id", Value: goal.ID.String()})
+			if code != 200 {
+				t.Fatalf("status = %d, resposta %v", code, response)
+			}
+
+			var contribution schemas.GoalContribution
+			if err := getDB().Where("goal_id = ?", goal.ID).Order("created_at DESC").First(&contribution).Error; err != nil {
+				t.Fatalf("erro carregando aporte: %v", err)
+			}
+			if contribution.Amount != tt.amount || contribution.TransferID == nil {
+				t.Fatalf("aporte = %+v, esperado valor %.2f com transferência", contribution, tt.amount)
+			}
+
+			var transfer schemas.Transfer
+			if err := getDB().First(&transfer, "id = ?", *contribution.TransferID).Error; err != nil {
+				t.Fatalf("erro carregando transferência: %v", err)
+			}
+			if transfer.FromAccountID.String() != tt.wantFrom || transfer.ToAccountID.String() != tt.wantTo {
+				t.Fatalf("transferência de %s para %s, esperado de %s para %s", transfer.FromAccountID, transfer.ToAccountID, tt.wantFrom, tt.wantTo)
+			}
+			if transfer.Amount != tt.want || transfer.ToAmount != tt.want || transfer.Description != tt.wantDesc {
+				t.Fatalf("transferência = %+v, esperado valor positivo %.2f e descrição %q", transfer, tt.want, tt.wantDesc)
+			}
+		})
+	}
+
+	var negative int64
+	if err := getDB().Model(&schemas.Transfer{}).Where("amount <= 0 OR to_amount <= 0").Count(&negative).Error; err != nil {
+		t.Fatalf("erro contando transferências: %v", err)
+	}
+	if negative != 0 {
+		t.Fatalf("%d transferências com valor não positivo", negative)
+	}
+}
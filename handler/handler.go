@@ -1,11 +1,18 @@
 package handler
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Pmmvito/Golang-Api-Exemple/config"
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/Pmmvito/Golang-Api-Exemple/service/mailer"
+	"github.com/Pmmvito/Golang-Api-Exemple/service/oidc"
+	"github.com/Pmmvito/Golang-Api-Exemple/service/storage"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -13,9 +20,34 @@ var (
 	logger     *config.Logger
 	db         *gorm.DB
 	sessionTTL = 24 * time.Hour
+	refreshTTL = 30 * 24 * time.Hour
+
+	sessionTouchInterval = 5 * time.Minute
+
+	mailSender               mailer.Mailer
+	appBaseURL               string
+	requireEmailVerification bool
+	mfaIssuer                = "Golang Finance"
+
+	blobStore             storage.BlobStore = storage.NewMemoryStore()
+	receiptMaxUploadBytes int64             = 10 << 20
+	receiptWorkers                          = 2
+	ocrReviewThreshold                      = 0.6
+
+	loginStore loginAttemptStore = dbLoginAttemptStore{}
+
+	oidcProviders = map[string]idTokenVerifier{}
+	adminEmails   = map[string]bool{}
+
+	accountDeletionGrace = 30 * 24 * time.Hour
+	softDeleteRetention  = 30 * 24 * time.Hour
+	purgeInterval        = time.Hour
+	recurrenceInterval   = time.Hour
 )
 
-func InitializerHandler() {
+// InitializerHandler carrega a configuração dos handlers e devolve erro quando um serviço obrigatório não pode ser
+// configurado, para que a aplicação não suba em estado inseguro.
+func InitializerHandler() error {
 	logger = config.GetLogger("handler")
 	db = config.GetDatabase()
 
@@ -28,6 +60,107 @@ func InitializerHandler() {
 			sessionTTL = time.Duration(hours) * time.Hour
 		}
 	}
+
+	if ttlStr := os.Getenv("REFRESH_TOKEN_TTL_HOURS"); ttlStr != "" {
+		if hours, err := strconv.Atoi(ttlStr); err == nil && hours > 0 {
+			refreshTTL = time.Duration(hours) * time.Hour
+		}
+	}
+
+	if intervalStr := os.Getenv("SESSION_TOUCH_INTERVAL_MINUTES"); intervalStr != "" {
+		if minutes, err := strconv.Atoi(intervalStr); err == nil && minutes >= 0 {
+			sessionTouchInterval = time.Duration(minutes) * time.Minute
+		}
+	}
+
+	var err error
+	mailSender, err = mailer.NewFromEnv()
+	if err != nil {
+		return fmt.Errorf("erro ao configurar envio de emails: %w", err)
+	}
+
+	blobStore, err = storage.NewFromEnv()
+	if err != nil {
+		return fmt.Errorf("erro ao configurar armazenamento de arquivos: %w", err)
+	}
+
+	if sizeStr := os.Getenv("RECEIPT_MAX_UPLOAD_MB"); sizeStr != "" {
+		if megabytes, err := strconv.Atoi(sizeStr); err == nil && megabytes > 0 {
+			receiptMaxUploadBytes = int64(megabytes) << 20
+		}
+	}
+
+	if workersStr := os.Getenv("RECEIPT_WORKERS"); workersStr != "" {
+		if workers, err := strconv.Atoi(workersStr); err == nil && workers > 0 {
+			receiptWorkers = workers
+		}
+	}
+
+	if thresholdStr := os.Getenv("OCR_REVIEW_THRESHOLD"); thresholdStr != "" {
+		if threshold, err := strconv.ParseFloat(thresholdStr, 64); err == nil && threshold >= 0 && threshold <= 1 {
+			ocrReviewThreshold = threshold
+		}
+	}
+
+	appBaseURL = strings.TrimRight(os.Getenv("APP_BASE_URL"), "/")
+	requireEmailVerification, _ = strconv.ParseBool(os.Getenv("REQUIRE_EMAIL_VERIFICATION"))
+
+	if issuer := strings.TrimSpace(os.Getenv("MFA_ISSUER")); issuer != "" {
+		mfaIssuer = issuer
+	}
+
+	if strings.EqualFold(os.Getenv("LOGIN_THROTTLE_STORE"), "memory") {
+		loginStore = newMemoryLoginAttemptStore()
+	}
+
+	oidcProviders = map[string]idTokenVerifier{}
+	if clientIDs := splitEnvList(os.Getenv("GOOGLE_CLIENT_IDS")); len(clientIDs) > 0 {
+		jwksURL := os.Getenv("GOOGLE_JWKS_URL")
+		if jwksURL == "" {
+			jwksURL = "https://www.googleapis.com/oauth2/v3/certs"
+		}
+		issuers := splitEnvList(os.Getenv("GOOGLE_ISSUERS"))
+		if len(issuers) == 0 {
+			issuers = []string{"https://accounts.google.com", "accounts.google.com"}
+		}
+		oidcProviders[oidcProviderGoogle] = oidc.NewVerifier(jwksURL, issuers, clientIDs)
+	}
+
+	adminEmails = map[string]bool{}
+	for _, email := range splitEnvList(os.Getenv("ADMIN_EMAILS")) {
+		adminEmails[strings.ToLower(email)] = true
+	}
+	if db != nil {
+		if err := promoteVerifiedAdmins(db); err != nil {
+			return fmt.Errorf("erro ao promover administradores de ADMIN_EMAILS: %w", err)
+		}
+	}
+
+	if daysStr := os.Getenv("ACCOUNT_DELETION_GRACE_DAYS"); daysStr != "" {
+		if days, err := strconv.Atoi(daysStr); err == nil && days >= 0 {
+			accountDeletionGrace = time.Duration(days) * 24 * time.Hour
+		}
+	}
+
+	if daysStr := os.Getenv("SOFT_DELETE_RETENTION_DAYS"); daysStr != "" {
+		if days, err := strconv.Atoi(daysStr); err == nil && days >= 0 {
+			softDeleteRetention = time.Duration(days) * 24 * time.Hour
+		}
+	}
+
+	if intervalStr := os.Getenv("PURGE_INTERVAL_MINUTES"); intervalStr != "" {
+		if minutes, err := strconv.Atoi(intervalStr); err == nil && minutes > 0 {
+			purgeInterval = time.Duration(minutes) * time.Minute
+		}
+	}
+
+	if intervalStr := os.Getenv("RECURRENCE_INTERVAL_MINUTES"); intervalStr != "" {
+		if minutes, err := strconv.Atoi(intervalStr); err == nil && minutes > 0 {
+			recurrenceInterval = time.Duration(minutes) * time.Minute
+		}
+	}
+
+	return nil
 }
 
 func getDB() *gorm.DB {
@@ -41,3 +174,105 @@ func getLogger() *config.Logger {
 func getSessionTTL() time.Duration {
 	return sessionTTL
 }
+
+func getRefreshTTL() time.Duration {
+	return refreshTTL
+}
+
+func getSessionTouchInterval() time.Duration {
+	return sessionTouchInterval
+}
+
+func getMailer() mailer.Mailer {
+	return mailSender
+}
+
+func getBlobStore() storage.BlobStore {
+	return blobStore
+}
+
+func getReceiptMaxUploadBytes() int64 {
+	return receiptMaxUploadBytes
+}
+
+func getReceiptWorkers() int {
+	return receiptWorkers
+}
+
+func getOcrReviewThreshold() float64 {
+	return ocrReviewThreshold
+}
+
+func getAppBaseURL() string {
+	return appBaseURL
+}
+
+func isEmailVerificationRequired() bool {
+	return requireEmailVerification
+}
+
+func getMFAIssuer() string {
+	return mfaIssuer
+}
+
+func getLoginAttemptStore() loginAttemptStore {
+	return loginStore
+}
+
+func getOIDCVerifier(provider string) (idTokenVerifier, bool) {
+	verifier, ok := oidcProviders[provider]
+	return verifier, ok
+}
+
+// roleForVerifiedEmail concede o papel de administrador aos emails listados em ADMIN_EMAILS. Só deve ser usado
+// quando a posse do endereço já foi comprovada, como no cadastro por um provedor OIDC.
+func roleForVerifiedEmail(email string) schemas.UserRole {
+	if adminEmails[strings.ToLower(email)] {
+		return schemas.UserRoleAdmin
+	}
+	return schemas.UserRoleUser
+}
+
+// promoteVerifiedAdmins concede o papel de administrador às contas listadas em ADMIN_EMAILS cujo email já foi
+// verificado. Sem userIDs, considera todas as contas; com eles, apenas as informadas.
+func promoteVerifiedAdmins(tx *gorm.DB, userIDs ...uuid.UUID) error {
+	if len(adminEmails) == 0 {
+		return nil
+	}
+	emails := make([]string, 0, len(adminEmails))
+	for email := range adminEmails {
+		emails = append(emails, email)
+	}
+	query := tx.Model(&schemas.User{}).
+		Where("email IN ? AND role <> ? AND email_verified_at IS NOT NULL", emails, schemas.UserRoleAdmin)
+	if len(userIDs) > 0 {
+		query = query.Where("id IN ?", userIDs)
+	}
+	return query.Updates(map[string]interface{}{"role": schemas.UserRoleAdmin}).Error
+}
+
+func splitEnvList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func getAccountDeletionGrace() time.Duration {
+	return accountDeletionGrace
+}
+
+func getSoftDeleteRetention() time.Duration {
+	return softDeleteRetention
+}
+
+func getPurgeInterval() time.Duration {
+	return purgeInterval
+}
+
+func getRecurrenceInterval() time.Duration {
+	return recurrenceInterval
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/config"
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// setupTestDB abre um banco SQLite novo, com as migrações aplicadas, e o instala como banco dos handlers
+// durante o teste.
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	t.Setenv("SQLITE_PATH", filepath.Join(t.TempDir(), "test.db"))
+	testDB, err := config.InitializeSQLite()
+	if err != nil {
+		t.Fatalf("erro abrindo banco de teste: %v", err)
+	}
+
+	previousDB, previousLogger := db, logger
+	db, logger = testDB, config.GetLogger("handler-test")
+	t.Cleanup(func() {
+		db, logger = previousDB, previousLogger
+		if sqlDB, err := testDB.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return testDB
+}
+
+func createTestUser(t *testing.T, email string) *schemas.User {
+	t.Helper()
+
+	user := schemas.User{Name: "Teste", Email: email, Active: true, Role: schemas.UserRoleUser}
+	if err := getDB().Create(&user).Error; err != nil {
+		t.Fatalf("erro criando usuário de teste: %v", err)
+	}
+	return &user
+}
+
+func timePtr(value time.Time) *time.Time {
+	return &value
+}
+
+// callHandler executa o handler como se o AuthMiddleware tivesse autenticado o usuário informado e devolve o
+// status e o corpo JSON da resposta.
+func callHandler(t *testing.T, handler gin.HandlerFunc, user *schemas.User, method, target string, body interface{}, params ...gin.Param) (int, map[string]interface{}) {
+	t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			t.Fatalf("erro codificando corpo: %v", err)
+		}
+	}
+
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(method, target, &payload)
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Params = params
+	if user != nil {
+		ctx.Set(contextUserKey, user)
+	}
+	handler(ctx)
+
+	// Respostas que não são JSON (como imagens) ficam com o mapa vazio.
+	response := map[string]interface{}{}
+	json.Unmarshal(recorder.Body.Bytes(), &response)
+	return recorder.Code, response
+}
@@ -0,0 +1,853 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	householdHeader        = "X-Household-ID"
+	householdInvitationTTL = 7 * 24 * time.Hour
+)
+
+var (
+	errHouseholdReadOnly      = errors.New("seu papel no grupo familiar permite apenas leitura")
+	errHouseholdNotMember     = errors.New("grupo familiar não encontrado")
+	errHouseholdOwnerRequired = errors.New("apenas proprietários podem gerenciar o grupo familiar")
+	errHouseholdLastOwner     = errors.New("o grupo precisa de outro proprietário: promova um membro ou exclua o grupo")
+	errHouseholdAlreadyMember = errors.New("este email já participa do grupo familiar")
+	errInvitationOtherEmail   = errors.New("o convite foi enviado para outro email")
+)
+
+// dataScope indica de quem são as categorias e despesas acessadas na requisição: do próprio
+// usuário ou do grupo familiar ativo, escolhido pelo cabeçalho X-Household-ID.
+type dataScope struct {
+	UserID      uuid.UUID
+	HouseholdID *uuid.UUID
+	Role        schemas.HouseholdRole
+}
+
+func personalScope(userID uuid.UUID) dataScope {
+	return dataScope{UserID: userID, Role: schemas.HouseholdRoleOwner}
+}
+
+// apply filtra a tabela informada (categories ou expenses) pelo escopo.
+func (s dataScope) apply(db *gorm.DB, table string) *gorm.DB {
+	if s.HouseholdID != nil {
+		return db.Where(table+".household_id = ?", *s.HouseholdID)
+	}
+	return db.Where(table+".user_id = ? AND "+table+".household_id IS NULL", s.UserID)
+}
+
+func (s dataScope) canWrite() bool {
+	return s.Role == schemas.HouseholdRoleOwner || s.Role == schemas.HouseholdRoleEditor
+}
+
+// HouseholdContext define o escopo de dados a partir do cabeçalho X-Household-ID; sem ele,
+// as rotas trabalham com os dados pessoais do usuário. Deve ser usado depois de AuthMiddleware.
+func HouseholdContext() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		user, err := getAuthenticatedUser(ctx)
+		if err != nil {
+			respondError(ctx, 401, "não autenticado", nil)
+			return
+		}
+
+		header := ctx.GetHeader(householdHeader)
+		if header == "" {
+			setDataScope(ctx, personalScope(user.ID))
+			ctx.Next()
+			return
+		}
+
+		householdID, err := uuid.Parse(header)
+		if err != nil {
+			respondError(ctx, 400, householdHeader+" inválido", nil)
+			return
+		}
+
+		member, err := findHouseholdMember(getDB(), householdID, user.ID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				respondError(ctx, 403, "você não participa deste grupo familiar", nil)
+				return
+			}
+			respondError(ctx, 500, "erro ao validar grupo familiar", err.Error())
+			return
+		}
+
+		setDataScope(ctx, dataScope{UserID: user.ID, HouseholdID: &householdID, Role: member.Role})
+		ctx.Next()
+	}
+}
+
+// writableDataScope devolve o escopo da requisição, respondendo 403 quando o papel é somente leitura.
+func writableDataScope(ctx *gin.Context, user *schemas.User) (dataScope, bool) {
+	scope := getDataScope(ctx, user)
+	if !scope.canWrite() {
+		respondError(ctx, 403, errHouseholdReadOnly.Error(), nil)
+		return scope, false
+	}
+	return scope, true
+}
+
+// ListHouseholdsHandler godoc
+// @Summary Listar grupos familiares
+// @Description Lista os grupos familiares dos quais o usuário participa, com o papel dele em cada um
+// @Tags Grupos familiares
+// @Security Bearer
+// @Produce json
+// @Success 200 {object} HouseholdListSuccess
+// @Failure 401 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /households [get]
+func ListHouseholdsHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	var memberships []schemas.HouseholdMember
+	if err := getDB().Preload("Household").
+		Where("user_id = ?", user.ID).
+		Order("created_at ASC").
+		Find(&memberships).Error; err != nil {
+		respondError(ctx, 500, "erro ao listar grupos familiares", err.Error())
+		return
+	}
+
+	responses := make([]HouseholdResponse, 0, len(memberships))
+	for i := range memberships {
+		if memberships[i].Household == nil {
+			continue
+		}
+		responses = append(responses, toHouseholdResponse(memberships[i].Household, memberships[i].Role, nil))
+	}
+
+	respondSuccess(ctx, "grupos familiares", responses)
+}
+
+// CreateHouseholdHandler godoc
+// @Summary Criar grupo familiar
+// @Description Cria um grupo familiar com o usuário como proprietário e as categorias padrão. Para usar os dados do grupo, envie o cabeçalho X-Household-ID nas rotas de categorias, despesas e dashboard.
+// @Tags Grupos familiares
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body HouseholdRequest true "Nome do grupo"
+// @Success 200 {object} HouseholdSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /households [post]
+func CreateHouseholdHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	var request HouseholdRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	household := schemas.Household{Name: request.Name, CreatedByID: user.ID}
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&household).Error; err != nil {
+			return err
+		}
+		member := schemas.HouseholdMember{
+			HouseholdID: household.ID,
+			UserID:      user.ID,
+			Role:        schemas.HouseholdRoleOwner,
+		}
+		if err := tx.Create(&member).Error; err != nil {
+			return err
+		}
+		return seedDefaultCategories(tx, user.ID, &household.ID)
+	})
+	if err != nil {
+		respondError(ctx, 500, "erro ao criar grupo familiar", err.Error())
+		return
+	}
+
+	respondHousehold(ctx, "grupo familiar criado", &household, schemas.HouseholdRoleOwner)
+}
+
+// GetHouseholdHandler godoc
+// @Summary Detalhar grupo familiar
+// @Description Retorna o grupo familiar com seus membros e papéis
+// @Tags Grupos familiares
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID do grupo familiar"
+// @Success 200 {object} HouseholdSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /households/{id} [get]
+func GetHouseholdHandler(ctx *gin.Context) {
+	member, ok := loadHouseholdMembership(ctx, false)
+	if !ok {
+		return
+	}
+
+	respondHousehold(ctx, "grupo familiar", member.Household, member.Role)
+}
+
+// UpdateHouseholdHandler godoc
+// @Summary Renomear grupo familiar
+// @Description Altera o nome do grupo familiar. Apenas proprietários.
+// @Tags Grupos familiares
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do grupo familiar"
+// @Param body body HouseholdRequest true "Novo nome"
+// @Success 200 {object} HouseholdSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /households/{id} [patch]
+func UpdateHouseholdHandler(ctx *gin.Context) {
+	member, ok := loadHouseholdMembership(ctx, true)
+	if !ok {
+		return
+	}
+
+	var request HouseholdRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	if err := getDB().Model(member.Household).Updates(map[string]interface{}{"name": request.Name}).Error; err != nil {
+		respondError(ctx, 500, "erro ao atualizar grupo familiar", err.Error())
+		return
+	}
+	member.Household.Name = request.Name
+
+	respondHousehold(ctx, "grupo familiar atualizado", member.Household, member.Role)
+}
+
+// DeleteHouseholdHandler godoc
+// @Summary Excluir grupo familiar
+// @Description Exclui o grupo familiar, suas categorias e despesas, membros e convites. Apenas proprietários.
+// @Tags Grupos familiares
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID do grupo familiar"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /households/{id} [delete]
+func DeleteHouseholdHandler(ctx *gin.Context) {
+	member, ok := loadHouseholdMembership(ctx, true)
+	if !ok {
+		return
+	}
+
+	err := getDB().Transaction(func(tx *gorm.DB) error {
+		return deleteHousehold(tx, member.HouseholdID)
+	})
+	if err != nil {
+		respondError(ctx, 500, "erro ao excluir grupo familiar", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "grupo familiar excluído", nil)
+}
+
+// CreateHouseholdInvitationHandler godoc
+// @Summary Convidar para o grupo familiar
+// @Description Envia por email um convite para participar do grupo com o papel editor (padrão) ou viewer. Convites anteriores para o mesmo email deixam de valer. Apenas proprietários.
+// @Tags Grupos familiares
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do grupo familiar"
+// @Param body body HouseholdInvitationRequest true "Email e papel"
+// @Success 200 {object} HouseholdInvitationSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /households/{id}/invitations [post]
+func CreateHouseholdInvitationHandler(ctx *gin.Context) {
+	member, ok := loadHouseholdMembership(ctx, true)
+	if !ok {
+		return
+	}
+
+	var request HouseholdInvitationRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	rawToken, err := generateSessionToken()
+	if err != nil {
+		respondError(ctx, 500, "erro ao gerar convite", err.Error())
+		return
+	}
+
+	invitation := schemas.HouseholdInvitation{
+		HouseholdID: member.HouseholdID,
+		Email:       request.Email,
+		Role:        schemas.HouseholdRole(request.Role),
+		TokenHash:   schemas.HashToken(rawToken),
+		InvitedByID: member.UserID,
+		ExpiresAt:   time.Now().Add(householdInvitationTTL),
+	}
+
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&schemas.HouseholdMember{}).
+			Joins("JOIN users ON users.id = household_members.user_id").
+			Where("household_members.household_id = ? AND users.email = ?", member.HouseholdID, request.Email).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return errHouseholdAlreadyMember
+		}
+
+		if err := tx.Model(&schemas.HouseholdInvitation{}).
+			Where("household_id = ? AND email = ? AND accepted_at IS NULL AND revoked_at IS NULL", member.HouseholdID, request.Email).
+			Updates(map[string]interface{}{"revoked_at": time.Now()}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&invitation).Error
+	})
+	if err != nil {
+		if errors.Is(err, errHouseholdAlreadyMember) {
+			respondError(ctx, 409, err.Error(), nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao criar convite", err.Error())
+		return
+	}
+
+	if err := sendHouseholdInvitationEmail(ctx.Request.Context(), member, request.Email, rawToken); err != nil {
+		getLogger().WarnF("não foi possível enviar convite do grupo familiar: %v", err)
+	}
+
+	respondSuccess(ctx, "convite enviado", toHouseholdInvitationResponse(&invitation))
+}
+
+// ListHouseholdInvitationsHandler godoc
+// @Summary Listar convites pendentes
+// @Description Lista os convites do grupo familiar que ainda não foram aceitos, revogados ou expiraram. Apenas proprietários.
+// @Tags Grupos familiares
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID do grupo familiar"
+// @Success 200 {object} HouseholdInvitationListSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /households/{id}/invitations [get]
+func ListHouseholdInvitationsHandler(ctx *gin.Context) {
+	member, ok := loadHouseholdMembership(ctx, true)
+	if !ok {
+		return
+	}
+
+	var invitations []schemas.HouseholdInvitation
+	if err := getDB().
+		Where("household_id = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", member.HouseholdID, time.Now()).
+		Order("created_at DESC").
+		Find(&invitations).Error; err != nil {
+		respondError(ctx, 500, "erro ao listar convites", err.Error())
+		return
+	}
+
+	responses := make([]HouseholdInvitationResponse, len(invitations))
+	for i := range invitations {
+		responses[i] = toHouseholdInvitationResponse(&invitations[i])
+	}
+
+	respondSuccess(ctx, "convites pendentes", responses)
+}
+
+// RevokeHouseholdInvitationHandler godoc
+// @Summary Revogar convite
+// @Description Cancela um convite pendente do grupo familiar. Apenas proprietários.
+// @Tags Grupos familiares
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID do grupo familiar"
+// @Param invitationId path string true "ID do convite"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /households/{id}/invitations/{invitationId} [delete]
+func RevokeHouseholdInvitationHandler(ctx *gin.Context) {
+	member, ok := loadHouseholdMembership(ctx, true)
+	if !ok {
+		return
+	}
+
+	invitationID, err := parseUUIDParam(ctx.Param("invitationId"))
+	if err != nil {
+		respondError(ctx, 400, "id do convite inválido", nil)
+		return
+	}
+
+	result := getDB().Model(&schemas.HouseholdInvitation{}).
+		Where("id = ? AND household_id = ? AND accepted_at IS NULL AND revoked_at IS NULL", invitationID, member.HouseholdID).
+		Updates(map[string]interface{}{"revoked_at": time.Now()})
+	if result.Error != nil {
+		respondError(ctx, 500, "erro ao revogar convite", result.Error.Error())
+		return
+	}
+	if result.RowsAffected == 0 {
+		respondError(ctx, 404, "convite não encontrado", nil)
+		return
+	}
+
+	respondSuccess(ctx, "convite revogado", nil)
+}
+
+// AcceptHouseholdInvitationHandler godoc
+// @Summary Aceitar convite
+// @Description Aceita o convite recebido por email e passa a participar do grupo familiar. O convite só pode ser aceito pela conta com o email convidado.
+// @Tags Grupos familiares
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body AcceptHouseholdInvitationRequest true "Token do convite"
+// @Success 200 {object} HouseholdSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /households/invitations/accept [post]
+func AcceptHouseholdInvitationHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	var request AcceptHouseholdInvitationRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	var member schemas.HouseholdMember
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		invitation := schemas.HouseholdInvitation{}
+		if err := tx.Where("token_hash = ?", schemas.HashToken(request.Token)).First(&invitation).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errUserTokenInvalid
+			}
+			return err
+		}
+		if invitation.AcceptedAt != nil || invitation.RevokedAt != nil || invitation.ExpiresAt.Before(time.Now()) {
+			return errUserTokenInvalid
+		}
+		if !strings.EqualFold(invitation.Email, user.Email) {
+			return errInvitationOtherEmail
+		}
+
+		result := tx.Model(&schemas.HouseholdInvitation{}).
+			Where("id = ? AND accepted_at IS NULL", invitation.ID).
+			Updates(map[string]interface{}{"accepted_at": time.Now()})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errUserTokenInvalid
+		}
+
+		existing, err := findHouseholdMember(tx, invitation.HouseholdID, user.ID)
+		if err == nil {
+			member = *existing
+			return nil
+		}
+		if err != gorm.ErrRecordNotFound {
+			return err
+		}
+
+		member = schemas.HouseholdMember{
+			HouseholdID: invitation.HouseholdID,
+			UserID:      user.ID,
+			Role:        invitation.Role,
+		}
+		if err := tx.Create(&member).Error; err != nil {
+			return err
+		}
+		return tx.Preload("Household").First(&member, "id = ?", member.ID).Error
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, errUserTokenInvalid):
+			respondError(ctx, 400, err.Error(), nil)
+		case errors.Is(err, errInvitationOtherEmail):
+			respondError(ctx, 403, err.Error(), nil)
+		default:
+			respondError(ctx, 500, "erro ao aceitar convite", err.Error())
+		}
+		return
+	}
+
+	respondHousehold(ctx, "convite aceito", member.Household, member.Role)
+}
+
+// UpdateHouseholdMemberHandler godoc
+// @Summary Alterar papel de membro
+// @Description Define o papel (owner, editor ou viewer) de outro membro do grupo familiar. Apenas proprietários.
+// @Tags Grupos familiares
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do grupo familiar"
+// @Param userId path string true "ID do usuário membro"
+// @Param body body UpdateHouseholdMemberRequest true "Novo papel"
+// @Success 200 {object} HouseholdSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /households/{id}/members/{userId} [put]
+func UpdateHouseholdMemberHandler(ctx *gin.Context) {
+	member, ok := loadHouseholdMembership(ctx, true)
+	if !ok {
+		return
+	}
+
+	targetID, err := parseUUIDParam(ctx.Param("userId"))
+	if err != nil {
+		respondError(ctx, 400, "id do membro inválido", nil)
+		return
+	}
+
+	var request UpdateHouseholdMemberRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	if targetID == member.UserID {
+		respondError(ctx, 409, "não é possível alterar o próprio papel", nil)
+		return
+	}
+
+	result := getDB().Model(&schemas.HouseholdMember{}).
+		Where("household_id = ? AND user_id = ?", member.HouseholdID, targetID).
+		Updates(map[string]interface{}{"role": request.Role})
+	if result.Error != nil {
+		respondError(ctx, 500, "erro ao alterar papel", result.Error.Error())
+		return
+	}
+	if result.RowsAffected == 0 {
+		respondError(ctx, 404, "membro não encontrado", nil)
+		return
+	}
+
+	respondHousehold(ctx, "papel atualizado", member.Household, member.Role)
+}
+
+// RemoveHouseholdMemberHandler godoc
+// @Summary Remover membro
+// @Description Remove um membro do grupo familiar. Proprietários removem qualquer membro; os demais podem remover apenas a si mesmos para sair do grupo. O último proprietário não pode sair.
+// @Tags Grupos familiares
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID do grupo familiar"
+// @Param userId path string true "ID do usuário membro"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /households/{id}/members/{userId} [delete]
+func RemoveHouseholdMemberHandler(ctx *gin.Context) {
+	member, ok := loadHouseholdMembership(ctx, false)
+	if !ok {
+		return
+	}
+
+	targetID, err := parseUUIDParam(ctx.Param("userId"))
+	if err != nil {
+		respondError(ctx, 400, "id do membro inválido", nil)
+		return
+	}
+
+	if targetID != member.UserID && member.Role != schemas.HouseholdRoleOwner {
+		respondError(ctx, 403, errHouseholdOwnerRequired.Error(), nil)
+		return
+	}
+
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		target, err := findHouseholdMember(tx, member.HouseholdID, targetID)
+		if err != nil {
+			return err
+		}
+		if target.Role == schemas.HouseholdRoleOwner {
+			owners, err := countHouseholdOwners(tx, member.HouseholdID)
+			if err != nil {
+				return err
+			}
+			if owners <= 1 {
+				return errHouseholdLastOwner
+			}
+		}
+		return tx.Unscoped().Delete(target).Error
+	})
+	if err != nil {
+		switch {
+		case err == gorm.ErrRecordNotFound:
+			respondError(ctx, 404, "membro não encontrado", nil)
+		case errors.Is(err, errHouseholdLastOwner):
+			respondError(ctx, 409, err.Error(), nil)
+		default:
+			respondError(ctx, 500, "erro ao remover membro", err.Error())
+		}
+		return
+	}
+
+	message := "membro removido"
+	if targetID == member.UserID {
+		message = "você saiu do grupo familiar"
+	}
+	respondSuccess(ctx, message, nil)
+}
+
+// loadHouseholdMembership carrega o vínculo do usuário com o grupo do parâmetro :id. Quem não
+// participa recebe 404, para não revelar a existência do grupo.
+func loadHouseholdMembership(ctx *gin.Context, ownerOnly bool) (*schemas.HouseholdMember, bool) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return nil, false
+	}
+
+	householdID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return nil, false
+	}
+
+	member, err := findHouseholdMember(getDB(), householdID, user.ID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, errHouseholdNotMember.Error(), nil)
+			return nil, false
+		}
+		respondError(ctx, 500, "erro ao carregar grupo familiar", err.Error())
+		return nil, false
+	}
+
+	if ownerOnly && member.Role != schemas.HouseholdRoleOwner {
+		respondError(ctx, 403, errHouseholdOwnerRequired.Error(), nil)
+		return nil, false
+	}
+	return member, true
+}
+
+func findHouseholdMember(db *gorm.DB, householdID, userID uuid.UUID) (*schemas.HouseholdMember, error) {
+	member := schemas.HouseholdMember{}
+	if err := db.Preload("Household").
+		Where("household_id = ? AND user_id = ?", householdID, userID).
+		First(&member).Error; err != nil {
+		return nil, err
+	}
+	if member.Household == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &member, nil
+}
+
+func countHouseholdOwners(tx *gorm.DB, householdID uuid.UUID) (int64, error) {
+	var count int64
+	err := tx.Model(&schemas.HouseholdMember{}).
+		Where("household_id = ? AND role = ?", householdID, schemas.HouseholdRoleOwner).
+		Count(&count).Error
+	return count, err
+}
+
+func respondHousehold(ctx *gin.Context, message string, household *schemas.Household, role schemas.HouseholdRole) {
+	var members []schemas.HouseholdMember
+	if err := getDB().Preload("User").
+		Where("household_id = ?", household.ID).
+		Order("created_at ASC").
+		Find(&members).Error; err != nil {
+		respondError(ctx, 500, "erro ao listar membros", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, message, toHouseholdResponse(household, role, members))
+}
+
+func toHouseholdResponse(household *schemas.Household, role schemas.HouseholdRole, members []schemas.HouseholdMember) HouseholdResponse {
+	response := HouseholdResponse{
+		ID:        household.ID.String(),
+		Name:      household.Name,
+		Role:      string(role),
+		CreatedAt: household.CreatedAt,
+	}
+	for i := range members {
+		response.Members = append(response.Members, toHouseholdMemberResponse(&members[i]))
+	}
+	return response
+}
+
+// deleteHousehold exclui logicamente recorrências, categorias, orçamentos, metas, despesas, rascunhos de recibo,
+// estabelecimentos, regras de categoria, compras parceladas, receitas, contas e transferências do grupo
+// (removidas de vez pelo expurgo) e apaga membros e convites.
+func deleteHousehold(tx *gorm.DB, householdID uuid.UUID) error {
+	if err := tx.Where("household_id = ?", householdID).Delete(&schemas.RecurrenceRule{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("household_id = ?", householdID).Delete(&schemas.Expense{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("household_id = ?", householdID).Delete(&schemas.ReceiptDraft{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("household_id = ?", householdID).Delete(&schemas.CategoryRule{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("household_id = ?", householdID).Delete(&schemas.Merchant{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("household_id = ?", householdID).Delete(&schemas.Goal{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("household_id = ?", householdID).Delete(&schemas.BudgetAlert{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("household_id = ?", householdID).Delete(&schemas.Budget{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("household_id = ?", householdID).Delete(&schemas.InstallmentPurchase{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("household_id = ?", householdID).Delete(&schemas.Income{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("household_id = ?", householdID).Delete(&schemas.Transfer{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("household_id = ?", householdID).Delete(&schemas.Account{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("household_id = ?", householdID).Delete(&schemas.Category{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("household_id = ?", householdID).Delete(&schemas.HouseholdInvitation{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("household_id = ?", householdID).Delete(&schemas.HouseholdMember{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("id = ?", householdID).Delete(&schemas.Household{}).Error
+}
+
+// leaveHouseholds desliga o usuário dos grupos antes da exclusão da conta. Grupos sem outros membros
+// são excluídos; nos demais, a posse passa ao membro mais antigo quando necessário e as categorias,
+// despesas, receitas, contas, transferências e recorrências criadas pelo usuário ficam com um proprietário, preservando os dados compartilhados.
+func leaveHouseholds(tx *gorm.DB, userID uuid.UUID) error {
+	var memberships []schemas.HouseholdMember
+	if err := tx.Where("user_id = ?", userID).Find(&memberships).Error; err != nil {
+		return err
+	}
+
+	for _, membership := range memberships {
+		var others []schemas.HouseholdMember
+		if err := tx.Where("household_id = ? AND user_id <> ?", membership.HouseholdID, userID).
+			Order("created_at ASC").
+			Find(&others).Error; err != nil {
+			return err
+		}
+
+		if len(others) == 0 {
+			if err := deleteHousehold(tx, membership.HouseholdID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		heir := others[0]
+		for _, other := range others {
+			if other.Role == schemas.HouseholdRoleOwner {
+				heir = other
+				break
+			}
+		}
+		if heir.Role != schemas.HouseholdRoleOwner {
+			if err := tx.Model(&heir).Updates(map[string]interface{}{"role": schemas.HouseholdRoleOwner}).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, model := range []interface{}{&schemas.Expense{}, &schemas.ReceiptDraft{}, &schemas.Merchant{}, &schemas.CategoryRule{}, &schemas.Income{}, &schemas.Account{}, &schemas.Transfer{}, &schemas.InstallmentPurchase{}, &schemas.Budget{}, &schemas.BudgetAlert{}, &schemas.Goal{}, &schemas.Category{}, &schemas.RecurrenceRule{}} {
+			if err := tx.Unscoped().Model(model).
+				Where("household_id = ? AND user_id = ?", membership.HouseholdID, userID).
+				Updates(map[string]interface{}{"user_id": heir.UserID}).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Unscoped().Delete(&membership).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sendHouseholdInvitationEmail(ctx context.Context, inviter *schemas.HouseholdMember, email, rawToken string) error {
+	inviterName := "Um usuário"
+	if user, err := reloadUser(inviter.UserID); err == nil {
+		inviterName = user.Name
+	}
+	body := fmt.Sprintf("Olá!\n\n%s convidou você para o grupo familiar \"%s\", onde as categorias e despesas são compartilhadas.\n\nPara aceitar, entre no app com este email e use o link abaixo:\n%s\n\nO convite expira em %d dias.\n",
+		inviterName, inviter.Household.Name, buildAppLink("/households/accept", rawToken), int(householdInvitationTTL.Hours()/24))
+	return sendMail(ctx, email, "Convite para grupo familiar", body)
+}
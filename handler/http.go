@@ -8,6 +8,7 @@ import (
 
 type APIError struct {
 	Message string      `json:"message"`
+	Code    string      `json:"code,omitempty"`
 	Details interface{} `json:"details,omitempty"`
 }
 
@@ -21,6 +22,11 @@ func respondError(ctx *gin.Context, status int, message string, details interfac
 	ctx.AbortWithStatusJSON(status, APIError{Message: message, Details: details})
 }
 
+func respondErrorCode(ctx *gin.Context, status int, code string, message string, details interface{}) {
+	ctx.Header("Content-Type", "application/json")
+	ctx.AbortWithStatusJSON(status, APIError{Message: message, Code: code, Details: details})
+}
+
 func respondSuccess(ctx *gin.Context, message string, data interface{}) {
 	ctx.Header("Content-Type", "application/json")
 	ctx.JSON(http.StatusOK, APISuccess{Message: message, Data: data})
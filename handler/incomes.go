@@ -0,0 +1,376 @@
+package handler
+
+import (
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CreateIncomeHandler godoc
+// @Summary Criar receita
+// @Description Registra uma nova receita (salário, freelance, reembolso...) usando uma categoria do tipo receita
+// @Tags Receitas
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body IncomeRequest true "Dados da receita"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} IncomeItemSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /incomes [post]
+func CreateIncomeHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	var request IncomeRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	categoryID, err := uuid.Parse(request.CategoryID)
+	if err != nil {
+		respondError(ctx, 400, "categoryId inválido", nil)
+		return
+	}
+
+	if !categoryInScope(getDB(), scope, categoryID, schemas.CategoryKindIncome) {
+		respondError(ctx, 403, "categoria de receita não pertence ao usuário", nil)
+		return
+	}
+
+	accountID, ok := parseRequestAccount(ctx, getDB(), scope, request.AccountID)
+	if !ok {
+		return
+	}
+
+	date, err := parseDate(request.Date)
+	if err != nil {
+		respondError(ctx, 400, "data inválida", err.Error())
+		return
+	}
+
+	income := schemas.Income{
+		UserID:      user.ID,
+		HouseholdID: scope.HouseholdID,
+		CategoryID:  categoryID,
+		Description: request.Description,
+		Amount:      request.Amount,
+		Date:        date,
+		Recurring:   request.Recurring,
+		AccountID:   accountID,
+	}
+	if err := getDB().Create(&income).Error; err != nil {
+		respondError(ctx, 500, "erro ao criar receita", err.Error())
+		return
+	}
+
+	if err := getDB().Preload("Category").First(&income, "id = ?", income.ID).Error; err != nil {
+		respondError(ctx, 500, "erro ao carregar receita criada", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "receita criada", toIncomeResponse(&income))
+}
+
+// ListIncomesHandler godoc
+// @Summary Listar receitas
+// @Description Lista as receitas do período, da mais recente para a mais antiga. Sem from/to, o período é o mês informado (ou o atual). O resumo considera todas as receitas filtradas.
+// @Tags Receitas
+// @Security Bearer
+// @Produce json
+// @Param month query int false "Mês (1-12), ignorado quando from/to são informados"
+// @Param year query int false "Ano, ignorado quando from/to são informados"
+// @Param from query string false "Data inicial (inclusiva), ex.: 2024-01-01"
+// @Param to query string false "Data final (inclusiva), ex.: 2024-03-31"
+// @Param categoryId query []string false "Filtro por categoria; repita o parâmetro ou separe por vírgula para várias" collectionFormat(multi)
+// @Param accountId query string false "Filtro por conta"
+// @Param limit query int false "Quantidade máxima de receitas (padrão 50, máximo 200)"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} IncomeListSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /incomes [get]
+func ListIncomesHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	filter, err := buildExpenseFilter(ctx)
+	if err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	scope := getDataScope(ctx, user)
+
+	var totals struct {
+		TotalCount  int64   `gorm:"column:total_count"`
+		TotalAmount float64 `gorm:"column:total_amount"`
+	}
+	if err := applyIncomeFilter(getDB().Model(&schemas.Income{}), scope, filter).
+		Select("COUNT(*) AS total_count, COALESCE(SUM(incomes.amount),0) AS total_amount").
+		Scan(&totals).Error; err != nil {
+		respondError(ctx, 500, "erro ao resumir receitas", err.Error())
+		return
+	}
+
+	var incomes []schemas.Income
+	if err := applyIncomeFilter(getDB().Preload("Category"), scope, filter).
+		Order("incomes.date DESC").
+		Order("incomes.id DESC").
+		Limit(filter.Limit).
+		Find(&incomes).Error; err != nil {
+		respondError(ctx, 500, "erro ao listar receitas", err.Error())
+		return
+	}
+
+	responses := make([]IncomeResponse, len(incomes))
+	for i := range incomes {
+		responses[i] = *toIncomeResponse(&incomes[i])
+	}
+
+	summary := IncomeSummary{TotalCount: int(totals.TotalCount), TotalAmount: roundFloat(totals.TotalAmount)}
+	respondSuccess(ctx, "receitas", IncomesListResponse{Incomes: responses, Summary: summary})
+}
+
+// GetIncomeHandler godoc
+// @Summary Buscar receita
+// @Description Retorna os detalhes de uma receita específica
+// @Tags Receitas
+// @Security Bearer
+// @Produce json
+// @Param id path string true "Identificador da receita"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} IncomeItemSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Router /incomes/{id} [get]
+func GetIncomeHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	incomeID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	income := schemas.Income{}
+	if err := getDataScope(ctx, user).apply(getDB().Preload("Category"), "incomes").
+		Where("id = ?", incomeID).
+		First(&income).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "receita não encontrada", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao carregar receita", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "receita", toIncomeResponse(&income))
+}
+
+// UpdateIncomeHandler godoc
+// @Summary Atualizar receita
+// @Description Atualiza campos de uma receita existente
+// @Tags Receitas
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "Identificador da receita"
+// @Param body body UpdateIncomeRequest true "Campos para atualização"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} IncomeItemSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Router /incomes/{id} [put]
+func UpdateIncomeHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	incomeID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	var request UpdateIncomeRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		income := schemas.Income{}
+		if err := scope.apply(tx, "incomes").Where("id = ?", incomeID).First(&income).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{}
+		if request.CategoryID != nil {
+			categoryUUID, err := uuid.Parse(*request.CategoryID)
+			if err != nil {
+				return err
+			}
+			if !categoryInScope(tx, scope, categoryUUID, schemas.CategoryKindIncome) {
+				return gorm.ErrInvalidData
+			}
+			updates["category_id"] = categoryUUID
+		}
+		if request.Description != nil {
+			updates["description"] = *request.Description
+		}
+		if request.Amount != nil {
+			updates["amount"] = *request.Amount
+		}
+		if request.Date != nil {
+			parsedDate, err := parseDate(*request.Date)
+			if err != nil {
+				return err
+			}
+			updates["date"] = parsedDate
+		}
+		if request.Recurring != nil {
+			updates["recurring"] = *request.Recurring
+		}
+		if request.RemoveAccount {
+			updates["account_id"] = nil
+		} else if request.AccountID != nil {
+			accountUUID, err := uuid.Parse(*request.AccountID)
+			if err != nil {
+				return err
+			}
+			if !accountInScope(tx, scope, accountUUID) {
+				return errAccountScope
+			}
+			updates["account_id"] = accountUUID
+		}
+
+		return tx.Model(&income).Updates(updates).Error
+	})
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "receita não encontrada", nil)
+			return
+		}
+		if err == gorm.ErrInvalidData {
+			respondError(ctx, 403, "categoria de receita não pertence ao usuário", nil)
+			return
+		}
+		if err == errAccountScope {
+			respondError(ctx, 403, err.Error(), nil)
+			return
+		}
+		respondError(ctx, 400, "erro ao atualizar receita", err.Error())
+		return
+	}
+
+	updated := schemas.Income{}
+	if err := getDB().Preload("Category").Where("id = ?", incomeID).First(&updated).Error; err != nil {
+		respondError(ctx, 500, "erro ao recarregar receita", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "receita atualizada", toIncomeResponse(&updated))
+}
+
+// DeleteIncomeHandler godoc
+// @Summary Remover receita
+// @Description Exclui uma receita
+// @Tags Receitas
+// @Security Bearer
+// @Param id path string true "Identificador da receita"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Router /incomes/{id} [delete]
+func DeleteIncomeHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	incomeID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	result := scope.apply(getDB(), "incomes").Where("id = ?", incomeID).Delete(&schemas.Income{})
+	if result.Error != nil {
+		respondError(ctx, 500, "erro ao remover receita", result.Error.Error())
+		return
+	}
+	if result.RowsAffected == 0 {
+		respondError(ctx, 404, "receita não encontrada", nil)
+		return
+	}
+
+	respondSuccess(ctx, "receita removida", nil)
+}
+
+// applyIncomeFilter aplica o escopo, o período, as categorias e a conta do filtro à consulta de receitas.
+func applyIncomeFilter(db *gorm.DB, scope dataScope, filter ExpenseFilter) *gorm.DB {
+	query := scope.apply(db, "incomes")
+	if !filter.From.IsZero() {
+		query = query.Where("incomes.date >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("incomes.date < ?", filter.To)
+	}
+	if len(filter.CategoryIDs) > 0 {
+		query = query.Where("incomes.category_id IN ?", filter.CategoryIDs)
+	}
+	if filter.AccountID != nil {
+		query = query.Where("incomes.account_id = ?", *filter.AccountID)
+	}
+	return query
+}
@@ -0,0 +1,473 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxInstallments         = 48
+	defaultStatementsMonths = 3
+	maxStatementsMonths     = 24
+)
+
+var errNotCreditCard = errors.New("a conta informada não é um cartão de crédito")
+
+// CreateInstallmentPurchaseHandler godoc
+// @Summary Criar compra parcelada
+// @Description Registra uma compra parcelada no cartão de crédito, gerando uma despesa por parcela. Cada parcela é datada um mês após a anterior e cai na fatura seguinte; a primeira parcela absorve a diferença de centavos da divisão.
+// @Tags Parcelamentos
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body InstallmentPurchaseRequest true "Dados da compra parcelada"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} InstallmentPurchaseSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /installments [post]
+func CreateInstallmentPurchaseHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	var request InstallmentPurchaseRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	categoryID, err := uuid.Parse(request.CategoryID)
+	if err != nil {
+		respondError(ctx, 400, "categoryId inválido", nil)
+		return
+	}
+	if !categoryInScope(getDB(), scope, categoryID, schemas.CategoryKindExpense) {
+		respondError(ctx, 403, "categoria não pertence ao usuário", nil)
+		return
+	}
+
+	accountID, ok := parseRequestAccount(ctx, getDB(), scope, request.AccountID)
+	if !ok {
+		return
+	}
+	account := schemas.Account{}
+	if err := getDB().First(&account, "id = ?", *accountID).Error; err != nil {
+		respondError(ctx, 500, "erro ao carregar conta", err.Error())
+		return
+	}
+	if account.Type != schemas.AccountTypeCreditCard {
+		respondError(ctx, 400, errNotCreditCard.Error(), nil)
+		return
+	}
+
+	purchaseDate, err := parseDate(request.PurchaseDate)
+	if err != nil {
+		respondError(ctx, 400, "purchaseDate inválida", err.Error())
+		return
+	}
+
+	purchase := schemas.InstallmentPurchase{
+		UserID:       user.ID,
+		HouseholdID:  scope.HouseholdID,
+		AccountID:    account.ID,
+		CategoryID:   categoryID,
+		Description:  request.Description,
+		TotalAmount:  request.TotalAmount,
+		Installments: request.Installments,
+		PurchaseDate: purchaseDate,
+	}
+	var expenses []schemas.Expense
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&purchase).Error; err != nil {
+			return err
+		}
+		for i, amount := range splitInstallments(purchase.TotalAmount, purchase.Installments) {
+			number := i + 1
+			expenses = append(expenses, schemas.Expense{
+				UserID:                user.ID,
+				HouseholdID:           scope.HouseholdID,
+				CategoryID:            categoryID,
+				Description:           purchase.Description,
+				Amount:                amount,
+				Date:                  installmentDate(purchaseDate, number),
+				Origin:                schemas.ExpenseOriginManual,
+				AccountID:             &account.ID,
+				InstallmentPurchaseID: &purchase.ID,
+				InstallmentNumber:     &number,
+			})
+		}
+		return tx.Create(&expenses).Error
+	})
+	if err != nil {
+		respondError(ctx, 500, "erro ao criar compra parcelada", err.Error())
+		return
+	}
+	dates := make([]time.Time, len(expenses))
+	for i := range expenses {
+		dates[i] = expenses[i].Date
+	}
+	checkBudgetAlerts(ctx.Request.Context(), scope, dates...)
+
+	respondSuccess(ctx, "compra parcelada criada", toInstallmentPurchaseResponse(&purchase, &account, expenses))
+}
+
+// ListInstallmentPurchasesHandler godoc
+// @Summary Listar compras parceladas
+// @Description Lista as compras parceladas do escopo, da mais recente para a mais antiga, com as parcelas e a fatura de cada uma
+// @Tags Parcelamentos
+// @Security Bearer
+// @Produce json
+// @Param accountId query string false "Filtro por cartão"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} InstallmentPurchaseListSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /installments [get]
+func ListInstallmentPurchasesHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	query := getDataScope(ctx, user).apply(getDB().Preload("Account"), "installment_purchases")
+	if raw := ctx.Query("accountId"); raw != "" {
+		accountID, err := uuid.Parse(raw)
+		if err != nil {
+			respondError(ctx, 400, "accountId inválido", nil)
+			return
+		}
+		query = query.Where("account_id = ?", accountID)
+	}
+
+	var purchases []schemas.InstallmentPurchase
+	if err := query.Order("purchase_date DESC").Order("created_at DESC").Find(&purchases).Error; err != nil {
+		respondError(ctx, 500, "erro ao listar compras parceladas", err.Error())
+		return
+	}
+
+	ids := make([]uuid.UUID, len(purchases))
+	for i := range purchases {
+		ids[i] = purchases[i].ID
+	}
+	var expenses []schemas.Expense
+	if len(ids) > 0 {
+		if err := getDB().Where("installment_purchase_id IN ?", ids).Find(&expenses).Error; err != nil {
+			respondError(ctx, 500, "erro ao carregar parcelas", err.Error())
+			return
+		}
+	}
+	byPurchase := map[uuid.UUID][]schemas.Expense{}
+	for _, expense := range expenses {
+		byPurchase[*expense.InstallmentPurchaseID] = append(byPurchase[*expense.InstallmentPurchaseID], expense)
+	}
+
+	responses := make([]InstallmentPurchaseResponse, len(purchases))
+	for i := range purchases {
+		responses[i] = toInstallmentPurchaseResponse(&purchases[i], purchases[i].Account, byPurchase[purchases[i].ID])
+	}
+
+	respondSuccess(ctx, "compras parceladas", responses)
+}
+
+// GetInstallmentPurchaseHandler godoc
+// @Summary Buscar compra parcelada
+// @Description Retorna a compra parcelada com suas parcelas, a despesa de cada uma e a fatura em que cai
+// @Tags Parcelamentos
+// @Security Bearer
+// @Produce json
+// @Param id path string true "Identificador da compra parcelada"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} InstallmentPurchaseSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /installments/{id} [get]
+func GetInstallmentPurchaseHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	purchaseID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	purchase := schemas.InstallmentPurchase{}
+	if err := getDataScope(ctx, user).apply(getDB().Preload("Account"), "installment_purchases").
+		Where("id = ?", purchaseID).
+		First(&purchase).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "compra parcelada não encontrada", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao carregar compra parcelada", err.Error())
+		return
+	}
+
+	var expenses []schemas.Expense
+	if err := getDB().Where("installment_purchase_id = ?", purchase.ID).Find(&expenses).Error; err != nil {
+		respondError(ctx, 500, "erro ao carregar parcelas", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "compra parcelada", toInstallmentPurchaseResponse(&purchase, purchase.Account, expenses))
+}
+
+// DeleteInstallmentPurchaseHandler godoc
+// @Summary Remover compra parcelada
+// @Description Exclui a compra parcelada e todas as suas parcelas
+// @Tags Parcelamentos
+// @Security Bearer
+// @Param id path string true "Identificador da compra parcelada"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /installments/{id} [delete]
+func DeleteInstallmentPurchaseHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	purchaseID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		result := scope.apply(tx, "installment_purchases").Where("id = ?", purchaseID).Delete(&schemas.InstallmentPurchase{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Where("installment_purchase_id = ?", purchaseID).Delete(&schemas.Expense{}).Error
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "compra parcelada não encontrada", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao remover compra parcelada", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "compra parcelada removida", nil)
+}
+
+// AccountStatementsHandler godoc
+// @Summary Faturas do cartão
+// @Description Lista as faturas do cartão de crédito a partir da que fecha no mês informado (ou no atual), com as despesas de cada uma. Parcelas trazem o rótulo k/N.
+// @Tags Contas
+// @Security Bearer
+// @Produce json
+// @Param id path string true "Identificador do cartão de crédito"
+// @Param month query int false "Mês de fechamento da primeira fatura (1-12)"
+// @Param year query int false "Ano de fechamento da primeira fatura"
+// @Param months query int false "Quantidade de faturas (padrão 3, máximo 24)"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} StatementListSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /accounts/{id}/statements [get]
+func AccountStatementsHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	accountID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	filter, err := buildExpenseFilter(ctx)
+	if err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+	months := parseIntDefault(ctx.Query("months"), defaultStatementsMonths)
+	if months <= 0 {
+		months = defaultStatementsMonths
+	}
+	if months > maxStatementsMonths {
+		months = maxStatementsMonths
+	}
+
+	account := schemas.Account{}
+	if err := getDataScope(ctx, user).apply(getDB(), "accounts").Where("id = ?", accountID).First(&account).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "conta não encontrada", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao carregar conta", err.Error())
+		return
+	}
+	if account.Type != schemas.AccountTypeCreditCard {
+		respondError(ctx, 400, errNotCreditCard.Error(), nil)
+		return
+	}
+
+	statements := make([]StatementResponse, 0, months)
+	for i := 0; i < months; i++ {
+		start, end, due := account.StatementPeriod(filter.Year, time.Month(filter.Month+i))
+		closing := end.AddDate(0, 0, -1)
+
+		var expenses []schemas.Expense
+		if err := getDB().Preload("InstallmentPurchase").
+			Where("account_id = ? AND date >= ? AND date < ?", account.ID, start, end).
+			Order("date ASC").Order("created_at ASC").
+			Find(&expenses).Error; err != nil {
+			respondError(ctx, 500, "erro ao carregar fatura", err.Error())
+			return
+		}
+
+		statement := StatementResponse{
+			AccountID:   account.ID.String(),
+			Year:        closing.Year(),
+			Month:       int(closing.Month()),
+			PeriodStart: start,
+			ClosingDate: closing,
+			DueDate:     due,
+			Items:       make([]StatementItem, 0, len(expenses)),
+		}
+		total := 0.0
+		for j := range expenses {
+			expense := &expenses[j]
+			total += expense.Amount
+			statement.Items = append(statement.Items, StatementItem{
+				ExpenseID:             expense.ID.String(),
+				Date:                  expense.Date,
+				Description:           expense.Description,
+				CategoryID:            expense.CategoryID.String(),
+				Amount:                expense.Amount,
+				InstallmentPurchaseID: uuidPtrString(expense.InstallmentPurchaseID),
+				Installment:           installmentLabel(expense),
+			})
+		}
+		statement.Total = roundFloat(total)
+		statements = append(statements, statement)
+	}
+
+	respondSuccess(ctx, "faturas", statements)
+}
+
+// splitInstallments divide o total em parcelas iguais em centavos; a primeira absorve o resto da divisão.
+func splitInstallments(total float64, count int) []float64 {
+	cents := int64(math.Round(total * 100))
+	base := cents / int64(count)
+	amounts := make([]float64, count)
+	for i := range amounts {
+		amounts[i] = float64(base) / 100
+	}
+	amounts[0] = float64(cents-base*int64(count-1)) / 100
+	return amounts
+}
+
+// installmentDate é a data da parcela de número informado: a data da compra avançada number-1 meses,
+// com o dia limitado ao último dia do mês.
+func installmentDate(purchaseDate time.Time, number int) time.Time {
+	first := time.Date(purchaseDate.Year(), purchaseDate.Month(), 1, 0, 0, 0, 0, purchaseDate.Location()).AddDate(0, number-1, 0)
+	day := purchaseDate.Day()
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// installmentLabel devolve "k/N" para parcelas com a compra carregada, ou vazio para as demais despesas.
+func installmentLabel(expense *schemas.Expense) string {
+	if expense.InstallmentNumber == nil || expense.InstallmentPurchase == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", *expense.InstallmentNumber, expense.InstallmentPurchase.Installments)
+}
+
+func toInstallmentPurchaseResponse(purchase *schemas.InstallmentPurchase, account *schemas.Account, expenses []schemas.Expense) InstallmentPurchaseResponse {
+	byNumber := map[int]*schemas.Expense{}
+	for i := range expenses {
+		if expenses[i].InstallmentNumber != nil {
+			byNumber[*expenses[i].InstallmentNumber] = &expenses[i]
+		}
+	}
+
+	amounts := splitInstallments(purchase.TotalAmount, purchase.Installments)
+	items := make([]InstallmentResponse, 0, purchase.Installments)
+	for number := 1; number <= purchase.Installments; number++ {
+		item := InstallmentResponse{
+			Number: number,
+			Label:  fmt.Sprintf("%d/%d", number, purchase.Installments),
+			Date:   installmentDate(purchase.PurchaseDate, number),
+			Amount: amounts[number-1],
+		}
+		if expense := byNumber[number]; expense != nil {
+			item.Date = expense.Date
+			item.Amount = expense.Amount
+			item.ExpenseID = uuidPtrString(&expense.ID)
+		}
+		if account != nil {
+			year, month := account.StatementMonth(item.Date)
+			item.StatementYear, item.StatementMonth = year, int(month)
+		}
+		items = append(items, item)
+	}
+
+	return InstallmentPurchaseResponse{
+		ID:           purchase.ID.String(),
+		HouseholdID:  uuidPtrString(purchase.HouseholdID),
+		CreatedBy:    purchase.UserID.String(),
+		AccountID:    purchase.AccountID.String(),
+		CategoryID:   purchase.CategoryID.String(),
+		Description:  purchase.Description,
+		TotalAmount:  purchase.TotalAmount,
+		Installments: purchase.Installments,
+		PurchaseDate: purchase.PurchaseDate,
+		CreatedAt:    purchase.CreatedAt,
+		Items:        items,
+	}
+}
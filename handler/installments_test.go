@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSplitInstallments(t *testing.T) {
+	tests := []struct {
+		name  string
+		total float64
+		count int
+		want  []float64
+	}{
+		{"divisão exata", 90, 3, []float64{30, 30, 30}},
+		{"100 em 3 deixa o centavo na primeira", 100, 3, []float64{33.34, 33.33, 33.33}},
+		{"resto de vários centavos", 99.99, 4, []float64{25.02, 24.99, 24.99, 24.99}},
+		{"parcela única", 10.5, 1, []float64{10.5}},
+		{"centavos menores que as parcelas", 0.05, 3, []float64{0.03, 0.01, 0.01}},
+		{"valor com erro de ponto flutuante", 0.1 + 0.2, 2, []float64{0.15, 0.15}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitInstallments(tt.total, tt.count)
+			if len(got) != len(tt.want) {
+				t.Fatalf("parcelas = %v, esperado %v", got, tt.want)
+			}
+			var sum float64
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("parcelas = %v, esperado %v", got, tt.want)
+				}
+				sum += got[i]
+			}
+			if math.Round(sum*100) != math.Round(tt.total*100) {
+				t.Fatalf("soma das parcelas = %.2f, esperado %.2f", sum, tt.total)
+			}
+		})
+	}
+}
+
+func TestInstallmentDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		purchase time.Time
+		number   int
+		want     time.Time
+	}{
+		{"primeira parcela na data da compra", time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC), 1, time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)},
+		{"31 de janeiro cai em 29 de fevereiro", time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC), 2, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"31 de janeiro cai em 28 de fevereiro", time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC), 2, time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC)},
+		{"volta ao dia 31 em março", time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC), 3, time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)},
+		{"31 em abril cai em 30", time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC), 4, time.Date(2024, time.April, 30, 0, 0, 0, 0, time.UTC)},
+		{"dezembro passa para janeiro", time.Date(2024, time.December, 15, 0, 0, 0, 0, time.UTC), 2, time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)},
+		{"doze parcelas", time.Date(2024, time.March, 30, 0, 0, 0, 0, time.UTC), 12, time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := installmentDate(tt.purchase, tt.number); !got.Equal(tt.want) {
+				t.Fatalf("installmentDate(%s, %d) = %s, esperado %s", tt.purchase.Format("2006-01-02"), tt.number, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"gorm.io/gorm"
+)
+
+// StartBackgroundJobs inicia as rotinas periódicas de manutenção; deve ser chamada após InitializerHandler.
+func StartBackgroundJobs(ctx context.Context) {
+	go runPeriodically(ctx, "expurgo de dados", getPurgeInterval(), purgeExpiredData)
+	go runPeriodically(ctx, "geração de recorrências", getRecurrenceInterval(), materializeDueRecurrences)
+	startReceiptWorkers(ctx)
+}
+
+func runPeriodically(ctx context.Context, name string, interval time.Duration, job func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(ctx); err != nil {
+			getLogger().ErrorF("rotina %s falhou: %v", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeExpiredData exclui as contas cujo período de carência terminou e as linhas
+// excluídas logicamente há mais tempo que a retenção configurada.
+func purgeExpiredData(ctx context.Context) error {
+	db := getDB().WithContext(ctx)
+
+	var users []schemas.User
+	if err := db.Where("deletion_due_at IS NOT NULL AND deletion_due_at <= ?", time.Now()).Find(&users).Error; err != nil {
+		return err
+	}
+
+	for i := range users {
+		user := &users[i]
+		var userImageKeys []string
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			userImageKeys, err = deleteUserData(tx, user)
+			return err
+		}); err != nil {
+			return fmt.Errorf("exclusão da conta %s: %w", user.ID, err)
+		}
+		deleteReceiptImages(ctx, userImageKeys)
+		if err := getLoginAttemptStore().Reset(ctx, loginEmailKey(user.Email)); err != nil {
+			getLogger().WarnF("não foi possível limpar tentativas de login: %v", err)
+		}
+		getLogger().InfoF("conta %s excluída definitivamente", user.ID)
+	}
+
+	cutoff := time.Now().Add(-getSoftDeleteRetention())
+	imageKeys, err := purgedReceiptImageKeys(db, cutoff)
+	if err != nil {
+		return fmt.Errorf("receipt images: %w", err)
+	}
+	if err := purgeSoftDeleted(db, cutoff); err != nil {
+		return err
+	}
+	deleteReceiptImages(ctx, imageKeys)
+
+	// Os jobs de recibo concluídos só servem para consulta do resultado e seguem a mesma retenção.
+	return db.Unscoped().Where("finished_at IS NOT NULL AND finished_at < ?", cutoff).Delete(&schemas.ReceiptJob{}).Error
+}
+
+// purgeSoftDeleted remove definitivamente as linhas excluídas logicamente antes do corte,
+// incluindo as filhas de pais excluídos, que não recebem deleted_at.
+func purgeSoftDeleted(db *gorm.DB, cutoff time.Time) error {
+	for _, table := range personalDataTables {
+		if table.ParentTable != "" {
+			subquery := "SELECT id FROM " + table.ParentTable + " WHERE deleted_at IS NOT NULL AND deleted_at < ?"
+			if err := db.Unscoped().
+				Where(table.OwnerColumn+" IN ("+subquery+")", cutoff).
+				Delete(table.Model).Error; err != nil {
+				return fmt.Errorf("%s: %w", table.File, err)
+			}
+		}
+
+		if !table.SoftDelete {
+			continue
+		}
+		if err := db.Unscoped().
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+			Delete(table.Model).Error; err != nil {
+			return fmt.Errorf("%s: %w", table.File, err)
+		}
+	}
+	return nil
+}
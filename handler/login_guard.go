@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const errorCodeAccountLocked = "account_locked"
+
+type lockoutPolicy struct {
+	Threshold   int
+	BaseLockout time.Duration
+	MaxLockout  time.Duration
+	Window      time.Duration
+}
+
+var (
+	emailLockoutPolicy = lockoutPolicy{Threshold: 5, BaseLockout: time.Minute, MaxLockout: time.Hour, Window: 30 * time.Minute}
+	ipLockoutPolicy    = lockoutPolicy{Threshold: 20, BaseLockout: time.Minute, MaxLockout: time.Hour, Window: 30 * time.Minute}
+)
+
+// lockDuration dobra o bloqueio a cada falha acima do limite, até MaxLockout.
+func (p lockoutPolicy) lockDuration(failures int) time.Duration {
+	if failures < p.Threshold {
+		return 0
+	}
+	exponent := failures - p.Threshold
+	if exponent > 20 {
+		return p.MaxLockout
+	}
+	duration := time.Duration(float64(p.BaseLockout) * math.Pow(2, float64(exponent)))
+	if duration > p.MaxLockout {
+		return p.MaxLockout
+	}
+	return duration
+}
+
+type loginThrottleState struct {
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+func (s loginThrottleState) lockedAt(now time.Time) bool {
+	return s.LockedUntil != nil && s.LockedUntil.After(now)
+}
+
+// loginAttemptStore abstrai onde os contadores de falha e a auditoria são mantidos.
+type loginAttemptStore interface {
+	Get(ctx context.Context, key string) (loginThrottleState, error)
+	RegisterFailure(ctx context.Context, key string, policy lockoutPolicy, now time.Time) (loginThrottleState, error)
+	Reset(ctx context.Context, key string) error
+	RecordAttempt(ctx context.Context, attempt *schemas.LoginAttempt) error
+}
+
+type dbLoginAttemptStore struct{}
+
+func (dbLoginAttemptStore) Get(ctx context.Context, key string) (loginThrottleState, error) {
+	throttle := schemas.LoginThrottle{}
+	if err := getDB().WithContext(ctx).First(&throttle, "key = ?", key).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return loginThrottleState{}, nil
+		}
+		return loginThrottleState{}, err
+	}
+	return loginThrottleState{
+		Failures:      throttle.Failures,
+		LastFailureAt: throttle.LastFailureAt,
+		LockedUntil:   throttle.LockedUntil,
+	}, nil
+}
+
+func (dbLoginAttemptStore) RegisterFailure(ctx context.Context, key string, policy lockoutPolicy, now time.Time) (loginThrottleState, error) {
+	var state loginThrottleState
+	err := getDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		throttle := schemas.LoginThrottle{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&throttle, "key = ?", key).Error; err != nil {
+			if err != gorm.ErrRecordNotFound {
+				return err
+			}
+			throttle = schemas.LoginThrottle{Key: key}
+		}
+
+		current := loginThrottleState{
+			Failures:      throttle.Failures,
+			LastFailureAt: throttle.LastFailureAt,
+			LockedUntil:   throttle.LockedUntil,
+		}
+		state = nextThrottleState(current, policy, now)
+
+		throttle.Failures = state.Failures
+		throttle.LastFailureAt = state.LastFailureAt
+		throttle.LockedUntil = state.LockedUntil
+		return tx.Save(&throttle).Error
+	})
+	return state, err
+}
+
+func (dbLoginAttemptStore) Reset(ctx context.Context, key string) error {
+	return getDB().WithContext(ctx).Where("key = ?", key).Delete(&schemas.LoginThrottle{}).Error
+}
+
+func (dbLoginAttemptStore) RecordAttempt(ctx context.Context, attempt *schemas.LoginAttempt) error {
+	return getDB().WithContext(ctx).Create(attempt).Error
+}
+
+// memoryLoginAttemptStore mantém os contadores em memória; indicado para testes e instâncias únicas.
+type memoryLoginAttemptStore struct {
+	mu       sync.Mutex
+	states   map[string]loginThrottleState
+	attempts []schemas.LoginAttempt
+}
+
+func newMemoryLoginAttemptStore() *memoryLoginAttemptStore {
+	return &memoryLoginAttemptStore{states: map[string]loginThrottleState{}}
+}
+
+func (m *memoryLoginAttemptStore) Get(ctx context.Context, key string) (loginThrottleState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.states[key], nil
+}
+
+func (m *memoryLoginAttemptStore) RegisterFailure(ctx context.Context, key string, policy lockoutPolicy, now time.Time) (loginThrottleState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state := nextThrottleState(m.states[key], policy, now)
+	m.states[key] = state
+	return state, nil
+}
+
+func (m *memoryLoginAttemptStore) Reset(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.states, key)
+	return nil
+}
+
+func (m *memoryLoginAttemptStore) RecordAttempt(ctx context.Context, attempt *schemas.LoginAttempt) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.attempts = append(m.attempts, *attempt)
+	return nil
+}
+
+func nextThrottleState(current loginThrottleState, policy lockoutPolicy, now time.Time) loginThrottleState {
+	failures := current.Failures
+	if !current.lockedAt(now) && !current.LastFailureAt.IsZero() && now.Sub(current.LastFailureAt) > policy.Window {
+		failures = 0
+	}
+	failures++
+
+	state := loginThrottleState{Failures: failures, LastFailureAt: now, LockedUntil: current.LockedUntil}
+	if duration := policy.lockDuration(failures); duration > 0 {
+		until := now.Add(duration)
+		state.LockedUntil = &until
+	}
+	return state
+}
+
+func loginEmailKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func loginIPKey(ip string) string {
+	return "ip:" + ip
+}
+
+// checkLoginLock retorna o fim do bloqueio mais distante entre email e IP, se houver.
+func checkLoginLock(ctx context.Context, email, ip string) (*time.Time, error) {
+	now := time.Now()
+	var lockedUntil *time.Time
+	for _, key := range []string{loginEmailKey(email), loginIPKey(ip)} {
+		state, err := getLoginAttemptStore().Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if state.lockedAt(now) && (lockedUntil == nil || state.LockedUntil.After(*lockedUntil)) {
+			lockedUntil = state.LockedUntil
+		}
+	}
+	return lockedUntil, nil
+}
+
+// registerLoginFailure grava a auditoria e incrementa os contadores, devolvendo o bloqueio aplicado.
+func registerLoginFailure(ctx *gin.Context, user *schemas.User, email, reason string) (*time.Time, error) {
+	store := getLoginAttemptStore()
+	reqCtx := ctx.Request.Context()
+	now := time.Now()
+	ip := ctx.ClientIP()
+
+	attempt := schemas.LoginAttempt{
+		Email:     truncateString(email, 180),
+		IPAddress: truncateString(ip, 45),
+		UserAgent: truncateString(ctx.Request.UserAgent(), 255),
+		Reason:    reason,
+	}
+	if user != nil {
+		userID := user.ID
+		attempt.UserID = &userID
+	}
+	if err := store.RecordAttempt(reqCtx, &attempt); err != nil {
+		return nil, err
+	}
+
+	emailState, err := store.RegisterFailure(reqCtx, loginEmailKey(email), emailLockoutPolicy, now)
+	if err != nil {
+		return nil, err
+	}
+	ipState, err := store.RegisterFailure(reqCtx, loginIPKey(ip), ipLockoutPolicy, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if user != nil && emailState.lockedAt(now) {
+		if err := getDB().WithContext(reqCtx).Model(&schemas.User{}).
+			Where("id = ?", user.ID).
+			Updates(map[string]interface{}{"locked_until": *emailState.LockedUntil}).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	var lockedUntil *time.Time
+	for _, state := range []loginThrottleState{emailState, ipState} {
+		if state.lockedAt(now) && (lockedUntil == nil || state.LockedUntil.After(*lockedUntil)) {
+			lockedUntil = state.LockedUntil
+		}
+	}
+	return lockedUntil, nil
+}
+
+// clearLoginFailures remove o bloqueio por email após um login bem-sucedido ou redefinição de senha.
+func clearLoginFailures(ctx context.Context, userID uuid.UUID, email string) error {
+	if err := getLoginAttemptStore().Reset(ctx, loginEmailKey(email)); err != nil {
+		return err
+	}
+	return getDB().WithContext(ctx).Model(&schemas.User{}).
+		Where("id = ? AND locked_until IS NOT NULL", userID).
+		Updates(map[string]interface{}{"locked_until": nil}).Error
+}
+
+func respondAccountLocked(ctx *gin.Context, until time.Time) {
+	retryAfter := int(math.Ceil(time.Until(until).Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	ctx.Header("Retry-After", fmt.Sprintf("%d", retryAfter))
+	respondErrorCode(ctx, 429, errorCodeAccountLocked, "muitas tentativas de login, tente novamente mais tarde", gin.H{
+		"lockedUntil":       until,
+		"retryAfterSeconds": retryAfter,
+	})
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+)
+
+func TestLockoutPolicyLockDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   lockoutPolicy
+		failures int
+		want     time.Duration
+	}{
+		{"email abaixo do limite", emailLockoutPolicy, 4, 0},
+		{"email no limite", emailLockoutPolicy, 5, time.Minute},
+		{"email dobra", emailLockoutPolicy, 6, 2 * time.Minute},
+		{"email dobra de novo", emailLockoutPolicy, 7, 4 * time.Minute},
+		{"email antes do teto", emailLockoutPolicy, 10, 32 * time.Minute},
+		{"email no teto", emailLockoutPolicy, 11, time.Hour},
+		{"email muito acima do teto", emailLockoutPolicy, 500, time.Hour},
+		{"ip abaixo do limite", ipLockoutPolicy, 19, 0},
+		{"ip no limite", ipLockoutPolicy, 20, time.Minute},
+		{"ip dobra", ipLockoutPolicy, 21, 2 * time.Minute},
+		{"ip no teto", ipLockoutPolicy, 26, time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.lockDuration(tt.failures); got != tt.want {
+				t.Fatalf("lockDuration(%d) = %v, esperado %v", tt.failures, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextThrottleState(t *testing.T) {
+	base := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		current      loginThrottleState
+		policy       lockoutPolicy
+		now          time.Time
+		wantFailures int
+		wantLock     time.Duration
+	}{
+		{
+			name:         "primeira falha",
+			policy:       emailLockoutPolicy,
+			now:          base,
+			wantFailures: 1,
+		},
+		{
+			name:         "quinta falha de email bloqueia por um minuto",
+			current:      loginThrottleState{Failures: 4, LastFailureAt: base.Add(-time.Minute)},
+			policy:       emailLockoutPolicy,
+			now:          base,
+			wantFailures: 5,
+			wantLock:     time.Minute,
+		},
+		{
+			name:         "falha durante o bloqueio dobra o tempo",
+			current:      loginThrottleState{Failures: 5, LastFailureAt: base.Add(-time.Hour), LockedUntil: timePtr(base.Add(time.Minute))},
+			policy:       emailLockoutPolicy,
+			now:          base,
+			wantFailures: 6,
+			wantLock:     2 * time.Minute,
+		},
+		{
+			name:         "décima primeira falha atinge o teto de uma hora",
+			current:      loginThrottleState{Failures: 10, LastFailureAt: base.Add(-time.Minute)},
+			policy:       emailLockoutPolicy,
+			now:          base,
+			wantFailures: 11,
+			wantLock:     time.Hour,
+		},
+		{
+			name:         "falhas antigas fora da janela recomeçam a contagem",
+			current:      loginThrottleState{Failures: 4, LastFailureAt: base.Add(-31 * time.Minute)},
+			policy:       emailLockoutPolicy,
+			now:          base,
+			wantFailures: 1,
+		},
+		{
+			name:         "décima nona falha por IP não bloqueia",
+			current:      loginThrottleState{Failures: 18, LastFailureAt: base.Add(-time.Minute)},
+			policy:       ipLockoutPolicy,
+			now:          base,
+			wantFailures: 19,
+		},
+		{
+			name:         "vigésima falha por IP bloqueia",
+			current:      loginThrottleState{Failures: 19, LastFailureAt: base.Add(-time.Minute)},
+			policy:       ipLockoutPolicy,
+			now:          base,
+			wantFailures: 20,
+			wantLock:     time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextThrottleState(tt.current, tt.policy, tt.now)
+			if got.Failures != tt.wantFailures {
+				t.Fatalf("Failures = %d, esperado %d", got.Failures, tt.wantFailures)
+			}
+			if !got.LastFailureAt.Equal(tt.now) {
+				t.Fatalf("LastFailureAt = %v, esperado %v", got.LastFailureAt, tt.now)
+			}
+			if tt.wantLock == 0 {
+				if got.lockedAt(tt.now) {
+					t.Fatalf("não deveria bloquear, LockedUntil = %v", got.LockedUntil)
+				}
+				return
+			}
+			if got.LockedUntil == nil || !got.LockedUntil.Equal(tt.now.Add(tt.wantLock)) {
+				t.Fatalf("LockedUntil = %v, esperado %v", got.LockedUntil, tt.now.Add(tt.wantLock))
+			}
+		})
+	}
+}
+
+func TestMemoryLoginAttemptStore(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryLoginAttemptStore()
+	key := loginEmailKey(" Ana@Example.com ")
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	wantLocks := []time.Duration{0, 0, 0, 0, time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 16 * time.Minute, 32 * time.Minute, time.Hour, time.Hour}
+	for i, want := range wantLocks {
+		state, err := store.RegisterFailure(ctx, key, emailLockoutPolicy, now)
+		if err != nil {
+			t.Fatalf("RegisterFailure: %v", err)
+		}
+		if state.Failures != i+1 {
+			t.Fatalf("falha %d: Failures = %d", i+1, state.Failures)
+		}
+		if want == 0 {
+			if state.lockedAt(now) {
+				t.Fatalf("falha %d: não deveria bloquear", i+1)
+			}
+		} else if state.LockedUntil == nil || !state.LockedUntil.Equal(now.Add(want)) {
+			t.Fatalf("falha %d: LockedUntil = %v, esperado %v", i+1, state.LockedUntil, now.Add(want))
+		}
+		now = now.Add(time.Second)
+	}
+
+	if other, _ := store.Get(ctx, loginEmailKey("bia@example.com")); other.Failures != 0 {
+		t.Fatalf("outra chave não deveria ter falhas: %+v", other)
+	}
+	if state, _ := store.Get(ctx, loginEmailKey("ana@example.com")); !state.lockedAt(now) {
+		t.Fatalf("a chave deveria ser normalizada e continuar bloqueada: %+v", state)
+	}
+
+	if err := store.Reset(ctx, key); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	state, _ := store.Get(ctx, key)
+	if state.Failures != 0 || state.lockedAt(now) {
+		t.Fatalf("Reset deveria limpar o estado: %+v", state)
+	}
+
+	state, _ = store.RegisterFailure(ctx, key, emailLockoutPolicy, now)
+	if state.Failures != 1 || state.lockedAt(now) {
+		t.Fatalf("após Reset a contagem recomeça: %+v", state)
+	}
+
+	if err := store.RecordAttempt(ctx, &schemas.LoginAttempt{Email: "ana@example.com", Reason: "invalid_password"}); err != nil {
+		t.Fatalf("RecordAttempt: %v", err)
+	}
+	if len(store.attempts) != 1 {
+		t.Fatalf("attempts = %d, esperado 1", len(store.attempts))
+	}
+}
+
+func TestClearLoginFailuresResetsOnSuccess(t *testing.T) {
+	setupTestDB(t)
+	previousStore := loginStore
+	loginStore = newMemoryLoginAttemptStore()
+	t.Cleanup(func() { loginStore = previousStore })
+
+	ctx := context.Background()
+	user := createTestUser(t, "ana@example.com")
+	now := time.Now()
+	for i := 0; i < emailLockoutPolicy.Threshold; i++ {
+		if _, err := loginStore.RegisterFailure(ctx, loginEmailKey(user.Email), emailLockoutPolicy, now); err != nil {
+			t.Fatalf("RegisterFailure: %v", err)
+		}
+	}
+	if _, err := loginStore.RegisterFailure(ctx, loginIPKey("10.0.0.1"), ipLockoutPolicy, now); err != nil {
+		t.Fatalf("RegisterFailure: %v", err)
+	}
+	if err := getDB().Model(user).Update("locked_until", now.Add(time.Minute)).Error; err != nil {
+		t.Fatalf("erro bloqueando usuário: %v", err)
+	}
+
+	lockedUntil, err := checkLoginLock(ctx, user.Email, "10.0.0.1")
+	if err != nil || lockedUntil == nil {
+		t.Fatalf("checkLoginLock antes do sucesso = %v, %v; esperado bloqueio", lockedUntil, err)
+	}
+
+	if err := clearLoginFailures(ctx, user.ID, user.Email); err != nil {
+		t.Fatalf("clearLoginFailures: %v", err)
+	}
+
+	lockedUntil, err = checkLoginLock(ctx, user.Email, "10.0.0.1")
+	if err != nil || lockedUntil != nil {
+		t.Fatalf("checkLoginLock após o sucesso = %v, %v; esperado sem bloqueio", lockedUntil, err)
+	}
+	if state, _ := loginStore.Get(ctx, loginIPKey("10.0.0.1")); state.Failures != 1 {
+		t.Fatalf("o contador por IP não é zerado pelo login do usuário: %+v", state)
+	}
+
+	var reloaded schemas.User
+	if err := getDB().First(&reloaded, "id = ?", user.ID).Error; err != nil {
+		t.Fatalf("erro recarregando usuário: %v", err)
+	}
+	if reloaded.LockedUntil != nil {
+		t.Fatalf("locked_until deveria ser limpo, obteve %v", reloaded.LockedUntil)
+	}
+}
@@ -164,7 +164,13 @@ func generateMealPlanWithAI(ctx *gin.Context, user *schemas.User, isoWeek string
 
 	expenses := fetchRecentExpenses(ctx.Request.Context(), user.ID, 12)
 	items := fetchRecentItems(ctx.Request.Context(), user.ID, 20)
-	topCategories := fetchTopCategories(user.ID, startOfWeek, endOfWeek)
+	topCategories, err := fetchTopCategories(personalScope(user.ID), startOfWeek, endOfWeek)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	monthStart, monthEnd := monthInterval(int(startOfWeek.Month()), startOfWeek.Year())
+	monthIncome := aggregateIncome(personalScope(user.ID), monthStart, monthEnd)
+	monthSpent := aggregateTotal(personalScope(user.ID), monthStart, monthEnd)
 
 	currency := "BRL"
 	language := "pt-BR"
@@ -177,7 +183,7 @@ func generateMealPlanWithAI(ctx *gin.Context, user *schemas.User, isoWeek string
 		}
 	}
 
-	prompt := buildMealPlanPrompt(user.Name, isoWeek, startOfWeek, currency, language, request, expenses, items, topCategories)
+	prompt := buildMealPlanPrompt(user.Name, isoWeek, startOfWeek, currency, language, request, expenses, items, topCategories, monthIncome, monthSpent)
 	modelName := detectModelName()
 
 	req := gemini.GenerateContentRequest{
@@ -405,16 +411,15 @@ func fetchRecentItems(ctx context.Context, userID uuid.UUID, limit int) []schema
 	if limit <= 0 {
 		limit = 15
 	}
-	getDB().WithContext(ctx).
-		Joins("JOIN expenses ON expenses.id = expense_items.expense_id").
-		Where("expenses.user_id = ?", userID).
+	personalScope(userID).apply(getDB().WithContext(ctx).
+		Joins("JOIN expenses ON expenses.id = expense_items.expense_id AND expenses.deleted_at IS NULL"), "expenses").
 		Order("expense_items.created_at DESC").
 		Limit(limit).
 		Find(&items)
 	return items
 }
 
-func buildMealPlanPrompt(name, isoWeek string, start time.Time, currency, language string, request *GenerateMealPlanRequest, expenses []schemas.Expense, items []schemas.ExpenseItem, topCategories []CategoryAggregate) string {
+func buildMealPlanPrompt(name, isoWeek string, start time.Time, currency, language string, request *GenerateMealPlanRequest, expenses []schemas.Expense, items []schemas.ExpenseItem, topCategories []CategoryAggregate, monthIncome, monthSpent float64) string {
 	var builder strings.Builder
 	builder.WriteString("Você é um nutricionista financeiro que cria planos de refeições realistas.\n")
 	builder.WriteString("Entregue receitas práticas usando ingredientes do histórico de compras.\n")
@@ -442,6 +447,10 @@ func buildMealPlanPrompt(name, isoWeek string, start time.Time, currency, langua
 		}
 	}
 
+	if monthIncome > 0 {
+		builder.WriteString(fmt.Sprintf("Receitas do mês: %.2f %s; gastos do mês até agora: %.2f %s. Mantenha o plano compatível com o saldo disponível.\n", monthIncome, currency, monthSpent, currency))
+	}
+
 	if len(topCategories) > 0 {
 		builder.WriteString("Categorias com mais gastos recentes:\n")
 		for i, cat := range topCategories {
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/google/uuid"
+)
+
+func TestFetchRecentItemsUsesPersonalScope(t *testing.T) {
+	setupTestDB(t)
+	user := createTestUser(t, "ana@example.com")
+	other := createTestUser(t, "bia@example.com")
+	householdID := uuid.New()
+
+	expenses := []struct {
+		owner     uuid.UUID
+		household *uuid.UUID
+		deleted   bool
+		item      string
+	}{
+		{user.ID, nil, false, "arroz"},
+		{user.ID, &householdID, false, "feijão do grupo"},
+		{user.ID, nil, true, "leite excluído"},
+		{other.ID, nil, false, "café de outra pessoa"},
+	}
+	for i, data := range expenses {
+		expense := schemas.Expense{
+			UserID:      data.owner,
+			HouseholdID: data.household,
+			CategoryID:  uuid.New(),
+			Description: "compra",
+			Amount:      10,
+			Date:        time.Now().AddDate(0, 0, -i),
+			Items:       []schemas.ExpenseItem{{Name: data.item, Quantity: 1, UnitPrice: 10, TotalPrice: 10}},
+		}
+		if err := getDB().Create(&expense).Error; err != nil {
+			t.Fatalf("erro criando despesa: %v", err)
+		}
+		if data.deleted {
+			if err := getDB().Delete(&expense).Error; err != nil {
+				t.Fatalf("erro excluindo despesa: %v", err)
+			}
+		}
+	}
+
+	items := fetchRecentItems(context.Background(), user.ID, 10)
+	if len(items) != 1 || items[0].Name != "arroz" {
+		names := make([]string, len(items))
+		for i := range items {
+			names[i] = items[i].Name
+		}
+		t.Fatalf("itens = %v, esperado apenas [arroz]", names)
+	}
+
+	recent := fetchRecentExpenses(context.Background(), user.ID, 10)
+	if len(recent) != 1 || recent[0].HouseholdID != nil {
+		t.Fatalf("despesas recentes = %d, esperado apenas a pessoal", len(recent))
+	}
+}
@@ -0,0 +1,349 @@
+package handler
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// merchantAccents troca as letras acentuadas do português pela versão sem acento, para que "Padaria São José"
+// e "PADARIA SAO JOSE" sejam o mesmo estabelecimento.
+var merchantAccents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// merchantLegalSuffixes são as terminações de razão social ignoradas na comparação de nomes.
+var merchantLegalSuffixes = map[string]bool{
+	"ltda": true, "me": true, "epp": true, "eireli": true, "sa": true, "cia": true,
+}
+
+// merchantTotal é a soma das despesas de um estabelecimento no período.
+type merchantTotal struct {
+	MerchantID   uuid.UUID
+	Total        float64
+	ExpenseCount int
+	LastPurchase aggregateTime
+}
+
+// aggregateTimeLayouts são os formatos em que o SQLite devolve datas gravadas pelo GORM.
+var aggregateTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// aggregateTime lê datas calculadas, como MAX(date). O Postgres as devolve como time.Time, mas o SQLite devolve
+// o texto gravado, pois a coluna calculada não tem tipo declarado. Valid é falso quando o valor é NULL.
+type aggregateTime struct {
+	Time  time.Time
+	Valid bool
+}
+
+func (t *aggregateTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*t = aggregateTime{}
+		return nil
+	case time.Time:
+		*t = aggregateTime{Time: v, Valid: true}
+		return nil
+	case []byte:
+		return t.Scan(string(v))
+	case string:
+		for _, layout := range aggregateTimeLayouts {
+			if parsed, err := time.Parse(layout, v); err == nil {
+				*t = aggregateTime{Time: parsed, Valid: true}
+				return nil
+			}
+		}
+		return fmt.Errorf("data inválida: %q", v)
+	}
+	return fmt.Errorf("tipo de data não suportado: %T", value)
+}
+
+func (t aggregateTime) Value() (driver.Value, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return t.Time, nil
+}
+
+// ListMerchantsHandler godoc
+// @Summary Listar estabelecimentos
+// @Description Lista os estabelecimentos identificados nos recibos do escopo atual com o total gasto, a quantidade de compras e a data da última compra, do maior para o menor total. from e to (YYYY-MM-DD, inclusivos) restringem o período somado; sem eles, considera todas as despesas.
+// @Tags Estabelecimentos
+// @Security Bearer
+// @Produce json
+// @Param from query string false "Início do período (YYYY-MM-DD)"
+// @Param to query string false "Fim do período, inclusivo (YYYY-MM-DD)"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} MerchantListSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /merchants [get]
+func ListMerchantsHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	var from, to time.Time
+	if raw := ctx.Query("from"); raw != "" {
+		if from, err = parseDate(raw); err != nil {
+			respondError(ctx, 400, "from inválido", nil)
+			return
+		}
+	}
+	if raw := ctx.Query("to"); raw != "" {
+		parsed, err := parseDate(raw)
+		if err != nil {
+			respondError(ctx, 400, "to inválido", nil)
+			return
+		}
+		to = time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, parsed.Location()).AddDate(0, 0, 1)
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		respondError(ctx, 400, "from deve ser anterior ou igual a to", nil)
+		return
+	}
+
+	scope := getDataScope(ctx, user)
+
+	var merchants []schemas.Merchant
+	if err := scope.apply(getDB().Model(&schemas.Merchant{}), "merchants").Find(&merchants).Error; err != nil {
+		respondError(ctx, 500, "erro ao listar estabelecimentos", err.Error())
+		return
+	}
+
+	var totals []merchantTotal
+	query := scope.apply(getDB().Model(&schemas.Expense{}), "expenses").
+		Select("expenses.merchant_id, SUM(expenses.amount) AS total, COUNT(*) AS expense_count, MAX(expenses.date) AS last_purchase").
+		Where("expenses.merchant_id IS NOT NULL")
+	if !from.IsZero() {
+		query = query.Where("expenses.date >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("expenses.date < ?", to)
+	}
+	if err := query.Group("expenses.merchant_id").Scan(&totals).Error; err != nil {
+		respondError(ctx, 500, "erro ao somar despesas", err.Error())
+		return
+	}
+
+	responses := make([]MerchantResponse, len(merchants))
+	index := make(map[uuid.UUID]int, len(merchants))
+	for i := range merchants {
+		responses[i] = toMerchantResponse(&merchants[i])
+		index[merchants[i].ID] = i
+	}
+	for _, total := range totals {
+		i, ok := index[total.MerchantID]
+		if !ok {
+			continue
+		}
+		responses[i].Total = roundFloat(total.Total)
+		responses[i].ExpenseCount = total.ExpenseCount
+		if total.LastPurchase.Valid {
+			lastPurchase := total.LastPurchase.Time
+			responses[i].LastPurchase = &lastPurchase
+		}
+	}
+	sort.Slice(responses, func(i, j int) bool {
+		if responses[i].Total != responses[j].Total {
+			return responses[i].Total > responses[j].Total
+		}
+		return responses[i].Name < responses[j].Name
+	})
+
+	respondSuccess(ctx, "estabelecimentos", responses)
+}
+
+// resolveMerchant encontra ou cria o estabelecimento do recibo no escopo. Um cadastro achado pelo nome e ainda
+// sem CNPJ adota o CNPJ do recibo. Sem nome nem CNPJ não há estabelecimento e o retorno é nil.
+func resolveMerchant(tx *gorm.DB, user *schemas.User, scope dataScope, name, cnpj, address string) (*schemas.Merchant, error) {
+	name = strings.TrimSpace(name)
+	cnpj = normalizeCNPJ(cnpj)
+	address = strings.TrimSpace(address)
+	normalized := normalizeMerchantName(name)
+	if cnpj == "" && normalized == "" {
+		return nil, nil
+	}
+
+	merchant, err := findMerchant(tx, scope, name, cnpj)
+	if err != nil {
+		return nil, err
+	}
+	if merchant != nil {
+		updates := map[string]interface{}{}
+		if merchant.CNPJ == "" && cnpj != "" {
+			updates["cnpj"] = cnpj
+		}
+		if merchant.Address == "" && address != "" {
+			updates["address"] = truncateString(address, 255)
+		}
+		if merchant.Name == "" && name != "" {
+			updates["name"] = truncateString(name, 180)
+			updates["normalized_name"] = normalized
+		}
+		if len(updates) > 0 {
+			if err := tx.Model(merchant).Updates(updates).Error; err != nil {
+				return nil, err
+			}
+		}
+		return merchant, nil
+	}
+
+	created := schemas.Merchant{
+		UserID:         user.ID,
+		HouseholdID:    scope.HouseholdID,
+		Name:           truncateString(name, 180),
+		NormalizedName: normalized,
+		CNPJ:           cnpj,
+		Address:        truncateString(address, 255),
+	}
+	if err := tx.Create(&created).Error; err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// findMerchant procura o estabelecimento no escopo sem criá-lo. O CNPJ identifica o estabelecimento; sem ele,
+// vale o nome normalizado. Devolve nil quando não há cadastro correspondente.
+func findMerchant(tx *gorm.DB, scope dataScope, name, cnpj string) (*schemas.Merchant, error) {
+	cnpj = normalizeCNPJ(cnpj)
+	normalized := normalizeMerchantName(name)
+
+	var candidates []schemas.Merchant
+	if cnpj != "" {
+		if err := scope.apply(tx.Model(&schemas.Merchant{}), "merchants").
+			Where("merchants.cnpj = ?", cnpj).
+			Order("merchants.created_at ASC").
+			Limit(1).
+			Find(&candidates).Error; err != nil {
+			return nil, err
+		}
+	}
+	if len(candidates) == 0 && normalized != "" {
+		query := scope.apply(tx.Model(&schemas.Merchant{}), "merchants").
+			Where("merchants.normalized_name = ?", normalized)
+		if cnpj != "" {
+			// Com CNPJ diferente é outra empresa, ainda que o nome coincida.
+			query = query.Where("merchants.cnpj = ''")
+		}
+		if err := query.Order("merchants.created_at ASC").Limit(1).Find(&candidates).Error; err != nil {
+			return nil, err
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	return &candidates[0], nil
+}
+
+// normalizeCNPJ devolve apenas os 14 dígitos do CNPJ, ou string vazia quando os dígitos verificadores não conferem.
+func normalizeCNPJ(raw string) string {
+	digits := make([]int, 0, 14)
+	for _, r := range raw {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, int(r-'0'))
+		}
+	}
+	if len(digits) != 14 {
+		return ""
+	}
+
+	repeated := true
+	for _, d := range digits[1:] {
+		if d != digits[0] {
+			repeated = false
+			break
+		}
+	}
+	if repeated {
+		return ""
+	}
+
+	checkDigit := func(length int) int {
+		sum, weight := 0, length-7
+		for _, d := range digits[:length] {
+			sum += d * weight
+			weight--
+			if weight < 2 {
+				weight = 9
+			}
+		}
+		if rest := sum % 11; rest >= 2 {
+			return 11 - rest
+		}
+		return 0
+	}
+	if checkDigit(12) != digits[12] || checkDigit(13) != digits[13] {
+		return ""
+	}
+
+	var builder strings.Builder
+	for _, d := range digits {
+		builder.WriteByte(byte('0' + d))
+	}
+	return builder.String()
+}
+
+// formatCNPJ aplica a máscara 00.000.000/0000-00 a um CNPJ normalizado.
+func formatCNPJ(cnpj string) string {
+	if len(cnpj) != 14 {
+		return cnpj
+	}
+	return fmt.Sprintf("%s.%s.%s/%s-%s", cnpj[:2], cnpj[2:5], cnpj[5:8], cnpj[8:12], cnpj[12:])
+}
+
+// normalizeMerchantName reduz o nome a minúsculas sem acentos, pontuação nem terminações de razão social.
+func normalizeMerchantName(name string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		if r == '/' || r == '.' {
+			// Mantém "S/A" e "S.A." juntos para que virem "sa".
+			return -1
+		}
+		return ' '
+	}, merchantAccents.Replace(strings.ToLower(name)))
+
+	words := strings.Fields(cleaned)
+	for len(words) > 1 && merchantLegalSuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	return truncateString(strings.Join(words, " "), 180)
+}
+
+func toMerchantResponse(merchant *schemas.Merchant) MerchantResponse {
+	name := merchant.Name
+	if name == "" {
+		name = formatCNPJ(merchant.CNPJ)
+	}
+	return MerchantResponse{
+		ID:          merchant.ID.String(),
+		HouseholdID: uuidPtrString(merchant.HouseholdID),
+		Name:        name,
+		CNPJ:        formatCNPJ(merchant.CNPJ),
+		Address:     merchant.Address,
+		CreatedAt:   merchant.CreatedAt,
+	}
+}
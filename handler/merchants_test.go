@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/google/uuid"
+)
+
+func TestListMerchantsAggregatesInDatabase(t *testing.T) {
+	setupTestDB(t)
+	user := createTestUser(t, "ana@example.com")
+	other := createTestUser(t, "bia@example.com")
+
+	createMerchant := func(owner *schemas.User, name string) *schemas.Merchant {
+		merchant := schemas.Merchant{UserID: owner.ID, Name: name, NormalizedName: normalizeMerchantName(name)}
+		if err := getDB().Create(&merchant).Error; err != nil {
+			t.Fatalf("erro criando estabelecimento: %v", err)
+		}
+		return &merchant
+	}
+	createExpense := func(owner *schemas.User, merchant *schemas.Merchant, amount float64, date time.Time) *schemas.Expense {
+		expense := schemas.Expense{UserID: owner.ID, CategoryID: uuid.New(), MerchantID: &merchant.ID, Description: merchant.Name, Amount: amount, Date: date}
+		if err := getDB().Create(&expense).Error; err != nil {
+			t.Fatalf("erro criando despesa: %v", err)
+		}
+		return &expense
+	}
+
+	market := createMerchant(user, "Mercado Central")
+	bakery := createMerchant(user, "Padaria São José")
+	pharmacy := createMerchant(user, "Farmácia")
+	foreign := createMerchant(other, "Mercado da Bia")
+
+	march := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	createExpense(user, market, 100.10, march)
+	createExpense(user, market, 50.25, march.AddDate(0, 0, 20))
+	createExpense(user, bakery, 12.5, march.AddDate(0, 0, 5))
+	createExpense(user, bakery, 30, march.AddDate(0, 1, 0))
+	deleted := createExpense(user, bakery, 999, march.AddDate(0, 0, 6))
+	if err := getDB().Delete(deleted).Error; err != nil {
+		t.Fatalf("erro excluindo despesa: %v", err)
+	}
+	createExpense(other, foreign, 500, march)
+
+	type merchantRow struct {
+		name         string
+		total        float64
+		count        float64
+		lastPurchase string
+	}
+	listMerchants := func(query string) []merchantRow {
+		status, body := callHandler(t, ListMerchantsHandler, user, "GET", "/merchants"+query, nil)
+		if status != 200 {
+			t.Fatalf("status = %d: %v", status, body)
+		}
+		var rows []merchantRow
+		for _, item := range body["data"].([]interface{}) {
+			merchant := item.(map[string]interface{})
+			last, _ := merchant["lastPurchase"].(string)
+			if last != "" {
+				parsed, err := time.Parse(time.RFC3339Nano, last)
+				if err != nil {
+					t.Fatalf("lastPurchase inválido %q: %v", last, err)
+				}
+				last = parsed.UTC().Format("2006-01-02")
+			}
+			rows = append(rows, merchantRow{
+				name:         merchant["name"].(string),
+				total:        merchant["total"].(float64),
+				count:        merchant["expenseCount"].(float64),
+				lastPurchase: last,
+			})
+		}
+		return rows
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []merchantRow
+	}{
+		{"todo o período", "", []merchantRow{
+			{market.Name, 150.35, 2, "2024-03-21"},
+			{bakery.Name, 42.5, 2, "2024-04-01"},
+			{pharmacy.Name, 0, 0, ""},
+		}},
+		{"apenas março", "?from=2024-03-01&to=2024-03-31", []merchantRow{
+			{market.Name, 150.35, 2, "2024-03-21"},
+			{bakery.Name, 12.5, 1, "2024-03-06"},
+			{pharmacy.Name, 0, 0, ""},
+		}},
+		{"a partir de abril", "?from=2024-04-01", []merchantRow{
+			{bakery.Name, 30, 1, "2024-04-01"},
+			{pharmacy.Name, 0, 0, ""},
+			{market.Name, 0, 0, ""},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := listMerchants(tt.query)
+			if len(got) != len(tt.want) {
+				t.Fatalf("estabelecimentos = %+v, esperado %+v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("posição %d = %+v, esperado %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
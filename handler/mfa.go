@@ -0,0 +1,478 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/Pmmvito/Golang-Api-Exemple/service/totp"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	mfaChallengeTTL         = 5 * time.Minute
+	mfaChallengeMaxAttempts = 5
+	mfaRecoveryCodeCount    = 10
+	mfaTOTPSkew             = 1
+	mfaStatusPending        = "mfa_pending"
+)
+
+var (
+	errMFACodeInvalid    = errors.New("código inválido")
+	recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// EnrollMFAHandler godoc
+// @Summary Iniciar cadastro do segundo fator
+// @Description Gera um novo segredo TOTP e a URI otpauth para o app autenticador. O segundo fator só é ativado após a confirmação com um código válido.
+// @Tags Auth
+// @Security Bearer
+// @Produce json
+// @Success 200 {object} MFAEnrollSuccess
+// @Failure 401 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/mfa/enroll [post]
+func EnrollMFAHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	if user.MFAEnabled {
+		respondError(ctx, 409, "autenticação em dois fatores já habilitada", nil)
+		return
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		respondError(ctx, 500, "erro ao gerar segredo", err.Error())
+		return
+	}
+
+	if err := getDB().Model(&schemas.User{}).
+		Where("id = ?", user.ID).
+		Updates(map[string]interface{}{"mfa_secret": secret, "mfa_last_step": 0}).Error; err != nil {
+		respondError(ctx, 500, "erro ao salvar segredo", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "escaneie o QR code e confirme com um código", MFAEnrollResponse{
+		Secret:     secret,
+		OtpauthURI: totp.URI(getMFAIssuer(), user.Email, secret),
+	})
+}
+
+// ConfirmMFAHandler godoc
+// @Summary Confirmar segundo fator
+// @Description Ativa a autenticação em dois fatores com um código do app autenticador e retorna os códigos de recuperação, exibidos uma única vez
+// @Tags Auth
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body MFACodeRequest true "Código TOTP"
+// @Success 200 {object} MFARecoveryCodesSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/mfa/confirm [post]
+func ConfirmMFAHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	var request MFACodeRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	if user.MFAEnabled {
+		respondError(ctx, 409, "autenticação em dois fatores já habilitada", nil)
+		return
+	}
+	if user.MFASecret == "" {
+		respondError(ctx, 400, "inicie o cadastro do segundo fator antes de confirmar", nil)
+		return
+	}
+
+	var codes []string
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		valid, err := verifyTOTPCode(tx, user, request.Code)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return errMFACodeInvalid
+		}
+
+		if err := tx.Model(&schemas.User{}).
+			Where("id = ?", user.ID).
+			Updates(map[string]interface{}{"mfa_enabled": true}).Error; err != nil {
+			return err
+		}
+
+		codes, err = generateRecoveryCodes(tx, user.ID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, errMFACodeInvalid) {
+			respondError(ctx, 400, err.Error(), nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao ativar segundo fator", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "autenticação em dois fatores habilitada", MFARecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableMFAHandler godoc
+// @Summary Desativar segundo fator
+// @Description Desativa a autenticação em dois fatores mediante a senha atual e um código TOTP ou de recuperação
+// @Tags Auth
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body MFADisableRequest true "Senha e código"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/mfa/disable [post]
+func DisableMFAHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	var request MFADisableRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	if !user.MFAEnabled {
+		respondError(ctx, 400, "autenticação em dois fatores não habilitada", nil)
+		return
+	}
+
+	if !checkCurrentPassword(user, request.Password) {
+		respondError(ctx, 403, "senha incorreta", nil)
+		return
+	}
+
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		valid, err := verifySecondFactor(tx, user, request.Code)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return errMFACodeInvalid
+		}
+
+		if err := tx.Model(&schemas.User{}).
+			Where("id = ?", user.ID).
+			Updates(map[string]interface{}{"mfa_enabled": false, "mfa_secret": "", "mfa_last_step": 0}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("user_id = ?", user.ID).Delete(&schemas.MFARecoveryCode{}).Error
+	})
+	if err != nil {
+		if errors.Is(err, errMFACodeInvalid) {
+			respondError(ctx, 400, err.Error(), nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao desativar segundo fator", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "autenticação em dois fatores desativada", nil)
+}
+
+// RegenerateRecoveryCodesHandler godoc
+// @Summary Gerar novos códigos de recuperação
+// @Description Substitui todos os códigos de recuperação do segundo fator mediante um código TOTP válido
+// @Tags Auth
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body MFACodeRequest true "Código TOTP"
+// @Success 200 {object} MFARecoveryCodesSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/mfa/recovery-codes [post]
+func RegenerateRecoveryCodesHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	var request MFACodeRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	if !user.MFAEnabled {
+		respondError(ctx, 400, "autenticação em dois fatores não habilitada", nil)
+		return
+	}
+
+	var codes []string
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		valid, err := verifyTOTPCode(tx, user, request.Code)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return errMFACodeInvalid
+		}
+		codes, err = generateRecoveryCodes(tx, user.ID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, errMFACodeInvalid) {
+			respondError(ctx, 400, err.Error(), nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao gerar códigos de recuperação", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "códigos de recuperação gerados", MFARecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// VerifyMFAHandler godoc
+// @Summary Concluir login com segundo fator
+// @Description Troca o desafio mfa_pending retornado pelo login e um código TOTP ou de recuperação por uma sessão autenticada
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body MFAVerifyRequest true "Desafio e código"
+// @Success 200 {object} AuthSuccessResponse
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 429 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/mfa/verify [post]
+func VerifyMFAHandler(ctx *gin.Context) {
+	var request MFAVerifyRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	challenge := schemas.UserToken{}
+	if err := getDB().
+		Where("token_hash = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?",
+			schemas.HashToken(request.MFAToken), schemas.UserTokenMFAChallenge, time.Now()).
+		First(&challenge).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 401, "desafio inválido ou expirado", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao buscar desafio", err.Error())
+		return
+	}
+
+	user := schemas.User{}
+	if err := getDB().Preload("Config").First(&user, "id = ?", challenge.UserID).Error; err != nil {
+		respondError(ctx, 500, "erro ao buscar usuário", err.Error())
+		return
+	}
+	if !user.MFAEnabled {
+		respondError(ctx, 401, "desafio inválido ou expirado", nil)
+		return
+	}
+	if !user.Active {
+		respondError(ctx, 403, errAccountDisabled.Error(), nil)
+		return
+	}
+
+	lockedUntil, err := checkLoginLock(ctx.Request.Context(), user.Email, ctx.ClientIP())
+	if err != nil {
+		respondError(ctx, 500, "erro ao verificar tentativas de login", err.Error())
+		return
+	}
+	if lockedUntil != nil {
+		respondAccountLocked(ctx, *lockedUntil)
+		return
+	}
+
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		valid, err := verifySecondFactor(tx, &user, request.Code)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return errMFACodeInvalid
+		}
+		_, err = consumeUserToken(tx, request.MFAToken, schemas.UserTokenMFAChallenge)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, errMFACodeInvalid):
+			if err := registerMFAChallengeFailure(&challenge); err != nil {
+				getLogger().WarnF("não foi possível registrar tentativa de segundo fator: %v", err)
+			}
+			respondLoginFailure(ctx, &user, user.Email, "mfa_invalido")
+		case errors.Is(err, errUserTokenInvalid):
+			respondError(ctx, 401, "desafio inválido ou expirado", nil)
+		default:
+			respondError(ctx, 500, "erro ao verificar segundo fator", err.Error())
+		}
+		return
+	}
+
+	startUserSession(ctx, &user, request.DeviceName, "login realizado")
+}
+
+// respondMFAChallenge emite o desafio de segundo fator no lugar da sessão.
+func respondMFAChallenge(ctx *gin.Context, user *schemas.User) {
+	var rawToken string
+	if err := getDB().Transaction(func(tx *gorm.DB) error {
+		token, err := issueUserToken(tx, user.ID, schemas.UserTokenMFAChallenge, mfaChallengeTTL)
+		if err != nil {
+			return err
+		}
+		rawToken = token
+		return nil
+	}); err != nil {
+		respondError(ctx, 500, "erro ao gerar desafio de segundo fator", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "informe o código do segundo fator", MFAChallengeResponse{
+		Status:    mfaStatusPending,
+		MFAToken:  rawToken,
+		ExpiresAt: time.Now().Add(mfaChallengeTTL),
+	})
+}
+
+// registerMFAChallengeFailure conta a tentativa no desafio e o invalida ao atingir o limite.
+func registerMFAChallengeFailure(challenge *schemas.UserToken) error {
+	updates := map[string]interface{}{"attempts": gorm.Expr("attempts + 1")}
+	if challenge.Attempts+1 >= mfaChallengeMaxAttempts {
+		updates["used_at"] = time.Now()
+	}
+	return getDB().Model(&schemas.UserToken{}).
+		Where("id = ?", challenge.ID).
+		Updates(updates).Error
+}
+
+// verifySecondFactor aceita um código TOTP de seis dígitos ou um código de recuperação.
+func verifySecondFactor(tx *gorm.DB, user *schemas.User, code string) (bool, error) {
+	if isTOTPCode(code) {
+		return verifyTOTPCode(tx, user, code)
+	}
+	return consumeRecoveryCode(tx, user.ID, code)
+}
+
+// verifyTOTPCode valida o código e grava a janela utilizada, recusando a reutilização de um código já aceito.
+func verifyTOTPCode(tx *gorm.DB, user *schemas.User, code string) (bool, error) {
+	if user.MFASecret == "" {
+		return false, nil
+	}
+
+	step, ok := totp.Validate(user.MFASecret, code, time.Now(), mfaTOTPSkew)
+	if !ok {
+		return false, nil
+	}
+
+	result := tx.Model(&schemas.User{}).
+		Where("id = ? AND mfa_last_step < ?", user.ID, step).
+		Updates(map[string]interface{}{"mfa_last_step": step})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	user.MFALastStep = step
+	return true, nil
+}
+
+func consumeRecoveryCode(tx *gorm.DB, userID uuid.UUID, code string) (bool, error) {
+	normalized := normalizeRecoveryCode(code)
+	if normalized == "" {
+		return false, nil
+	}
+
+	result := tx.Model(&schemas.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, schemas.HashToken(normalized)).
+		Updates(map[string]interface{}{"used_at": time.Now()})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// generateRecoveryCodes substitui os códigos de recuperação do usuário e devolve os valores em texto puro.
+func generateRecoveryCodes(tx *gorm.DB, userID uuid.UUID) ([]string, error) {
+	if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&schemas.MFARecoveryCode{}).Error; err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, mfaRecoveryCodeCount)
+	for i := 0; i < mfaRecoveryCodeCount; i++ {
+		buf := make([]byte, 5)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		raw := recoveryCodeEncoding.EncodeToString(buf)
+
+		record := schemas.MFARecoveryCode{
+			UserID:   userID,
+			CodeHash: schemas.HashToken(raw),
+		}
+		if err := tx.Create(&record).Error; err != nil {
+			return nil, err
+		}
+		codes = append(codes, raw[:4]+"-"+raw[4:])
+	}
+	return codes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	replacer := strings.NewReplacer("-", "", " ", "")
+	return strings.ToUpper(replacer.Replace(strings.TrimSpace(code)))
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != totp.Digits {
+		return false
+	}
+	for _, ch := range code {
+		if ch < '0' || ch > '9' {
+			return false
+		}
+	}
+	return true
+}
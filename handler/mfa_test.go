@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/Pmmvito/Golang-Api-Exemple/service/totp"
+)
+
+func TestVerifyTOTPCodeRejectsReplay(t *testing.T) {
+	setupTestDB(t)
+	user := createTestUser(t, "ana@example.com")
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+	if err := getDB().Model(user).Updates(map[string]interface{}{"mfa_enabled": true, "mfa_secret": secret}).Error; err != nil {
+		t.Fatalf("erro ativando mfa: %v", err)
+	}
+	user.MFASecret = secret
+
+	step := totp.Step(time.Now())
+	previous, _ := totp.CodeAt(secret, step-1)
+	current, _ := totp.CodeAt(secret, step)
+
+	if ok, err := verifyTOTPCode(getDB(), user, current); err != nil || !ok {
+		t.Fatalf("código atual = %v, %v; esperado aceito", ok, err)
+	}
+	if ok, err := verifyTOTPCode(getDB(), user, current); err != nil || ok {
+		t.Fatalf("reuso do código = %v, %v; esperado recusado", ok, err)
+	}
+	if ok, err := verifyTOTPCode(getDB(), user, previous); err != nil || ok {
+		t.Fatalf("código de janela anterior à aceita = %v, %v; esperado recusado", ok, err)
+	}
+
+	var reloaded schemas.User
+	if err := getDB().First(&reloaded, "id = ?", user.ID).Error; err != nil {
+		t.Fatalf("erro recarregando usuário: %v", err)
+	}
+	if reloaded.MFALastStep < step {
+		t.Fatalf("mfa_last_step = %d, esperado ao menos %d", reloaded.MFALastStep, step)
+	}
+}
+
+func TestVerifyTOTPCodeWithoutSecret(t *testing.T) {
+	user := &schemas.User{}
+	if ok, err := verifyTOTPCode(nil, user, "123456"); err != nil || ok {
+		t.Fatalf("sem segredo = %v, %v; esperado recusado", ok, err)
+	}
+}
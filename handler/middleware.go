@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"strings"
 	"time"
 
@@ -9,6 +10,8 @@ import (
 	"gorm.io/gorm"
 )
 
+var errAccountDisabled = errors.New("conta desativada")
+
 func AuthMiddleware() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		header := ctx.GetHeader("Authorization")
@@ -29,8 +32,13 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		if strings.HasPrefix(token, apiKeyPrefix) {
+			authenticateAPIKey(ctx, token)
+			return
+		}
+
 		session := schemas.Session{}
-		err := getDB().Preload("User.Config").First(&session, "token = ?", token).Error
+		err := getDB().Preload("User.Config").First(&session, "token = ?", schemas.HashToken(token)).Error
 		if err != nil {
 			if err == gorm.ErrRecordNotFound {
 				respondError(ctx, 401, "sessão não encontrada", nil)
@@ -51,8 +59,72 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		if !user.Active {
+			respondError(ctx, 403, errAccountDisabled.Error(), nil)
+			return
+		}
+
+		if err := touchSession(ctx, &session); err != nil {
+			getLogger().WarnF("não foi possível atualizar a sessão: %v", err)
+		}
+		ctx.Header("X-Session-Expires-At", session.ExpiresAt.UTC().Format(time.RFC3339))
+
 		setAuthenticatedUser(ctx, user)
 		setCurrentSession(ctx, &session)
 		ctx.Next()
 	}
 }
+
+// RequireRole restringe a rota aos papéis informados; deve ser usado depois de AuthMiddleware.
+func RequireRole(roles ...schemas.UserRole) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		user, err := getAuthenticatedUser(ctx)
+		if err != nil {
+			respondError(ctx, 401, "não autenticado", nil)
+			return
+		}
+
+		for _, role := range roles {
+			if user.Role == role {
+				ctx.Next()
+				return
+			}
+		}
+
+		respondError(ctx, 403, "acesso negado", nil)
+	}
+}
+
+// RequireSession recusa requisições autenticadas por chave de API; usado nas rotas de conta e administração.
+func RequireSession() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if _, ok := getCurrentAPIKey(ctx); ok {
+			respondError(ctx, 403, "rota indisponível para chaves de API", nil)
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// RequireScope exige o escopo informado quando a requisição usa chave de API. Sessões têm acesso completo.
+func RequireScope(scope schemas.APIKeyScope) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !apiKeyAllows(ctx, scope) {
+			respondInsufficientScope(ctx, scope)
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// apiKeyAllows informa se a requisição pode usar o escopo, para rotas cujo escopo depende do recurso.
+func apiKeyAllows(ctx *gin.Context, scope schemas.APIKeyScope) bool {
+	key, ok := getCurrentAPIKey(ctx)
+	return !ok || key.HasScope(scope)
+}
+
+func respondInsufficientScope(ctx *gin.Context, scope schemas.APIKeyScope) {
+	respondErrorCode(ctx, 403, errorCodeInsufficientScope, "a chave de API não possui o escopo necessário", gin.H{
+		"requiredScope": scope,
+	})
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+)
+
+// callProtected executa a cadeia de middlewares e o handler como o roteador faria para uma requisição com o
+// token informado e devolve o status e o corpo JSON da resposta.
+func callProtected(t *testing.T, token, method string, handlers ...gin.HandlerFunc) (int, map[string]interface{}) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Handle(method, "/protected", append([]gin.HandlerFunc{AuthMiddleware()}, handlers...)...)
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, "/protected", nil)
+	request.Header.Set("Authorization", "Bearer "+token)
+	engine.ServeHTTP(recorder, request)
+
+	response := map[string]interface{}{}
+	json.Unmarshal(recorder.Body.Bytes(), &response)
+	return recorder.Code, response
+}
+
+func respondProtected(ctx *gin.Context) {
+	respondSuccess(ctx, "ok", nil)
+}
+
+func sessionToken(t *testing.T, user *schemas.User) string {
+	t.Helper()
+
+	issued, err := issueTokens(getDB(), user.ID, sessionClient{})
+	if err != nil {
+		t.Fatalf("issueTokens: %v", err)
+	}
+	return issued.AccessToken
+}
+
+func TestRequireRoleRejectsOtherRoles(t *testing.T) {
+	setupTestDB(t)
+	plain := createTestUser(t, "ana@example.com")
+	support := createTestUser(t, "bia@example.com")
+	admin := createTestUser(t, "root@example.com")
+	for user, role := range map[*schemas.User]schemas.UserRole{support: schemas.UserRoleSupport, admin: schemas.UserRoleAdmin} {
+		if err := getDB().Model(user).Update("role", role).Error; err != nil {
+			t.Fatalf("erro definindo papel: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		user *schemas.User
+		want int
+	}{
+		{"usuário comum", plain, 403},
+		{"suporte", support, 200},
+		{"administrador", admin, 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := callProtected(t, sessionToken(t, tt.user), "GET", RequireSession(),
+				RequireRole(schemas.UserRoleSupport, schemas.UserRoleAdmin), respondProtected)
+			if status != tt.want {
+				t.Fatalf("status = %d, esperado %d: %v", status, tt.want, body)
+			}
+		})
+	}
+}
+
+func TestRequireScopeRejectsReadOnlyAPIKey(t *testing.T) {
+	setupTestDB(t)
+	user := createTestUser(t, "ana@example.com")
+
+	status, body := callHandler(t, CreateAPIKeyHandler, user, "POST", "/auth/api-keys",
+		CreateAPIKeyRequest{Name: "planilha", Scopes: []string{string(schemas.APIKeyScopeExpensesRead)}})
+	if status != 200 {
+		t.Fatalf("criação da chave: status = %d: %v", status, body)
+	}
+	apiKey := body["data"].(map[string]interface{})["key"].(string)
+
+	tests := []struct {
+		name     string
+		token    string
+		method   string
+		scope    schemas.APIKeyScope
+		want     int
+		wantCode string
+	}{
+		{"chave lê com o escopo de leitura", apiKey, "GET", schemas.APIKeyScopeExpensesRead, 200, ""},
+		{"chave de leitura não escreve", apiKey, "POST", schemas.APIKeyScopeExpensesWrite, 403, errorCodeInsufficientScope},
+		{"chave sem o escopo de outro recurso", apiKey, "GET", schemas.APIKeyScopeIncomesRead, 403, errorCodeInsufficientScope},
+		{"sessão tem acesso completo", sessionToken(t, user), "POST", schemas.APIKeyScopeExpensesWrite, 200, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := callProtected(t, tt.token, tt.method, RequireScope(tt.scope), respondProtected)
+			if status != tt.want {
+				t.Fatalf("status = %d, esperado %d: %v", status, tt.want, body)
+			}
+			if code, _ := body["code"].(string); code != tt.wantCode {
+				t.Fatalf("code = %q, esperado %q", code, tt.wantCode)
+			}
+			if tt.wantCode != "" {
+				if details, _ := body["details"].(map[string]interface{}); details["requiredScope"] != string(tt.scope) {
+					t.Fatalf("details = %v, esperado requiredScope %s", body["details"], tt.scope)
+				}
+			}
+		})
+	}
+
+	// Rotas de conta e administração não aceitam chaves de API, qualquer que seja o escopo.
+	if status, _ := callProtected(t, apiKey, "GET", RequireSession(), respondProtected); status != 403 {
+		t.Fatalf("rota exclusiva de sessão: status = %d, esperado 403", status)
+	}
+}
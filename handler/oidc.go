@@ -0,0 +1,333 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/Pmmvito/Golang-Api-Exemple/service/oidc"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const oidcProviderGoogle = "google"
+
+var (
+	errOIDCEmailUnverified = errors.New("o provedor não confirmou o email da conta")
+	errLastIdentity        = errors.New("defina uma senha antes de remover o último provedor vinculado")
+)
+
+// idTokenVerifier valida ID tokens de um provedor OpenID Connect.
+type idTokenVerifier interface {
+	Verify(ctx context.Context, rawToken string) (*oidc.Claims, error)
+}
+
+// OIDCLoginHandler godoc
+// @Summary Entrar com provedor externo
+// @Description Valida o ID token do provedor (ex.: google), vincula ou cria o usuário e emite a mesma sessão do login por senha. Contas existentes são vinculadas pelo email quando o provedor o confirma. Se o email da conta ainda não tinha sido verificado, a senha, o segundo fator, as sessões e as chaves de API existentes são descartados antes do vínculo.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provedor" Enums(google)
+// @Param body body OIDCLoginRequest true "ID token"
+// @Success 200 {object} AuthSuccessResponse
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Failure 502 {object} APIError
+// @Router /auth/oidc/{provider} [post]
+func OIDCLoginHandler(ctx *gin.Context) {
+	provider := strings.ToLower(ctx.Param("provider"))
+	verifier, ok := getOIDCVerifier(provider)
+	if !ok {
+		respondError(ctx, 404, "provedor de login não configurado", nil)
+		return
+	}
+
+	var request OIDCLoginRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	claims, err := verifier.Verify(ctx.Request.Context(), request.IDToken)
+	if err != nil {
+		if errors.Is(err, oidc.ErrInvalidToken) || errors.Is(err, oidc.ErrExpiredToken) {
+			respondError(ctx, 401, err.Error(), nil)
+			return
+		}
+		respondError(ctx, 502, "erro ao validar token com o provedor", err.Error())
+		return
+	}
+
+	var user *schemas.User
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		resolved, err := resolveOIDCUser(tx, provider, claims)
+		if err != nil {
+			return err
+		}
+		user = resolved
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, errOIDCEmailUnverified) {
+			respondError(ctx, 403, err.Error(), nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao autenticar com o provedor", err.Error())
+		return
+	}
+
+	if !user.Active {
+		respondError(ctx, 403, errAccountDisabled.Error(), nil)
+		return
+	}
+
+	if user.MFAEnabled {
+		respondMFAChallenge(ctx, user)
+		return
+	}
+
+	startUserSession(ctx, user, request.DeviceName, "login realizado")
+}
+
+// ListIdentitiesHandler godoc
+// @Summary Listar provedores vinculados
+// @Description Lista as contas de provedores externos vinculadas ao usuário autenticado
+// @Tags Auth
+// @Security Bearer
+// @Produce json
+// @Success 200 {object} IdentityListSuccess
+// @Failure 401 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/identities [get]
+func ListIdentitiesHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	var identities []schemas.UserIdentity
+	if err := getDB().Where("user_id = ?", user.ID).Order("created_at ASC").Find(&identities).Error; err != nil {
+		respondError(ctx, 500, "erro ao listar provedores", err.Error())
+		return
+	}
+
+	responses := make([]IdentityResponse, len(identities))
+	for i := range identities {
+		responses[i] = toIdentityResponse(&identities[i])
+	}
+
+	respondSuccess(ctx, "provedores vinculados", responses)
+}
+
+// UnlinkIdentityHandler godoc
+// @Summary Desvincular provedor
+// @Description Remove o vínculo com um provedor externo. Contas sem senha precisam manter ao menos um provedor.
+// @Tags Auth
+// @Security Bearer
+// @Produce json
+// @Param id path string true "Identificador do vínculo"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/identities/{id} [delete]
+func UnlinkIdentityHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	identityID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		identity := schemas.UserIdentity{}
+		if err := tx.Where("id = ? AND user_id = ?", identityID, user.ID).First(&identity).Error; err != nil {
+			return err
+		}
+
+		if user.PasswordHash == "" {
+			var count int64
+			if err := tx.Model(&schemas.UserIdentity{}).Where("user_id = ?", user.ID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count <= 1 {
+				return errLastIdentity
+			}
+		}
+
+		return tx.Unscoped().Delete(&identity).Error
+	})
+	if err != nil {
+		switch {
+		case err == gorm.ErrRecordNotFound:
+			respondError(ctx, 404, "vínculo não encontrado", nil)
+		case errors.Is(err, errLastIdentity):
+			respondError(ctx, 409, err.Error(), nil)
+		default:
+			respondError(ctx, 500, "erro ao desvincular provedor", err.Error())
+		}
+		return
+	}
+
+	respondSuccess(ctx, "provedor desvinculado", nil)
+}
+
+// resolveOIDCUser encontra o usuário pelo vínculo existente, vincula pelo email confirmado
+// ou cria uma conta sem senha com as configurações padrão.
+func resolveOIDCUser(tx *gorm.DB, provider string, claims *oidc.Claims) (*schemas.User, error) {
+	now := time.Now()
+
+	identity := schemas.UserIdentity{}
+	err := tx.Where("provider = ? AND subject = ?", provider, claims.Subject).First(&identity).Error
+	if err == nil {
+		if err := tx.Model(&identity).Updates(map[string]interface{}{
+			"last_login_at": now,
+			"email":         truncateString(strings.ToLower(claims.Email), 180),
+		}).Error; err != nil {
+			return nil, err
+		}
+		user := schemas.User{}
+		if err := tx.Preload("Config").First(&user, "id = ?", identity.UserID).Error; err != nil {
+			return nil, err
+		}
+		return &user, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	email := strings.TrimSpace(strings.ToLower(claims.Email))
+	if email == "" || !claims.EmailVerified {
+		return nil, errOIDCEmailUnverified
+	}
+
+	user := schemas.User{}
+	err = tx.Preload("Config").Where("email = ?", email).First(&user).Error
+	switch {
+	case err == nil:
+		if user.EmailVerifiedAt == nil {
+			if err := claimUnverifiedAccount(tx, &user, now); err != nil {
+				return nil, err
+			}
+		}
+	case err == gorm.ErrRecordNotFound:
+		created, err := createOIDCUser(tx, email, claims.Name, now)
+		if err != nil {
+			return nil, err
+		}
+		user = *created
+	default:
+		return nil, err
+	}
+
+	identity = schemas.UserIdentity{
+		UserID:      user.ID,
+		Provider:    provider,
+		Subject:     claims.Subject,
+		Email:       truncateString(email, 180),
+		LastLoginAt: &now,
+	}
+	if err := tx.Create(&identity).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// claimUnverifiedAccount entrega ao dono do email, comprovado pelo provedor, uma conta cujo endereço nunca foi
+// verificado. Quem a cadastrou pode não ser o dono do email: a senha, o segundo fator, as sessões, as chaves de
+// API e os tokens pendentes dessa pessoa são descartados antes do vínculo, e o acesso por senha passa a depender
+// da redefinição pelo email.
+func claimUnverifiedAccount(tx *gorm.DB, user *schemas.User, now time.Time) error {
+	if err := tx.Model(user).Updates(map[string]interface{}{
+		"email_verified_at": now,
+		"password_hash":     "",
+		"pending_email":     "",
+		"mfa_enabled":       false,
+		"mfa_secret":        "",
+		"mfa_last_step":     0,
+	}).Error; err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("user_id = ?", user.ID).Delete(&schemas.MFARecoveryCode{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&schemas.UserToken{}).
+		Where("user_id = ? AND used_at IS NULL", user.ID).
+		Updates(map[string]interface{}{"used_at": now}).Error; err != nil {
+		return err
+	}
+	if err := invalidateUserSessions(tx, user.ID, ""); err != nil {
+		return err
+	}
+	if err := revokeUserAPIKeys(tx, user.ID); err != nil {
+		return err
+	}
+	if err := promoteVerifiedAdmins(tx, user.ID); err != nil {
+		return err
+	}
+
+	if roleForVerifiedEmail(user.Email) == schemas.UserRoleAdmin {
+		user.Role = schemas.UserRoleAdmin
+	}
+	user.EmailVerifiedAt = &now
+	user.PasswordHash = ""
+	user.PendingEmail = ""
+	user.MFAEnabled = false
+	user.MFASecret = ""
+	user.MFALastStep = 0
+	return nil
+}
+
+func createOIDCUser(tx *gorm.DB, email, name string, verifiedAt time.Time) (*schemas.User, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = strings.SplitN(email, "@", 2)[0]
+	}
+
+	user := schemas.User{
+		Name:            truncateString(name, 120),
+		Email:           email,
+		Active:          true,
+		Role:            roleForVerifiedEmail(email),
+		EmailVerifiedAt: &verifiedAt,
+	}
+	if err := tx.Create(&user).Error; err != nil {
+		return nil, err
+	}
+
+	currency, language, theme := normalizeConfigFields("", "", "")
+	config := schemas.UserConfig{
+		UserID:               user.ID,
+		Currency:             currency,
+		NotificationsEnabled: true,
+		Language:             language,
+		Theme:                schemas.Theme(theme),
+	}
+	if err := tx.Create(&config).Error; err != nil {
+		return nil, err
+	}
+
+	if err := seedDefaultCategories(tx, user.ID, nil); err != nil {
+		return nil, err
+	}
+
+	user.Config = &config
+	return &user, nil
+}
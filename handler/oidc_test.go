@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/Pmmvito/Golang-Api-Exemple/service/oidc"
+	"github.com/google/uuid"
+)
+
+func TestResolveOIDCUserClaimsUnverifiedAccount(t *testing.T) {
+	setupTestDB(t)
+	user := createTestUser(t, "ana@example.com")
+	if err := getDB().Model(user).Updates(map[string]interface{}{
+		"password_hash": "hash-de-quem-cadastrou",
+		"mfa_enabled":   true,
+		"mfa_secret":    "JBSWY3DPEHPK3PXP",
+		"pending_email": "outro@example.com",
+	}).Error; err != nil {
+		t.Fatalf("erro preparando usuário: %v", err)
+	}
+
+	session := schemas.Session{Token: "sessao-de-quem-cadastrou", ID: uuid.New(), UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour), Valid: true}
+	apiKey := schemas.APIKey{UserID: user.ID, Name: "script", Prefix: "fk_teste", KeyHash: "hash-da-chave"}
+	recovery := schemas.MFARecoveryCode{UserID: user.ID, CodeHash: "hash-do-codigo"}
+	for _, record := range []interface{}{&session, &apiKey, &recovery} {
+		if err := getDB().Create(record).Error; err != nil {
+			t.Fatalf("erro criando %T: %v", record, err)
+		}
+	}
+	if _, err := issueUserToken(getDB(), user.ID, schemas.UserTokenEmailChange, time.Hour); err != nil {
+		t.Fatalf("issueUserToken: %v", err)
+	}
+
+	claims := &oidc.Claims{Subject: "google-123", Email: "Ana@Example.com", EmailVerified: true, Name: "Ana"}
+	resolved, err := resolveOIDCUser(getDB(), oidcProviderGoogle, claims)
+	if err != nil {
+		t.Fatalf("resolveOIDCUser: %v", err)
+	}
+	if resolved.ID != user.ID {
+		t.Fatalf("usuário = %s, esperado o existente %s", resolved.ID, user.ID)
+	}
+	if resolved.PasswordHash != "" || resolved.MFAEnabled || resolved.EmailVerifiedAt == nil {
+		t.Fatalf("usuário devolvido não foi limpo: %+v", resolved)
+	}
+
+	var reloaded schemas.User
+	if err := getDB().First(&reloaded, "id = ?", user.ID).Error; err != nil {
+		t.Fatalf("erro recarregando usuário: %v", err)
+	}
+	if reloaded.PasswordHash != "" || reloaded.MFAEnabled || reloaded.MFASecret != "" || reloaded.PendingEmail != "" {
+		t.Fatalf("credenciais de quem cadastrou continuam na conta: %+v", reloaded)
+	}
+	if reloaded.EmailVerifiedAt == nil {
+		t.Fatal("o email deveria ficar verificado")
+	}
+
+	var reloadedSession schemas.Session
+	if err := getDB().First(&reloadedSession, "token = ?", session.Token).Error; err != nil {
+		t.Fatalf("erro recarregando sessão: %v", err)
+	}
+	if reloadedSession.Valid {
+		t.Fatal("a sessão existente deveria ser encerrada")
+	}
+
+	var reloadedKey schemas.APIKey
+	if err := getDB().First(&reloadedKey, "id = ?", apiKey.ID).Error; err != nil {
+		t.Fatalf("erro recarregando chave: %v", err)
+	}
+	if reloadedKey.RevokedAt == nil {
+		t.Fatal("a chave de API existente deveria ser revogada")
+	}
+
+	var pending, codes, identities int64
+	getDB().Model(&schemas.UserToken{}).Where("user_id = ? AND used_at IS NULL", user.ID).Count(&pending)
+	getDB().Model(&schemas.MFARecoveryCode{}).Where("user_id = ?", user.ID).Count(&codes)
+	getDB().Model(&schemas.UserIdentity{}).Where("user_id = ? AND subject = ?", user.ID, claims.Subject).Count(&identities)
+	if pending != 0 || codes != 0 {
+		t.Fatalf("tokens pendentes = %d, códigos de recuperação = %d; esperado nenhum", pending, codes)
+	}
+	if identities != 1 {
+		t.Fatalf("vínculos = %d, esperado 1", identities)
+	}
+}
+
+func TestResolveOIDCUserKeepsVerifiedAccount(t *testing.T) {
+	setupTestDB(t)
+	user := createTestUser(t, "ana@example.com")
+	verifiedAt := time.Now().Add(-24 * time.Hour)
+	if err := getDB().Model(user).Updates(map[string]interface{}{
+		"password_hash":     "hash-da-dona",
+		"email_verified_at": verifiedAt,
+	}).Error; err != nil {
+		t.Fatalf("erro preparando usuário: %v", err)
+	}
+	session := schemas.Session{Token: "sessao-da-dona", ID: uuid.New(), UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour), Valid: true}
+	if err := getDB().Create(&session).Error; err != nil {
+		t.Fatalf("erro criando sessão: %v", err)
+	}
+
+	claims := &oidc.Claims{Subject: "google-123", Email: "ana@example.com", EmailVerified: true}
+	resolved, err := resolveOIDCUser(getDB(), oidcProviderGoogle, claims)
+	if err != nil {
+		t.Fatalf("resolveOIDCUser: %v", err)
+	}
+	if resolved.PasswordHash != "hash-da-dona" {
+		t.Fatal("a senha de uma conta verificada deve ser mantida")
+	}
+
+	var reloadedSession schemas.Session
+	if err := getDB().First(&reloadedSession, "token = ?", session.Token).Error; err != nil {
+		t.Fatalf("erro recarregando sessão: %v", err)
+	}
+	if !reloadedSession.Valid {
+		t.Fatal("as sessões de uma conta verificada devem ser mantidas")
+	}
+
+	again, err := resolveOIDCUser(getDB(), oidcProviderGoogle, claims)
+	if err != nil || again.ID != user.ID {
+		t.Fatalf("login seguinte pelo vínculo = %v, %v", again, err)
+	}
+}
+
+func TestResolveOIDCUserRequiresVerifiedEmail(t *testing.T) {
+	setupTestDB(t)
+	createTestUser(t, "ana@example.com")
+
+	claims := &oidc.Claims{Subject: "google-123", Email: "ana@example.com", EmailVerified: false}
+	if _, err := resolveOIDCUser(getDB(), oidcProviderGoogle, claims); !errors.Is(err, errOIDCEmailUnverified) {
+		t.Fatalf("erro = %v, esperado errOIDCEmailUnverified", err)
+	}
+}
@@ -0,0 +1,375 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// personalDataTable descreve uma tabela com dados do usuário. A posse é direta (OwnerColumn = user_id)
+// ou indireta, por meio do id de uma tabela pai que possui user_id.
+type personalDataTable struct {
+	File        string
+	Model       interface{}
+	OwnerColumn string
+	ParentTable string
+	SoftDelete  bool
+	Export      bool
+}
+
+// personalDataTables lista as tabelas de dados pessoais, filhas antes das pais, na ordem de exclusão.
+// Toda nova entidade pertencente ao usuário deve ser registrada aqui.
+var personalDataTables = []personalDataTable{
+	{File: "recurrence_skips", Model: &schemas.RecurrenceSkip{}, OwnerColumn: "recurrence_rule_id", ParentTable: "recurrence_rules", SoftDelete: true, Export: true},
+	{File: "recurrence_rules", Model: &schemas.RecurrenceRule{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "expense_allocations", Model: &schemas.ExpenseAllocation{}, OwnerColumn: "expense_id", ParentTable: "expenses", SoftDelete: true, Export: true},
+	{File: "expense_items", Model: &schemas.ExpenseItem{}, OwnerColumn: "expense_id", ParentTable: "expenses", SoftDelete: true, Export: true},
+	{File: "receipts", Model: &schemas.Receipt{}, OwnerColumn: "expense_id", ParentTable: "expenses", SoftDelete: true, Export: true},
+	{File: "receipt_drafts", Model: &schemas.ReceiptDraft{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "receipt_jobs", Model: &schemas.ReceiptJob{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "expenses", Model: &schemas.Expense{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "category_rules", Model: &schemas.CategoryRule{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "merchants", Model: &schemas.Merchant{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "installment_purchases", Model: &schemas.InstallmentPurchase{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "incomes", Model: &schemas.Income{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "goal_contributions", Model: &schemas.GoalContribution{}, OwnerColumn: "goal_id", ParentTable: "goals", SoftDelete: true, Export: true},
+	{File: "goals", Model: &schemas.Goal{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "transfers", Model: &schemas.Transfer{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "accounts", Model: &schemas.Account{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "budget_alerts", Model: &schemas.BudgetAlert{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "budgets", Model: &schemas.Budget{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "categories", Model: &schemas.Category{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "meal_items", Model: &schemas.MealItem{}, OwnerColumn: "meal_plan_id", ParentTable: "meal_plans", SoftDelete: true, Export: true},
+	{File: "meal_plans", Model: &schemas.MealPlan{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "tips", Model: &schemas.GeneratedTip{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "sync_jobs", Model: &schemas.SyncJob{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "token_usage", Model: &schemas.TokenUsage{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "sessions", Model: &schemas.Session{}, OwnerColumn: "user_id", Export: true},
+	{File: "api_keys", Model: &schemas.APIKey{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "identities", Model: &schemas.UserIdentity{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "login_attempts", Model: &schemas.LoginAttempt{}, OwnerColumn: "user_id", SoftDelete: true, Export: true},
+	{File: "refresh_tokens", Model: &schemas.RefreshToken{}, OwnerColumn: "user_id", SoftDelete: true},
+	{File: "user_tokens", Model: &schemas.UserToken{}, OwnerColumn: "user_id", SoftDelete: true},
+	{File: "mfa_recovery_codes", Model: &schemas.MFARecoveryCode{}, OwnerColumn: "user_id", SoftDelete: true},
+	{File: "user_config", Model: &schemas.UserConfig{}, OwnerColumn: "user_id"},
+}
+
+func (t personalDataTable) scope(tx *gorm.DB, userID uuid.UUID, includeDeleted bool) *gorm.DB {
+	if t.ParentTable == "" {
+		return tx.Where(t.OwnerColumn+" = ?", userID)
+	}
+	subquery := "SELECT id FROM " + t.ParentTable + " WHERE user_id = ?"
+	if !includeDeleted {
+		subquery += " AND deleted_at IS NULL"
+	}
+	return tx.Where(t.OwnerColumn+" IN ("+subquery+")", userID)
+}
+
+// ExportPersonalDataHandler godoc
+// @Summary Exportar dados pessoais
+// @Description Gera um arquivo ZIP com todos os dados do usuário autenticado em JSON, além de despesas e itens em CSV (portabilidade LGPD)
+// @Tags Auth
+// @Security Bearer
+// @Produce application/zip
+// @Success 200 {file} file
+// @Failure 401 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/me/export [get]
+func ExportPersonalDataHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	archive, err := buildPersonalDataExport(getDB().WithContext(ctx.Request.Context()), user.ID)
+	if err != nil {
+		respondError(ctx, 500, "erro ao exportar dados", err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("dados-%s.zip", time.Now().Format("20060102"))
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Data(200, "application/zip", archive)
+}
+
+// DeleteAccountHandler godoc
+// @Summary Excluir conta
+// @Description Agenda a exclusão definitiva da conta e de todos os dados após o período de carência (ACCOUNT_DELETION_GRACE_DAYS), encerrando as demais sessões. Com carência zero a exclusão é imediata. Exige a senha e, com segundo fator habilitado, um código.
+// @Tags Auth
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body AccountDeletionRequest true "Confirmação"
+// @Success 200 {object} AccountDeletionSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/me [delete]
+func DeleteAccountHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	var request AccountDeletionRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	if user.DeletionDueAt != nil {
+		respondError(ctx, 409, "exclusão já agendada", nil)
+		return
+	}
+
+	if !checkCurrentPassword(user, request.Password) {
+		respondError(ctx, 403, "senha incorreta", nil)
+		return
+	}
+	if user.MFAEnabled && request.Code == "" {
+		respondError(ctx, 400, "código do segundo fator é obrigatório", nil)
+		return
+	}
+
+	keepSession := ""
+	if session, ok := getCurrentSession(ctx); ok {
+		keepSession = session.Token
+	}
+
+	grace := getAccountDeletionGrace()
+	dueAt := time.Now().Add(grace)
+
+	var imageKeys []string
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		if user.MFAEnabled {
+			valid, err := verifySecondFactor(tx, user, request.Code)
+			if err != nil {
+				return err
+			}
+			if !valid {
+				return errMFACodeInvalid
+			}
+		}
+
+		if grace == 0 {
+			imageKeys, err = deleteUserData(tx, user)
+			return err
+		}
+
+		if err := tx.Model(&schemas.User{}).
+			Where("id = ?", user.ID).
+			Updates(map[string]interface{}{"deletion_due_at": dueAt}).Error; err != nil {
+			return err
+		}
+		return invalidateUserSessions(tx, user.ID, keepSession)
+	})
+	if err != nil {
+		if errors.Is(err, errMFACodeInvalid) {
+			respondError(ctx, 400, err.Error(), nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao excluir conta", err.Error())
+		return
+	}
+
+	if grace == 0 {
+		deleteReceiptImages(ctx.Request.Context(), imageKeys)
+		if err := getLoginAttemptStore().Reset(ctx.Request.Context(), loginEmailKey(user.Email)); err != nil {
+			getLogger().WarnF("não foi possível limpar tentativas de login: %v", err)
+		}
+		respondSuccess(ctx, "conta excluída", nil)
+		return
+	}
+
+	if err := sendAccountDeletionEmail(ctx.Request.Context(), user, dueAt); err != nil {
+		getLogger().WarnF("não foi possível avisar sobre a exclusão da conta: %v", err)
+	}
+
+	respondSuccess(ctx, "exclusão da conta agendada", AccountDeletionResponse{DeletionDueAt: dueAt})
+}
+
+// CancelAccountDeletionHandler godoc
+// @Summary Cancelar exclusão da conta
+// @Description Cancela a exclusão agendada enquanto o período de carência não terminou
+// @Tags Auth
+// @Security Bearer
+// @Produce json
+// @Success 200 {object} APISuccess
+// @Failure 401 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/me/deletion/cancel [post]
+func CancelAccountDeletionHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	if user.DeletionDueAt == nil {
+		respondError(ctx, 409, "nenhuma exclusão agendada", nil)
+		return
+	}
+
+	if err := getDB().Model(&schemas.User{}).
+		Where("id = ?", user.ID).
+		Updates(map[string]interface{}{"deletion_due_at": nil}).Error; err != nil {
+		respondError(ctx, 500, "erro ao cancelar exclusão", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "exclusão cancelada", nil)
+}
+
+// buildPersonalDataExport monta o ZIP com um JSON por tabela e CSVs das despesas.
+func buildPersonalDataExport(tx *gorm.DB, userID uuid.UUID) ([]byte, error) {
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+
+	user := schemas.User{}
+	if err := tx.Preload("Config").First(&user, "id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	if err := writeZipJSON(archive, "profile.json", user); err != nil {
+		return nil, err
+	}
+
+	for _, table := range personalDataTables {
+		if !table.Export {
+			continue
+		}
+		rows := reflect.New(reflect.SliceOf(reflect.TypeOf(table.Model).Elem()))
+		if err := table.scope(tx.Model(table.Model), userID, false).Find(rows.Interface()).Error; err != nil {
+			return nil, fmt.Errorf("%s: %w", table.File, err)
+		}
+		if err := writeZipJSON(archive, table.File+".json", rows.Elem().Interface()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writeExpensesCSV(archive, tx, userID); err != nil {
+		return nil, err
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func writeZipJSON(archive *zip.Writer, name string, value interface{}) error {
+	writer, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func writeExpensesCSV(archive *zip.Writer, tx *gorm.DB, userID uuid.UUID) error {
+	var expenses []schemas.Expense
+	if err := tx.Preload("Category").Preload("Items").
+		Where("user_id = ?", userID).
+		Order("date ASC").
+		Find(&expenses).Error; err != nil {
+		return err
+	}
+
+	expenseWriter, err := archive.Create("expenses.csv")
+	if err != nil {
+		return err
+	}
+	expenseCSV := csv.NewWriter(expenseWriter)
+	expenseCSV.Write([]string{"id", "date", "description", "amount", "category", "recurring", "origin"})
+
+	itemRows := [][]string{{"expense_id", "name", "quantity", "unit_price", "total_price", "category"}}
+	for _, expense := range expenses {
+		categoryName := ""
+		if expense.Category != nil {
+			categoryName = expense.Category.Name
+		}
+		expenseCSV.Write([]string{
+			expense.ID.String(),
+			expense.Date.Format("2006-01-02"),
+			expense.Description,
+			strconv.FormatFloat(expense.Amount, 'f', 2, 64),
+			categoryName,
+			strconv.FormatBool(expense.Recurring),
+			string(expense.Origin),
+		})
+		for _, item := range expense.Items {
+			itemRows = append(itemRows, []string{
+				expense.ID.String(),
+				item.Name,
+				strconv.FormatFloat(item.Quantity, 'f', -1, 64),
+				strconv.FormatFloat(item.UnitPrice, 'f', 2, 64),
+				strconv.FormatFloat(item.TotalPrice, 'f', 2, 64),
+				item.CategoryTag,
+			})
+		}
+	}
+	expenseCSV.Flush()
+	if err := expenseCSV.Error(); err != nil {
+		return err
+	}
+
+	itemWriter, err := archive.Create("expense_items.csv")
+	if err != nil {
+		return err
+	}
+	itemCSV := csv.NewWriter(itemWriter)
+	return itemCSV.WriteAll(itemRows)
+}
+
+// deleteUserData remove definitivamente o usuário e todas as linhas que ele possui, inclusive as excluídas logicamente.
+// Devolve as chaves das imagens de recibo, que só devem ser apagadas do armazenamento depois do commit.
+func deleteUserData(tx *gorm.DB, user *schemas.User) ([]string, error) {
+	if err := leaveHouseholds(tx, user.ID); err != nil {
+		return nil, fmt.Errorf("households: %w", err)
+	}
+
+	imageKeys, err := userReceiptImageKeys(tx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("receipt images: %w", err)
+	}
+
+	for _, table := range personalDataTables {
+		if err := table.scope(tx.Unscoped(), user.ID, true).Delete(table.Model).Error; err != nil {
+			return nil, fmt.Errorf("%s: %w", table.File, err)
+		}
+	}
+
+	if err := tx.Where("key = ?", loginEmailKey(user.Email)).Delete(&schemas.LoginThrottle{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Unscoped().Where("id = ?", user.ID).Delete(&schemas.User{}).Error; err != nil {
+		return nil, err
+	}
+	return imageKeys, nil
+}
+
+func sendAccountDeletionEmail(ctx context.Context, user *schemas.User, dueAt time.Time) error {
+	body := fmt.Sprintf("Olá, %s!\n\nRecebemos o pedido de exclusão da sua conta. Ela e todos os seus dados serão apagados definitivamente em %s.\n\nPara cancelar, entre no app antes dessa data e desfaça a exclusão.\n",
+		user.Name, dueAt.Format("02/01/2006"))
+	return sendMail(ctx, user.Email, "Exclusão da conta agendada", body)
+}
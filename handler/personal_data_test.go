@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/Pmmvito/Golang-Api-Exemple/service/storage"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func createTestReceipt(t *testing.T, store *storage.MemoryStore, user *schemas.User) string {
+	t.Helper()
+
+	expense := schemas.Expense{UserID: user.ID, CategoryID: uuid.New(), Description: "mercado", Amount: 42}
+	if err := getDB().Create(&expense).Error; err != nil {
+		t.Fatalf("erro criando despesa: %v", err)
+	}
+	key := receiptImageKey(user.ID, "image/png")
+	if err := store.Put(context.Background(), key, bytes.NewReader(pngHeader)); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := getDB().Create(&schemas.Receipt{ExpenseID: expense.ID, FilePath: key, ContentType: "image/png"}).Error; err != nil {
+		t.Fatalf("erro criando recibo: %v", err)
+	}
+	return key
+}
+
+func TestDeleteUserDataKeepsImagesUntilCommit(t *testing.T) {
+	setupTestDB(t)
+	store := useReceiptLimits(t, 1<<20)
+	user := createTestUser(t, "ana@example.com")
+	key := createTestReceipt(t, store, user)
+
+	errRollback := errors.New("falha depois da exclusão")
+	err := getDB().Transaction(func(tx *gorm.DB) error {
+		keys, err := deleteUserData(tx, user)
+		if err != nil {
+			return err
+		}
+		if len(keys) != 1 || keys[0] != key {
+			t.Fatalf("chaves = %v, esperado [%s]", keys, key)
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("Transaction = %v", err)
+	}
+	if _, _, err := store.Get(context.Background(), key); err != nil {
+		t.Fatalf("a imagem deveria continuar após o rollback: %v", err)
+	}
+	var receipts int64
+	getDB().Model(&schemas.Receipt{}).Count(&receipts)
+	if receipts != 1 {
+		t.Fatalf("recibos = %d, esperado 1 após o rollback", receipts)
+	}
+}
+
+func TestPurgeExpiredDataDeletesReceiptImages(t *testing.T) {
+	setupTestDB(t)
+	store := useReceiptLimits(t, 1<<20)
+	user := createTestUser(t, "ana@example.com")
+	other := createTestUser(t, "bia@example.com")
+	key := createTestReceipt(t, store, user)
+	otherKey := createTestReceipt(t, store, other)
+	if err := getDB().Model(user).Update("deletion_due_at", time.Now().Add(-time.Hour)).Error; err != nil {
+		t.Fatalf("erro agendando exclusão: %v", err)
+	}
+
+	if err := purgeExpiredData(context.Background()); err != nil {
+		t.Fatalf("purgeExpiredData: %v", err)
+	}
+
+	var users int64
+	getDB().Unscoped().Model(&schemas.User{}).Where("id = ?", user.ID).Count(&users)
+	if users != 0 {
+		t.Fatal("a conta vencida deveria ser excluída")
+	}
+	if _, _, err := store.Get(context.Background(), key); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("a imagem da conta excluída deveria ser apagada: %v", err)
+	}
+	if _, _, err := store.Get(context.Background(), otherKey); err != nil {
+		t.Fatalf("a imagem de outra conta deveria ser mantida: %v", err)
+	}
+}
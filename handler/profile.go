@@ -0,0 +1,319 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var errEmailTaken = errors.New("email já cadastrado")
+
+// UpdateProfileHandler godoc
+// @Summary Atualizar perfil
+// @Description Atualiza nome e/ou email do usuário autenticado. A troca de email exige a senha atual e só é aplicada após a confirmação pelo link enviado ao novo endereço.
+// @Tags Auth
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body UpdateProfileRequest true "Campos do perfil"
+// @Success 200 {object} UserProfileSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/me [patch]
+func UpdateProfileHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	var request UpdateProfileRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	request.Normalize()
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	emailChange := request.Email != nil && *request.Email != user.Email
+	if emailChange {
+		if user.PasswordHash != "" && request.CurrentPassword == "" {
+			respondError(ctx, 400, "senha atual é obrigatória para trocar o email", nil)
+			return
+		}
+		if !checkCurrentPassword(user, request.CurrentPassword) {
+			respondError(ctx, 403, "senha incorreta", nil)
+			return
+		}
+
+		var count int64
+		if err := getDB().Model(&schemas.User{}).Where("email = ?", *request.Email).Count(&count).Error; err != nil {
+			respondError(ctx, 500, "erro ao verificar email", err.Error())
+			return
+		}
+		if count > 0 {
+			respondError(ctx, 409, errEmailTaken.Error(), nil)
+			return
+		}
+	}
+
+	var changeToken string
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{}
+		if request.Name != nil {
+			updates["name"] = *request.Name
+		}
+
+		switch {
+		case emailChange:
+			updates["pending_email"] = *request.Email
+			token, err := issueUserToken(tx, user.ID, schemas.UserTokenEmailChange, emailVerificationTTL)
+			if err != nil {
+				return err
+			}
+			changeToken = token
+		case request.Email != nil && user.PendingEmail != "":
+			// voltar ao email atual cancela a troca pendente
+			updates["pending_email"] = ""
+			if err := tx.Model(&schemas.UserToken{}).
+				Where("user_id = ? AND purpose = ? AND used_at IS NULL", user.ID, schemas.UserTokenEmailChange).
+				Updates(map[string]interface{}{"used_at": time.Now()}).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(updates) == 0 {
+			return nil
+		}
+		return tx.Model(&schemas.User{}).Where("id = ?", user.ID).Updates(updates).Error
+	})
+	if err != nil {
+		respondError(ctx, 500, "erro ao atualizar perfil", err.Error())
+		return
+	}
+
+	updated, err := reloadUser(user.ID)
+	if err != nil {
+		respondError(ctx, 500, "erro ao carregar usuário", err.Error())
+		return
+	}
+
+	if !emailChange {
+		respondSuccess(ctx, "perfil atualizado", toUserResponse(updated))
+		return
+	}
+
+	if err := sendEmailChangeEmail(ctx.Request.Context(), updated, changeToken); err != nil {
+		getLogger().WarnF("não foi possível enviar confirmação de troca de email: %v", err)
+	}
+	if err := sendEmailChangeNotice(ctx.Request.Context(), updated); err != nil {
+		getLogger().WarnF("não foi possível avisar sobre a troca de email: %v", err)
+	}
+
+	respondSuccess(ctx, "perfil atualizado, confirme o novo email para concluir a troca", toUserResponse(updated))
+}
+
+// UpdateConfigHandler godoc
+// @Summary Atualizar configurações
+// @Description Substitui as preferências do usuário (moeda, limite mensal, idioma, tema e notificações) com as mesmas regras do cadastro
+// @Tags Auth
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body UpdateConfigRequest true "Preferências"
+// @Success 200 {object} UserProfileSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/me/config [put]
+func UpdateConfigHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	var request UpdateConfigRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	request.Normalize()
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	config := schemas.UserConfig{UserID: user.ID}
+	if user.Config != nil {
+		config = *user.Config
+	}
+
+	config.Currency = request.Currency
+	config.MonthlyLimit = 0
+	if request.MonthlyLimit != nil {
+		config.MonthlyLimit = *request.MonthlyLimit
+	}
+	config.Language = request.Language
+	config.Theme = schemas.Theme(request.Theme)
+	config.NotificationsEnabled = true
+	if request.NotificationsEnabled != nil {
+		config.NotificationsEnabled = *request.NotificationsEnabled
+	}
+
+	if err := getDB().Save(&config).Error; err != nil {
+		respondError(ctx, 500, "erro ao salvar configurações", err.Error())
+		return
+	}
+
+	user.Config = &config
+	respondSuccess(ctx, "configurações atualizadas", toUserResponse(user))
+}
+
+// ChangePasswordHandler godoc
+// @Summary Alterar senha
+// @Description Altera a senha mediante a senha atual e encerra todas as outras sessões do usuário. Contas criadas por provedor externo definem a primeira senha sem informar a atual.
+// @Tags Auth
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param body body ChangePasswordRequest true "Senha atual e nova senha"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/me/password [post]
+func ChangePasswordHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	var request ChangePasswordRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	if !checkCurrentPassword(user, request.CurrentPassword) {
+		respondError(ctx, 403, "senha atual incorreta", nil)
+		return
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(request.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		respondError(ctx, 500, "erro ao gerar hash de senha", err.Error())
+		return
+	}
+
+	keepSession := ""
+	if session, ok := getCurrentSession(ctx); ok {
+		keepSession = session.Token
+	}
+
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&schemas.User{}).
+			Where("id = ?", user.ID).
+			Updates(map[string]interface{}{"password_hash": string(passwordHash)}).Error; err != nil {
+			return err
+		}
+
+		// links de redefinição emitidos antes da troca deixam de valer
+		if err := tx.Model(&schemas.UserToken{}).
+			Where("user_id = ? AND purpose = ? AND used_at IS NULL", user.ID, schemas.UserTokenPasswordReset).
+			Updates(map[string]interface{}{"used_at": time.Now()}).Error; err != nil {
+			return err
+		}
+
+		return invalidateUserSessions(tx, user.ID, keepSession)
+	})
+	if err != nil {
+		respondError(ctx, 500, "erro ao alterar senha", err.Error())
+		return
+	}
+	user.PasswordHash = string(passwordHash)
+
+	if err := sendPasswordChangedEmail(ctx.Request.Context(), user); err != nil {
+		getLogger().WarnF("não foi possível avisar sobre a troca de senha: %v", err)
+	}
+
+	respondSuccess(ctx, "senha alterada", nil)
+}
+
+// checkCurrentPassword confere a senha atual. Contas criadas por provedor externo não têm senha
+// e são confirmadas apenas pela sessão autenticada.
+func checkCurrentPassword(user *schemas.User, password string) bool {
+	if user.PasswordHash == "" {
+		return true
+	}
+	return bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) == nil
+}
+
+// applyPendingEmail efetiva a troca de email confirmada pelo usuário.
+func applyPendingEmail(tx *gorm.DB, userID uuid.UUID) error {
+	user := schemas.User{}
+	if err := tx.First(&user, "id = ?", userID).Error; err != nil {
+		return err
+	}
+	if user.PendingEmail == "" {
+		return errUserTokenInvalid
+	}
+
+	var count int64
+	if err := tx.Model(&schemas.User{}).
+		Where("email = ? AND id <> ?", user.PendingEmail, user.ID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errEmailTaken
+	}
+
+	return tx.Model(&user).Updates(map[string]interface{}{
+		"email":             user.PendingEmail,
+		"pending_email":     "",
+		"email_verified_at": time.Now(),
+	}).Error
+}
+
+func reloadUser(userID uuid.UUID) (*schemas.User, error) {
+	user := schemas.User{}
+	if err := getDB().Preload("Config").First(&user, "id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func sendEmailChangeEmail(ctx context.Context, user *schemas.User, rawToken string) error {
+	body := fmt.Sprintf("Olá, %s!\n\nConfirme seu novo email acessando o link abaixo:\n%s\n\nO link expira em %d horas. Até a confirmação, o email atual continua valendo.\n",
+		user.Name, buildAppLink("/verify-email", rawToken), int(emailVerificationTTL.Hours()))
+	return sendMail(ctx, user.PendingEmail, "Confirme seu novo email", body)
+}
+
+func sendEmailChangeNotice(ctx context.Context, user *schemas.User) error {
+	body := fmt.Sprintf("Olá, %s!\n\nRecebemos um pedido para trocar o email da sua conta para %s. Se você não fez esse pedido, altere sua senha imediatamente.\n",
+		user.Name, user.PendingEmail)
+	return sendMail(ctx, user.Email, "Pedido de troca de email", body)
+}
+
+func sendPasswordChangedEmail(ctx context.Context, user *schemas.User) error {
+	body := fmt.Sprintf("Olá, %s!\n\nA senha da sua conta foi alterada e as sessões em outros dispositivos foram encerradas. Se não foi você, use a opção \"esqueci minha senha\".\n",
+		user.Name)
+	return sendMail(ctx, user.Email, "Sua senha foi alterada", body)
+}
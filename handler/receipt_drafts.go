@@ -0,0 +1,479 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+var (
+	errReceiptDraftCategory = errors.New("a categoria do rascunho não está mais disponível")
+	errReceiptDraftAmount   = errors.New("informe o valor do rascunho antes de confirmar")
+)
+
+// ListReceiptDraftsHandler godoc
+// @Summary Listar rascunhos de recibo
+// @Description Lista os recibos processados que aguardam revisão no escopo atual, do mais recente para o mais antigo
+// @Tags Recibos
+// @Security Bearer
+// @Produce json
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} ReceiptDraftListSuccess
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /receipts/drafts [get]
+func ListReceiptDraftsHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	var drafts []schemas.ReceiptDraft
+	if err := getDataScope(ctx, user).apply(getDB().Preload("Category"), "receipt_drafts").
+		Order("created_at DESC").
+		Find(&drafts).Error; err != nil {
+		respondError(ctx, 500, "erro ao listar rascunhos", err.Error())
+		return
+	}
+
+	responses := make([]ReceiptDraftResponse, len(drafts))
+	for i := range drafts {
+		responses[i] = *toReceiptDraftResponse(&drafts[i])
+	}
+
+	respondSuccess(ctx, "rascunhos de recibo", responses)
+}
+
+// GetReceiptDraftHandler godoc
+// @Summary Detalhar rascunho de recibo
+// @Description Retorna um rascunho de recibo com os itens extraídos e a categoria sugerida
+// @Tags Recibos
+// @Security Bearer
+// @Produce json
+// @Param id path string true "Identificador do rascunho"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} ReceiptDraftSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /receipts/drafts/{id} [get]
+func GetReceiptDraftHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	draftID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	draft, err := loadReceiptDraft(getDB(), getDataScope(ctx, user), draftID)
+	if err != nil {
+		respondReceiptDraftLoadError(ctx, err)
+		return
+	}
+
+	respondSuccess(ctx, "rascunho de recibo", toReceiptDraftResponse(draft))
+}
+
+// UpdateReceiptDraftHandler godoc
+// @Summary Editar rascunho de recibo
+// @Description Corrige o estabelecimento (nome, CNPJ e endereço), a data, o valor, a categoria ou os itens extraídos antes da confirmação. Os itens informados substituem os atuais; envie categoryId vazio para remover a sugestão.
+// @Tags Recibos
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "Identificador do rascunho"
+// @Param body body UpdateReceiptDraftRequest true "Campos para atualização"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} ReceiptDraftSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /receipts/drafts/{id} [put]
+func UpdateReceiptDraftHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	draftID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	var request UpdateReceiptDraftRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	draft, err := loadReceiptDraft(getDB(), scope, draftID)
+	if err != nil {
+		respondReceiptDraftLoadError(ctx, err)
+		return
+	}
+
+	updates := map[string]interface{}{}
+	if request.Merchant != nil {
+		updates["merchant"] = *request.Merchant
+	}
+	if request.MerchantCNPJ != nil {
+		updates["merchant_cnpj"] = strings.TrimSpace(*request.MerchantCNPJ)
+	}
+	if request.MerchantAddress != nil {
+		updates["merchant_address"] = *request.MerchantAddress
+	}
+	if request.Date != nil {
+		date, err := parseDate(*request.Date)
+		if err != nil {
+			respondError(ctx, 400, "data inválida", nil)
+			return
+		}
+		updates["date"] = date
+	}
+	if request.Amount != nil {
+		updates["amount"] = roundFloat(*request.Amount)
+	}
+	if request.CategoryID != nil {
+		if strings.TrimSpace(*request.CategoryID) == "" {
+			updates["category_id"] = nil
+		} else {
+			categoryID, err := uuid.Parse(*request.CategoryID)
+			if err != nil {
+				respondError(ctx, 400, "categoryId inválido", nil)
+				return
+			}
+			if !categoryInScope(getDB(), scope, categoryID, schemas.CategoryKindExpense) {
+				respondError(ctx, 403, "categoria não pertence ao usuário", nil)
+				return
+			}
+			updates["category_id"] = categoryID
+		}
+	}
+	if request.Items != nil {
+		for _, item := range *request.Items {
+			if strings.TrimSpace(item.CategoryID) == "" {
+				continue
+			}
+			categoryID, err := uuid.Parse(strings.TrimSpace(item.CategoryID))
+			if err != nil {
+				respondError(ctx, 400, "categoryId de item inválido", nil)
+				return
+			}
+			if !categoryInScope(getDB(), scope, categoryID, schemas.CategoryKindExpense) {
+				respondError(ctx, 403, "categoria não pertence ao usuário", nil)
+				return
+			}
+		}
+		items, err := encodeReceiptDraftItems(*request.Items)
+		if err != nil {
+			respondError(ctx, 500, "erro ao salvar itens", err.Error())
+			return
+		}
+		updates["items"] = items
+	}
+
+	if err := getDB().Model(draft).Updates(updates).Error; err != nil {
+		respondError(ctx, 500, "erro ao atualizar rascunho", err.Error())
+		return
+	}
+
+	draft, err = loadReceiptDraft(getDB(), scope, draftID)
+	if err != nil {
+		respondReceiptDraftLoadError(ctx, err)
+		return
+	}
+
+	respondSuccess(ctx, "rascunho atualizado", toReceiptDraftResponse(draft))
+}
+
+// ConfirmReceiptDraftHandler godoc
+// @Summary Confirmar rascunho de recibo
+// @Description Registra o rascunho como despesa de origem OCR, com os itens, o texto extraído, a imagem original e o estabelecimento, e remove o rascunho. Sem categoria definida, a despesa vai para a categoria "Compras OCR"; sem estabelecimento, recebe uma descrição genérica.
+// @Tags Recibos
+// @Security Bearer
+// @Produce json
+// @Param id path string true "Identificador do rascunho"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} ExpenseItemSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /receipts/drafts/{id}/confirm [post]
+func ConfirmReceiptDraftHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	draftID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	var expense *schemas.Expense
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		draft, err := loadReceiptDraft(tx, scope, draftID)
+		if err != nil {
+			return err
+		}
+		if draft.Amount <= 0 {
+			return errReceiptDraftAmount
+		}
+		if draft.CategoryID != nil && !categoryInScope(tx, scope, *draft.CategoryID, schemas.CategoryKindExpense) {
+			return errReceiptDraftCategory
+		}
+		// O rascunho sai antes de a despesa ser criada: de duas confirmações simultâneas, só a que o removeu segue.
+		// A imagem passa a pertencer ao recibo da despesa, e a remoção definitiva evita que seja expurgada com ele.
+		if err := removeReceiptDraft(tx, draft); err != nil {
+			return err
+		}
+
+		data := receiptExpense{
+			CategoryID:      draft.CategoryID,
+			Merchant:        draft.Merchant,
+			MerchantCNPJ:    draft.MerchantCNPJ,
+			MerchantAddress: draft.MerchantAddress,
+			Date:            draft.Date,
+			Amount:          draft.Amount,
+			Items:           decodeReceiptDraftItems(draft.Items),
+			ExtractedText:   draft.ExtractedText,
+			Confidence:      draft.OcrConfidence,
+		}
+		if draft.ContentType != "" {
+			data.Image = &receiptImage{Key: draft.ImageKey, ContentType: draft.ContentType}
+		}
+		expense, err = createReceiptExpense(ctx.Request.Context(), tx, user, scope, data)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, errReceiptDraftCategory) || errors.Is(err, errReceiptDraftAmount) {
+			respondError(ctx, 400, err.Error(), nil)
+			return
+		}
+		respondReceiptDraftLoadError(ctx, err)
+		return
+	}
+	checkBudgetAlerts(ctx.Request.Context(), scope, expense.Date)
+
+	recorded, err := loadExpenseForResponse(ctx.Request.Context(), expense.ID)
+	if err != nil {
+		respondError(ctx, 500, "erro ao carregar despesa", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "rascunho confirmado", toExpenseResponse(recorded))
+}
+
+// DiscardReceiptDraftHandler godoc
+// @Summary Descartar rascunho de recibo
+// @Description Remove o rascunho e a imagem do recibo sem registrar despesa
+// @Tags Recibos
+// @Security Bearer
+// @Param id path string true "Identificador do rascunho"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /receipts/drafts/{id} [delete]
+func DiscardReceiptDraftHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	draftID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	draft, err := loadReceiptDraft(getDB(), scope, draftID)
+	if err != nil {
+		respondReceiptDraftLoadError(ctx, err)
+		return
+	}
+	if err := removeReceiptDraft(getDB(), draft); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// confirmado ou descartado por outra requisição; a imagem pode já pertencer a uma despesa
+			respondReceiptDraftLoadError(ctx, err)
+			return
+		}
+		respondError(ctx, 500, "erro ao descartar rascunho", err.Error())
+		return
+	}
+	if draft.ImageKey != "" {
+		deleteReceiptImages(ctx.Request.Context(), []string{draft.ImageKey})
+	}
+
+	respondSuccess(ctx, "rascunho descartado", nil)
+}
+
+// createReceiptDraft guarda o resultado do scan como rascunho, com as categorias sugeridas pelo categorizer. Quando
+// o recibo vem de um job, o job é vinculado ao rascunho na mesma transação, como em persistReceiptData.
+func createReceiptDraft(ctx context.Context, user *schemas.User, scope dataScope, payload *ReceiptScanResponse, rawModel string, image *receiptImage, jobID *uuid.UUID) (*schemas.ReceiptDraft, error) {
+	date, err := time.Parse("2006-01-02", payload.SuggestedDate)
+	if err != nil {
+		date = time.Now()
+	}
+
+	extractedText := strings.TrimSpace(payload.ExtractedText)
+	if extractedText == "" {
+		extractedText = strings.TrimSpace(rawModel)
+	}
+
+	items, err := encodeReceiptDraftItems(payload.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	db := getDB().WithContext(ctx)
+	draft := schemas.ReceiptDraft{
+		UserID:          user.ID,
+		HouseholdID:     scope.HouseholdID,
+		Merchant:        payload.Merchant,
+		MerchantCNPJ:    normalizeCNPJ(payload.MerchantCNPJ),
+		MerchantAddress: truncateString(payload.MerchantAddress, 255),
+		Date:            date,
+		Amount:          roundFloat(max(payload.SuggestedAmount, 0)),
+		Currency:        payload.Currency,
+		CategoryID:      parseOptionalUUID(payload.CategoryID),
+		Items:           items,
+		ExtractedText:   extractedText,
+		OcrConfidence:   payload.Confidence,
+	}
+	if image != nil {
+		draft.ImageKey = image.Key
+		draft.ContentType = image.ContentType
+	}
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&draft).Error; err != nil {
+			return err
+		}
+		return markReceiptJobSaved(tx, jobID, "draft_id", draft.ID)
+	}); err != nil {
+		return nil, err
+	}
+	return loadReceiptDraft(db, scope, draft.ID)
+}
+
+func loadReceiptDraft(db *gorm.DB, scope dataScope, id uuid.UUID) (*schemas.ReceiptDraft, error) {
+	draft := schemas.ReceiptDraft{}
+	if err := scope.apply(db.Preload("Category"), "receipt_drafts").Where("id = ?", id).First(&draft).Error; err != nil {
+		return nil, err
+	}
+	return &draft, nil
+}
+
+// removeReceiptDraft apaga o rascunho em definitivo e devolve gorm.ErrRecordNotFound quando outra requisição já
+// o removeu.
+func removeReceiptDraft(tx *gorm.DB, draft *schemas.ReceiptDraft) error {
+	result := tx.Unscoped().Where("deleted_at IS NULL").Delete(draft)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func respondReceiptDraftLoadError(ctx *gin.Context, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		respondError(ctx, 404, "rascunho não encontrado", nil)
+		return
+	}
+	respondError(ctx, 500, "erro ao carregar rascunho", err.Error())
+}
+
+func encodeReceiptDraftItems(items []ReceiptItem) (datatypes.JSON, error) {
+	cleaned := make([]ReceiptItem, 0, len(items))
+	for _, item := range items {
+		cleaned = append(cleaned, ReceiptItem{
+			Description: strings.TrimSpace(item.Description),
+			Quantity:    roundFloat(item.Quantity),
+			UnitPrice:   roundFloat(item.UnitPrice),
+			Total:       roundFloat(item.Total),
+			CategoryID:  strings.TrimSpace(item.CategoryID),
+		})
+	}
+	encoded, err := json.Marshal(cleaned)
+	return datatypes.JSON(encoded), err
+}
+
+func decodeReceiptDraftItems(raw datatypes.JSON) []ReceiptItem {
+	items := []ReceiptItem{}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &items); err != nil {
+			getLogger().WarnF("itens de rascunho inválidos: %v", err)
+		}
+	}
+	return items
+}
+
+func toReceiptDraftResponse(draft *schemas.ReceiptDraft) *ReceiptDraftResponse {
+	return &ReceiptDraftResponse{
+		ID:              draft.ID.String(),
+		HouseholdID:     uuidPtrString(draft.HouseholdID),
+		CreatedBy:       draft.UserID.String(),
+		Merchant:        draft.Merchant,
+		MerchantCNPJ:    formatCNPJ(draft.MerchantCNPJ),
+		MerchantAddress: draft.MerchantAddress,
+		Date:            draft.Date.Format("2006-01-02"),
+		Amount:          draft.Amount,
+		Currency:        draft.Currency,
+		CategoryID:      uuidPtrString(draft.CategoryID),
+		Category:        toCategoryResponse(draft.Category),
+		Items:           decodeReceiptDraftItems(draft.Items),
+		ExtractedText:   draft.ExtractedText,
+		OcrConfidence:   draft.OcrConfidence,
+		HasImage:        draft.ContentType != "",
+		CreatedAt:       draft.CreatedAt,
+	}
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/Pmmvito/Golang-Api-Exemple/service/storage"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// useGeminiResult configura o Gemini para responder com o resultado informado durante o teste.
+func useGeminiResult(t *testing.T, result receiptLLMResult) {
+	t.Helper()
+
+	text, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("erro codificando resultado: %v", err)
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"candidates": []interface{}{
+			map[string]interface{}{"content": map[string]interface{}{"parts": []interface{}{map[string]string{"text": string(text)}}}},
+		},
+		"usageMetadata": map[string]int64{"promptTokenCount": 10, "candidatesTokenCount": 5, "totalTokenCount": 15},
+	})
+	if err != nil {
+		t.Fatalf("erro codificando resposta: %v", err)
+	}
+
+	t.Setenv("GEMINI_API_KEY", "test-key")
+	previous := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(bytes.NewReader(body)),
+			Request:    request,
+		}, nil
+	})
+	t.Cleanup(func() { http.DefaultTransport = previous })
+}
+
+func createTestReceiptDraft(t *testing.T, store *storage.MemoryStore, user *schemas.User) *schemas.ReceiptDraft {
+	t.Helper()
+
+	image := &receiptImage{Key: receiptImageKey(user.ID, "image/png"), ContentType: "image/png"}
+	if err := store.Put(context.Background(), image.Key, bytes.NewReader(pngHeader)); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	payload := &ReceiptScanResponse{SuggestedAmount: 42.5, SuggestedDate: "2024-03-10", Currency: "BRL", Merchant: "Mercado", Confidence: 0.4}
+	draft, err := createReceiptDraft(context.Background(), user, personalScope(user.ID), payload, "", image, nil)
+	if err != nil {
+		t.Fatalf("createReceiptDraft: %v", err)
+	}
+	return draft
+}
+
+func TestScanReceiptHonoursReviewThreshold(t *testing.T) {
+	tests := []struct {
+		name       string
+		confidence float64
+		draftMode  bool
+		wantDraft  bool
+		wantReview bool
+	}{
+		{"confiança abaixo do limite vira rascunho para revisão", 0.4, false, true, true},
+		{"confiança no limite registra a despesa", 0.6, false, false, false},
+		{"confiança alta registra a despesa", 0.95, false, false, false},
+		{"modo rascunho ignora a confiança", 0.95, true, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupTestDB(t)
+			store := useReceiptLimits(t, 1<<20)
+			user := createTestUser(t, "ana@example.com")
+			useGeminiResult(t, receiptLLMResult{Total: 42.5, Currency: "BRL", Confidence: tt.confidence, Date: "2024-03-10", Merchant: "Mercado"})
+
+			input := receiptScanInput{
+				Data:     pngHeader,
+				Image:    receiptImage{Key: receiptImageKey(user.ID, "image/png"), ContentType: "image/png"},
+				Currency: "BRL",
+				Draft:    tt.draftMode,
+			}
+			response, expense, err := scanReceipt(context.Background(), user, personalScope(user.ID), input)
+			if err != nil {
+				t.Fatalf("scanReceipt: %v", err)
+			}
+			if response.Confidence != tt.confidence {
+				t.Fatalf("confiança = %v, esperado %v", response.Confidence, tt.confidence)
+			}
+			if response.ReviewRequired != tt.wantReview {
+				t.Fatalf("reviewRequired = %v, esperado %v", response.ReviewRequired, tt.wantReview)
+			}
+			if (response.Draft != nil) != tt.wantDraft || (expense != nil) == tt.wantDraft {
+				t.Fatalf("rascunho = %v, despesa = %v; esperado rascunho %v", response.Draft, expense, tt.wantDraft)
+			}
+
+			var expenses, drafts int64
+			getDB().Model(&schemas.Expense{}).Count(&expenses)
+			getDB().Model(&schemas.ReceiptDraft{}).Count(&drafts)
+			if tt.wantDraft && (expenses != 0 || drafts != 1) || !tt.wantDraft && (expenses != 1 || drafts != 0) {
+				t.Fatalf("despesas = %d, rascunhos = %d", expenses, drafts)
+			}
+			if _, _, err := store.Get(context.Background(), input.Image.Key); err != nil {
+				t.Fatalf("a imagem deveria ser armazenada: %v", err)
+			}
+		})
+	}
+}
+
+func TestConfirmReceiptDraftCreatesExpenseOnce(t *testing.T) {
+	setupTestDB(t)
+	store := useReceiptLimits(t, 1<<20)
+	user := createTestUser(t, "ana@example.com")
+	draft := createTestReceiptDraft(t, store, user)
+	param := gin.Param{Key: "id", Value: draft.ID.String()}
+	target := "/receipts/drafts/" + draft.ID.String()
+
+	status, body := callHandler(t, ConfirmReceiptDraftHandler, user, "POST", target+"/confirm", nil, param)
+	if status != 200 {
+		t.Fatalf("confirmação: status = %d: %v", status, body)
+	}
+
+	receipt := schemas.Receipt{}
+	if err := getDB().First(&receipt).Error; err != nil {
+		t.Fatalf("recibo não registrado: %v", err)
+	}
+	if receipt.FilePath != draft.ImageKey {
+		t.Fatalf("imagem do recibo = %q, esperado %q", receipt.FilePath, draft.ImageKey)
+	}
+
+	if status, _ := callHandler(t, ConfirmReceiptDraftHandler, user, "POST", target+"/confirm", nil, param); status != 404 {
+		t.Fatalf("segunda confirmação: status = %d, esperado 404", status)
+	}
+	if status, _ := callHandler(t, DiscardReceiptDraftHandler, user, "DELETE", target, nil, param); status != 404 {
+		t.Fatalf("descarte após confirmar: status = %d, esperado 404", status)
+	}
+	// Quem carregou o rascunho antes da confirmação não consegue mais removê-lo.
+	if err := removeReceiptDraft(getDB(), draft); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("removeReceiptDraft = %v, esperado gorm.ErrRecordNotFound", err)
+	}
+
+	var expenses int64
+	getDB().Model(&schemas.Expense{}).Count(&expenses)
+	if expenses != 1 {
+		t.Fatalf("despesas = %d, esperado 1", expenses)
+	}
+	if _, _, err := store.Get(context.Background(), draft.ImageKey); err != nil {
+		t.Fatalf("a imagem pertence ao recibo e deveria ser mantida: %v", err)
+	}
+}
+
+func TestDiscardReceiptDraft(t *testing.T) {
+	setupTestDB(t)
+	store := useReceiptLimits(t, 1<<20)
+	user := createTestUser(t, "ana@example.com")
+	other := createTestUser(t, "bia@example.com")
+	draft := createTestReceiptDraft(t, store, user)
+	param := gin.Param{Key: "id", Value: draft.ID.String()}
+	target := "/receipts/drafts/" + draft.ID.String()
+
+	if status, _ := callHandler(t, DiscardReceiptDraftHandler, other, "DELETE", target, nil, param); status != 404 {
+		t.Fatalf("outro usuário: status = %d, esperado 404", status)
+	}
+	if status, body := callHandler(t, DiscardReceiptDraftHandler, user, "DELETE", target, nil, param); status != 200 {
+		t.Fatalf("descarte: status = %d: %v", status, body)
+	}
+	if status, _ := callHandler(t, ConfirmReceiptDraftHandler, user, "POST", target+"/confirm", nil, param); status != 404 {
+		t.Fatalf("confirmação após descartar: status = %d, esperado 404", status)
+	}
+
+	var drafts, expenses int64
+	getDB().Unscoped().Model(&schemas.ReceiptDraft{}).Count(&drafts)
+	getDB().Model(&schemas.Expense{}).Count(&expenses)
+	if drafts != 0 || expenses != 0 {
+		t.Fatalf("rascunhos = %d, despesas = %d; esperado nenhum", drafts, expenses)
+	}
+	if _, _, err := store.Get(context.Background(), draft.ImageKey); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("a imagem do rascunho descartado deveria ser apagada: %v", err)
+	}
+}
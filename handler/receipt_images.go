@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/Pmmvito/Golang-Api-Exemple/service/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// receiptFormOverhead é a folga, além do limite da imagem, reservada para os demais campos do formulário.
+const receiptFormOverhead = 1 << 20
+
+// receiptImageTypes são os formatos aceitos, conferidos pelo conteúdo do arquivo, com a extensão usada ao armazenar.
+var receiptImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// receiptImage identifica a imagem original de um recibo no armazenamento de arquivos.
+type receiptImage struct {
+	Key         string
+	ContentType string
+}
+
+// GetReceiptImageHandler godoc
+// @Summary Baixar imagem do recibo
+// @Description Retorna a imagem original enviada no processamento do recibo. Só recibos de despesas do escopo atual podem ser lidos.
+// @Tags Recibos
+// @Security Bearer
+// @Produce image/jpeg,image/png,image/webp
+// @Param id path string true "Identificador do recibo"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {file} file
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /receipts/{id}/image [get]
+func GetReceiptImageHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	receiptID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	expenses := getDataScope(ctx, user).apply(getDB().Model(&schemas.Expense{}).Select("expenses.id"), "expenses")
+	receipt := schemas.Receipt{}
+	if err := getDB().Where("id = ? AND expense_id IN (?)", receiptID, expenses).First(&receipt).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "recibo não encontrado", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao carregar recibo", err.Error())
+		return
+	}
+	if receipt.ContentType == "" {
+		respondError(ctx, 404, "recibo sem imagem armazenada", nil)
+		return
+	}
+
+	reader, size, err := getBlobStore().Get(ctx.Request.Context(), receipt.FilePath)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			respondError(ctx, 404, "imagem do recibo não encontrada", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao ler imagem do recibo", err.Error())
+		return
+	}
+	defer reader.Close()
+
+	filename := "recibo-" + receipt.ID.String() + receiptImageTypes[receipt.ContentType]
+	ctx.DataFromReader(http.StatusOK, size, receipt.ContentType, reader, map[string]string{
+		"Content-Disposition":    fmt.Sprintf("inline; filename=%q", filename),
+		"Cache-Control":          "private, max-age=3600",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// readReceiptScanRequest lê os dados do scan em JSON, com a imagem em Base64, ou em multipart/form-data,
+// com a imagem no campo image, e devolve os bytes da imagem respeitando o limite de tamanho.
+func readReceiptScanRequest(ctx *gin.Context, request *ReceiptScanRequest) ([]byte, bool) {
+	limit := getReceiptMaxUploadBytes()
+	var tooLarge *http.MaxBytesError
+
+	if ctx.ContentType() != "multipart/form-data" {
+		// Em Base64 a imagem ocupa 4/3 do tamanho original.
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, limit/3*4+receiptFormOverhead)
+		if err := ctx.ShouldBindJSON(request); err != nil {
+			if errors.As(err, &tooLarge) {
+				respondReceiptTooLarge(ctx, limit)
+				return nil, false
+			}
+			respondError(ctx, 400, "payload inválido", err.Error())
+			return nil, false
+		}
+
+		rawImage := strings.TrimSpace(request.ImageBase64)
+		if rawImage == "" {
+			respondError(ctx, 400, "imagem é obrigatória", nil)
+			return nil, false
+		}
+		_, payload := extractMimeAndPayload(rawImage)
+		if payload == "" {
+			respondError(ctx, 400, "imagem inválida", "payload base64 vazio")
+			return nil, false
+		}
+		data, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			respondError(ctx, 400, "imagem inválida", err.Error())
+			return nil, false
+		}
+		if int64(len(data)) > limit {
+			respondReceiptTooLarge(ctx, limit)
+			return nil, false
+		}
+		return data, true
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, limit+receiptFormOverhead)
+	file, _, err := ctx.Request.FormFile("image")
+	if err != nil {
+		switch {
+		case errors.As(err, &tooLarge):
+			respondReceiptTooLarge(ctx, limit)
+		case errors.Is(err, http.ErrMissingFile):
+			respondError(ctx, 400, "imagem é obrigatória", nil)
+		default:
+			respondError(ctx, 400, "formulário inválido", err.Error())
+		}
+		return nil, false
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, limit+1))
+	if err != nil {
+		respondError(ctx, 400, "imagem inválida", err.Error())
+		return nil, false
+	}
+	if int64(len(data)) > limit {
+		respondReceiptTooLarge(ctx, limit)
+		return nil, false
+	}
+	if len(data) == 0 {
+		respondError(ctx, 400, "imagem é obrigatória", nil)
+		return nil, false
+	}
+
+	request.Currency = ctx.PostForm("currency")
+	request.Locale = ctx.PostForm("locale")
+	if raw := strings.TrimSpace(ctx.PostForm("amountHint")); raw != "" {
+		hint, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			respondError(ctx, 400, "amountHint inválido", nil)
+			return nil, false
+		}
+		request.AmountHint = &hint
+	}
+	if raw := strings.TrimSpace(ctx.PostForm("returnRaw")); raw != "" {
+		returnRaw, err := strconv.ParseBool(raw)
+		if err != nil {
+			respondError(ctx, 400, "returnRaw inválido", nil)
+			return nil, false
+		}
+		request.ReturnRaw = returnRaw
+	}
+	if raw := strings.TrimSpace(ctx.PostForm("async")); raw != "" {
+		async, err := strconv.ParseBool(raw)
+		if err != nil {
+			respondError(ctx, 400, "async inválido", nil)
+			return nil, false
+		}
+		request.Async = async
+	}
+	request.Mode = ctx.PostForm("mode")
+	return data, true
+}
+
+func respondReceiptTooLarge(ctx *gin.Context, limit int64) {
+	respondError(ctx, 413, "imagem excede o tamanho máximo", gin.H{"maxBytes": limit})
+}
+
+// sniffReceiptImage identifica o formato pelo conteúdo, ignorando o tipo declarado pelo cliente.
+func sniffReceiptImage(ctx *gin.Context, data []byte) (string, bool) {
+	mimeType := http.DetectContentType(data)
+	if _, ok := receiptImageTypes[mimeType]; !ok {
+		respondError(ctx, 415, "formato de imagem não suportado, envie JPEG, PNG ou WebP", gin.H{"detected": mimeType})
+		return "", false
+	}
+	return mimeType, true
+}
+
+func receiptImageKey(userID uuid.UUID, mimeType string) string {
+	return fmt.Sprintf("receipts/%s/%s%s", userID, uuid.New(), receiptImageTypes[mimeType])
+}
+
+// deleteReceiptImages remove imagens do armazenamento. Falhas são apenas registradas no log: um arquivo
+// órfão não afeta os dados, enquanto interromper a operação deixaria o banco inconsistente.
+func deleteReceiptImages(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := getBlobStore().Delete(ctx, key); err != nil {
+			getLogger().WarnF("não foi possível remover imagem de recibo %s: %v", key, err)
+		}
+	}
+}
+
+// userReceiptImageKeys lista as imagens armazenadas dos recibos de despesas do usuário, inclusive as excluídas
+// logicamente, as dos rascunhos e as dos jobs de recibo ainda não concluídos.
+func userReceiptImageKeys(tx *gorm.DB, userID uuid.UUID) ([]string, error) {
+	var keys []string
+	if err := tx.Unscoped().Model(&schemas.Receipt{}).
+		Where("content_type <> ''").
+		Where("expense_id IN (SELECT id FROM expenses WHERE user_id = ?)", userID).
+		Pluck("file_path", &keys).Error; err != nil {
+		return nil, err
+	}
+
+	var drafts []string
+	if err := tx.Unscoped().Model(&schemas.ReceiptDraft{}).
+		Where("user_id = ? AND content_type <> ''", userID).
+		Pluck("image_key", &drafts).Error; err != nil {
+		return nil, err
+	}
+	keys = append(keys, drafts...)
+
+	var pending []string
+	if err := tx.Unscoped().Model(&schemas.ReceiptJob{}).
+		Where("user_id = ? AND status IN ?", userID, []schemas.ReceiptJobStatus{schemas.ReceiptJobStatusQueued, schemas.ReceiptJobStatusProcessing}).
+		Pluck("image_key", &pending).Error; err != nil {
+		return nil, err
+	}
+	return append(keys, pending...), nil
+}
+
+// purgedReceiptImageKeys lista as imagens dos recibos e rascunhos que purgeSoftDeleted removerá com o corte informado.
+func purgedReceiptImageKeys(db *gorm.DB, cutoff time.Time) ([]string, error) {
+	var keys []string
+	if err := db.Unscoped().Model(&schemas.Receipt{}).
+		Where("content_type <> ''").
+		Where("(deleted_at IS NOT NULL AND deleted_at < ?) OR expense_id IN (SELECT id FROM expenses WHERE deleted_at IS NOT NULL AND deleted_at < ?)", cutoff, cutoff).
+		Pluck("file_path", &keys).Error; err != nil {
+		return nil, err
+	}
+
+	var drafts []string
+	if err := db.Unscoped().Model(&schemas.ReceiptDraft{}).
+		Where("content_type <> '' AND deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Pluck("image_key", &drafts).Error; err != nil {
+		return nil, err
+	}
+	return append(keys, drafts...), nil
+}
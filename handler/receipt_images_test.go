@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/Pmmvito/Golang-Api-Exemple/service/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// useReceiptLimits instala um armazenamento em memória e o limite de upload informado durante o teste.
+func useReceiptLimits(t *testing.T, maxBytes int64) *storage.MemoryStore {
+	t.Helper()
+
+	store := storage.NewMemoryStore()
+	previousStore, previousLimit := blobStore, receiptMaxUploadBytes
+	blobStore, receiptMaxUploadBytes = store, maxBytes
+	t.Cleanup(func() { blobStore, receiptMaxUploadBytes = previousStore, previousLimit })
+	return store
+}
+
+// callRawHandler executa o handler com o corpo e o Content-Type informados e devolve a resposta gravada.
+func callRawHandler(handler gin.HandlerFunc, user *schemas.User, contentType string, body io.Reader, params ...gin.Param) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest("POST", "/receipts/scan", body)
+	ctx.Request.Header.Set("Content-Type", contentType)
+	ctx.Params = params
+	ctx.Set(contextUserKey, user)
+	handler(ctx)
+	return recorder
+}
+
+func multipartImage(t *testing.T, data []byte) (string, io.Reader) {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("image", "recibo.jpg")
+	if err != nil {
+		t.Fatalf("CreateFormFile: %v", err)
+	}
+	part.Write(data)
+	writer.WriteField("currency", "BRL")
+	if err := writer.Close(); err != nil {
+		t.Fatalf("erro fechando formulário: %v", err)
+	}
+	return writer.FormDataContentType(), &body
+}
+
+func jsonImage(t *testing.T, data []byte) io.Reader {
+	t.Helper()
+
+	raw, err := json.Marshal(ReceiptScanRequest{ImageBase64: base64.StdEncoding.EncodeToString(data)})
+	if err != nil {
+		t.Fatalf("erro codificando corpo: %v", err)
+	}
+	return bytes.NewReader(raw)
+}
+
+func TestReadReceiptScanRequestLimits(t *testing.T) {
+	const limit = 1024
+	useReceiptLimits(t, limit)
+	user := &schemas.User{Name: "Teste"}
+	user.ID = uuid.New()
+
+	readOnly := func(ctx *gin.Context) {
+		var request ReceiptScanRequest
+		if data, ok := readReceiptScanRequest(ctx, &request); ok {
+			ctx.JSON(200, gin.H{"bytes": len(data), "currency": request.Currency})
+		}
+	}
+
+	exact := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{0}, limit-len(pngHeader))...)
+	oversize := append(append([]byte{}, exact...), 0)
+
+	asJSON := func(body io.Reader) (string, io.Reader) { return "application/json", body }
+
+	tests := []struct {
+		name    string
+		request func() (string, io.Reader)
+		want    int
+	}{
+		{"multipart no limite", func() (string, io.Reader) { return multipartImage(t, exact) }, 200},
+		{"multipart acima do limite", func() (string, io.Reader) { return multipartImage(t, oversize) }, 413},
+		{"json no limite", func() (string, io.Reader) { return asJSON(jsonImage(t, exact)) }, 200},
+		{"json acima do limite", func() (string, io.Reader) { return asJSON(jsonImage(t, oversize)) }, 413},
+		{"json maior que o corpo permitido", func() (string, io.Reader) {
+			return asJSON(strings.NewReader(`{"imageBase64":"` + strings.Repeat("A", receiptFormOverhead+limit*2) + `"}`))
+		}, 413},
+		{"json sem imagem", func() (string, io.Reader) { return asJSON(strings.NewReader(`{"currency":"BRL"}`)) }, 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType, body := tt.request()
+			recorder := callRawHandler(readOnly, user, contentType, body)
+			if recorder.Code != tt.want {
+				t.Fatalf("status = %d, esperado %d: %s", recorder.Code, tt.want, recorder.Body.String())
+			}
+			if tt.want == 413 && !strings.Contains(recorder.Body.String(), `"maxBytes":1024`) {
+				t.Fatalf("resposta 413 deveria informar o limite: %s", recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestScanReceiptRejectsNonImages(t *testing.T) {
+	setupTestDB(t)
+	useReceiptLimits(t, 1<<20)
+	user := createTestUser(t, "ana@example.com")
+
+	tests := map[string][]byte{
+		"texto":            []byte("isto não é uma imagem, apenas texto simples"),
+		"pdf":              []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\n"),
+		"html como imagem": []byte("<html><body><img src=x></body></html>"),
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			contentType, body := multipartImage(t, data)
+			recorder := callRawHandler(ScanReceiptHandler, user, contentType, body)
+			if recorder.Code != 415 {
+				t.Fatalf("multipart: status = %d, esperado 415: %s", recorder.Code, recorder.Body.String())
+			}
+
+			recorder = callRawHandler(ScanReceiptHandler, user, "application/json", jsonImage(t, data))
+			if recorder.Code != 415 {
+				t.Fatalf("json: status = %d, esperado 415: %s", recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+
+	var count int64
+	getDB().Model(&schemas.Expense{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("nenhuma despesa deveria ser criada, obteve %d", count)
+	}
+}
+
+func TestSniffReceiptImage(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"png", pngHeader, "image/png"},
+		{"jpeg", []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00"), "image/jpeg"},
+		{"webp", []byte("RIFF\x00\x00\x00\x00WEBPVP8 "), "image/webp"},
+		{"gif", []byte("GIF89a\x01\x00\x01\x00"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			got, ok := sniffReceiptImage(ctx, tt.data)
+			if tt.want == "" {
+				if ok || recorder.Code != 415 {
+					t.Fatalf("sniffReceiptImage = %q, %v (status %d), esperado 415", got, ok, recorder.Code)
+				}
+				return
+			}
+			if !ok || got != tt.want {
+				t.Fatalf("sniffReceiptImage = %q, %v, esperado %q", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetReceiptImageOwnership(t *testing.T) {
+	setupTestDB(t)
+	store := useReceiptLimits(t, 1<<20)
+	owner := createTestUser(t, "ana@example.com")
+	other := createTestUser(t, "bia@example.com")
+
+	expense := schemas.Expense{UserID: owner.ID, CategoryID: uuid.New(), Description: "mercado", Amount: 42}
+	if err := getDB().Create(&expense).Error; err != nil {
+		t.Fatalf("erro criando despesa: %v", err)
+	}
+	key := receiptImageKey(owner.ID, "image/png")
+	if err := store.Put(context.Background(), key, bytes.NewReader(pngHeader)); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	receipt := schemas.Receipt{ExpenseID: expense.ID, FilePath: key, ContentType: "image/png"}
+	if err := getDB().Create(&receipt).Error; err != nil {
+		t.Fatalf("erro criando recibo: %v", err)
+	}
+	param := gin.Param{Key: "id", Value: receipt.ID.String()}
+
+	status, _ := callHandler(t, GetReceiptImageHandler, other, "GET", "/receipts/"+receipt.ID.String()+"/image", nil, param)
+	if status != 404 {
+		t.Fatalf("outro usuário: status = %d, esperado 404", status)
+	}
+
+	recorder := callRawHandler(GetReceiptImageHandler, owner, "", nil, param)
+	if recorder.Code != 200 {
+		t.Fatalf("dono: status = %d, esperado 200: %s", recorder.Code, recorder.Body.String())
+	}
+	if !bytes.Equal(recorder.Body.Bytes(), pngHeader) {
+		t.Fatalf("conteúdo = %q, esperado a imagem armazenada", recorder.Body.Bytes())
+	}
+	if got := recorder.Header().Get("Content-Type"); got != "image/png" {
+		t.Fatalf("Content-Type = %q", got)
+	}
+	if got := recorder.Header().Get("X-Content-Type-Options"); got != "nosniff" {
+		t.Fatalf("X-Content-Type-Options = %q", got)
+	}
+
+	if err := getDB().Delete(&expense).Error; err != nil {
+		t.Fatalf("erro excluindo despesa: %v", err)
+	}
+	if recorder := callRawHandler(GetReceiptImageHandler, owner, "", nil, param); recorder.Code != 404 {
+		t.Fatalf("despesa excluída: status = %d, esperado 404", recorder.Code)
+	}
+}
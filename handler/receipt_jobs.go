@@ -0,0 +1,393 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	// receiptJobPollInterval é a frequência com que os workers procuram jobs sem aviso de novos envios,
+	// como os enfileirados por outra instância.
+	receiptJobPollInterval = 5 * time.Second
+	// maxReceiptJobAttempts limita quantas vezes um job interrompido por reinício volta para a fila.
+	maxReceiptJobAttempts = 3
+	// receiptJobLease é por quanto tempo um job em processamento pertence ao worker que o reservou. Só depois
+	// disso ele é considerado interrompido, para não ser retomado enquanto outra instância ainda o processa.
+	receiptJobLease = 10 * time.Minute
+	// receiptJobReapInterval é a frequência com que os jobs com a reserva vencida são procurados.
+	receiptJobReapInterval = time.Minute
+)
+
+// errReceiptJobAlreadySaved indica que outra execução do mesmo job já registrou a despesa ou o rascunho.
+var errReceiptJobAlreadySaved = errors.New("recibo do job já registrado")
+
+// receiptJobSignal acorda um worker ocioso quando um recibo é enfileirado.
+var receiptJobSignal = make(chan struct{}, 1)
+
+// GetReceiptJobHandler godoc
+// @Summary Consultar processamento de recibo
+// @Description Retorna a situação de um recibo enviado com async=true: queued, processing, done (com o resultado do scan) ou failed (com o erro). Só quem enviou o recibo pode consultá-lo.
+// @Tags Recibos
+// @Security Bearer
+// @Produce json
+// @Param id path string true "Identificador do job"
+// @Success 200 {object} ReceiptJobSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /receipts/jobs/{id} [get]
+func GetReceiptJobHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	jobID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	job := schemas.ReceiptJob{}
+	if err := getDB().Where("id = ? AND user_id = ?", jobID, user.ID).First(&job).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "job não encontrado", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao carregar job", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "processamento do recibo", toReceiptJobResponse(&job))
+}
+
+// enqueueReceiptJob armazena a imagem e registra o job na fila, avisando um worker ocioso.
+func enqueueReceiptJob(ctx context.Context, user *schemas.User, scope dataScope, input receiptScanInput) (*schemas.ReceiptJob, error) {
+	if err := getBlobStore().Put(ctx, input.Image.Key, bytes.NewReader(input.Data)); err != nil {
+		return nil, fmt.Errorf("%w: %v", errReceiptImageStore, err)
+	}
+
+	job := schemas.ReceiptJob{
+		UserID:      user.ID,
+		HouseholdID: scope.HouseholdID,
+		Status:      schemas.ReceiptJobStatusQueued,
+		ImageKey:    input.Image.Key,
+		ContentType: input.Image.ContentType,
+		Currency:    input.Currency,
+		Locale:      input.Locale,
+		AmountHint:  input.AmountHint,
+		ReturnRaw:   input.ReturnRaw,
+		Draft:       input.Draft,
+	}
+	if err := getDB().WithContext(ctx).Create(&job).Error; err != nil {
+		deleteReceiptImages(ctx, []string{job.ImageKey})
+		return nil, err
+	}
+
+	select {
+	case receiptJobSignal <- struct{}{}:
+	default:
+	}
+	return &job, nil
+}
+
+// startReceiptWorkers devolve à fila os jobs interrompidos, inicia os workers, que limitam quantos recibos são
+// processados ao mesmo tempo, e passa a procurar periodicamente jobs cuja reserva venceu.
+func startReceiptWorkers(ctx context.Context) {
+	if err := requeueInterruptedReceiptJobs(ctx, time.Now()); err != nil {
+		getLogger().ErrorF("não foi possível retomar jobs de recibo: %v", err)
+	}
+	for i := 0; i < getReceiptWorkers(); i++ {
+		go runReceiptWorker(ctx)
+	}
+	go runReceiptJobReaper(ctx)
+}
+
+func runReceiptJobReaper(ctx context.Context) {
+	ticker := time.NewTicker(receiptJobReapInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := requeueInterruptedReceiptJobs(ctx, now); err != nil {
+				getLogger().ErrorF("não foi possível retomar jobs de recibo: %v", err)
+			}
+		}
+	}
+}
+
+// requeueInterruptedReceiptJobs trata os jobs em processamento cuja reserva venceu, seja porque o servidor parou
+// ou porque o worker travou. Os que já foram interrompidos maxReceiptJobAttempts vezes sem registrar o recibo
+// falham; os demais voltam para a fila. Jobs reservados há menos de receiptJobLease não são tocados, pois
+// podem estar sendo processados por outra instância.
+func requeueInterruptedReceiptJobs(ctx context.Context, now time.Time) error {
+	db := getDB().WithContext(ctx)
+	expired := now.Add(-receiptJobLease)
+	interrupted := "status = ? AND (started_at IS NULL OR started_at < ?)"
+
+	var exhausted []schemas.ReceiptJob
+	if err := db.Where(interrupted, schemas.ReceiptJobStatusProcessing, expired).
+		Where("attempts >= ? AND expense_id IS NULL AND draft_id IS NULL", maxReceiptJobAttempts).
+		Find(&exhausted).Error; err != nil {
+		return err
+	}
+	for i := range exhausted {
+		deleteReceiptImages(ctx, []string{exhausted[i].ImageKey})
+		failReceiptJob(ctx, &exhausted[i], "processamento interrompido repetidamente")
+	}
+
+	return db.Model(&schemas.ReceiptJob{}).
+		Where(interrupted, schemas.ReceiptJobStatusProcessing, expired).
+		Updates(map[string]interface{}{"status": schemas.ReceiptJobStatusQueued, "started_at": nil}).Error
+}
+
+func runReceiptWorker(ctx context.Context) {
+	ticker := time.NewTicker(receiptJobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			job, err := claimReceiptJob(ctx)
+			if err != nil {
+				getLogger().ErrorF("erro ao buscar job de recibo: %v", err)
+				break
+			}
+			if job == nil {
+				break
+			}
+			processReceiptJob(ctx, job)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-receiptJobSignal:
+		case <-ticker.C:
+		}
+	}
+}
+
+// claimReceiptJob reserva o job mais antigo da fila. A troca de status condicionada ao status anterior
+// garante que dois workers, inclusive de instâncias diferentes, não peguem o mesmo job.
+func claimReceiptJob(ctx context.Context) (*schemas.ReceiptJob, error) {
+	db := getDB().WithContext(ctx)
+	for {
+		var jobs []schemas.ReceiptJob
+		if err := db.Where("status = ?", schemas.ReceiptJobStatusQueued).
+			Order("created_at ASC").
+			Limit(1).
+			Find(&jobs).Error; err != nil {
+			return nil, err
+		}
+		if len(jobs) == 0 {
+			return nil, nil
+		}
+
+		job := &jobs[0]
+		now := time.Now()
+		result := db.Model(&schemas.ReceiptJob{}).
+			Where("id = ? AND status = ?", job.ID, schemas.ReceiptJobStatusQueued).
+			Updates(map[string]interface{}{
+				"status":     schemas.ReceiptJobStatusProcessing,
+				"started_at": now,
+				"attempts":   gorm.Expr("attempts + 1"),
+			})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			job.Status = schemas.ReceiptJobStatusProcessing
+			job.StartedAt = &now
+			job.Attempts++
+			return job, nil
+		}
+	}
+}
+
+func processReceiptJob(ctx context.Context, job *schemas.ReceiptJob) {
+	if job.ExpenseID != nil || job.DraftID != nil {
+		// Uma tentativa anterior registrou o recibo e foi interrompida antes de concluir o job.
+		finishSavedReceiptJob(ctx, job)
+		return
+	}
+
+	response, expense, err := runReceiptJob(ctx, job)
+	if ctx.Err() != nil {
+		// O servidor está parando: o job continua em processamento e volta para a fila quando a reserva vencer.
+		return
+	}
+	if errors.Is(err, errReceiptJobAlreadySaved) {
+		finishSavedReceiptJob(ctx, job)
+		return
+	}
+	if err != nil {
+		getLogger().WarnF("job de recibo %s falhou: %v", job.ID, err)
+		deleteReceiptImages(ctx, []string{job.ImageKey})
+		failReceiptJob(ctx, job, err.Error())
+		return
+	}
+	if expense == nil && response.Draft == nil {
+		deleteReceiptImages(ctx, []string{job.ImageKey})
+	}
+	completeReceiptJob(ctx, job, response)
+}
+
+// finishSavedReceiptJob conclui o job cujo recibo já foi registrado por outra tentativa, sem processar a imagem de
+// novo. O resultado é montado a partir da despesa ou do rascunho gravado; a imagem continua com eles.
+func finishSavedReceiptJob(ctx context.Context, job *schemas.ReceiptJob) {
+	db := getDB().WithContext(ctx)
+	if err := db.First(job, "id = ?", job.ID).Error; err != nil {
+		getLogger().ErrorF("não foi possível recarregar job de recibo %s: %v", job.ID, err)
+		return
+	}
+
+	response := &ReceiptScanResponse{Currency: job.Currency}
+	switch {
+	case job.ExpenseID != nil:
+		expense, err := loadExpenseForResponse(ctx, *job.ExpenseID)
+		if err != nil {
+			getLogger().WarnF("não foi possível carregar despesa do job de recibo %s: %v", job.ID, err)
+			break
+		}
+		response.SuggestedAmount = expense.Amount
+		response.SuggestedDate = expense.Date.Format("2006-01-02")
+		response.SavedExpense = toExpenseResponse(expense)
+	case job.DraftID != nil:
+		draft := schemas.ReceiptDraft{}
+		if err := db.Preload("Category").First(&draft, "id = ?", *job.DraftID).Error; err != nil {
+			getLogger().WarnF("não foi possível carregar rascunho do job de recibo %s: %v", job.ID, err)
+			break
+		}
+		response.SuggestedAmount = draft.Amount
+		response.SuggestedDate = draft.Date.Format("2006-01-02")
+		response.ReviewRequired = !job.Draft
+		response.Draft = toReceiptDraftResponse(&draft)
+	}
+	completeReceiptJob(ctx, job, response)
+}
+
+func completeReceiptJob(ctx context.Context, job *schemas.ReceiptJob, response *ReceiptScanResponse) {
+	result, err := json.Marshal(response)
+	if err != nil {
+		failReceiptJob(ctx, job, err.Error())
+		return
+	}
+	if err := getDB().WithContext(ctx).Model(job).Updates(map[string]interface{}{
+		"status":      schemas.ReceiptJobStatusDone,
+		"result":      datatypes.JSON(result),
+		"finished_at": time.Now(),
+	}).Error; err != nil {
+		getLogger().ErrorF("não foi possível concluir job de recibo %s: %v", job.ID, err)
+	}
+}
+
+// markReceiptJobSaved vincula ao job a despesa ou o rascunho criado, na mesma transação que o grava. A condição
+// sobre as duas colunas vazias garante que só uma execução do job registre o recibo. Fora de um job não faz nada.
+func markReceiptJobSaved(tx *gorm.DB, jobID *uuid.UUID, column string, id uuid.UUID) error {
+	if jobID == nil {
+		return nil
+	}
+	result := tx.Model(&schemas.ReceiptJob{}).
+		Where("id = ? AND expense_id IS NULL AND draft_id IS NULL", *jobID).
+		Update(column, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errReceiptJobAlreadySaved
+	}
+	return nil
+}
+
+// runReceiptJob refaz o escopo de quem enviou o recibo, conferindo se ainda pode gravar no grupo familiar,
+// e processa a imagem armazenada.
+func runReceiptJob(ctx context.Context, job *schemas.ReceiptJob) (*ReceiptScanResponse, *schemas.Expense, error) {
+	user := schemas.User{}
+	if err := getDB().WithContext(ctx).Preload("Config").First(&user, "id = ?", job.UserID).Error; err != nil {
+		return nil, nil, fmt.Errorf("erro ao carregar usuário: %w", err)
+	}
+
+	scope := personalScope(user.ID)
+	if job.HouseholdID != nil {
+		member, err := findHouseholdMember(getDB().WithContext(ctx), *job.HouseholdID, user.ID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, nil, errHouseholdNotMember
+			}
+			return nil, nil, err
+		}
+		scope = dataScope{UserID: user.ID, HouseholdID: job.HouseholdID, Role: member.Role}
+		if !scope.canWrite() {
+			return nil, nil, errHouseholdReadOnly
+		}
+	}
+
+	reader, _, err := getBlobStore().Get(ctx, job.ImageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao ler imagem do recibo: %w", err)
+	}
+	data, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao ler imagem do recibo: %w", err)
+	}
+
+	return scanReceipt(ctx, &user, scope, receiptScanInput{
+		Data:        data,
+		Image:       receiptImage{Key: job.ImageKey, ContentType: job.ContentType},
+		ImageStored: true,
+		Currency:    job.Currency,
+		Locale:      job.Locale,
+		AmountHint:  job.AmountHint,
+		ReturnRaw:   job.ReturnRaw,
+		Draft:       job.Draft,
+		JobID:       &job.ID,
+	})
+}
+
+func failReceiptJob(ctx context.Context, job *schemas.ReceiptJob, message string) {
+	if err := getDB().WithContext(ctx).Model(job).Updates(map[string]interface{}{
+		"status":      schemas.ReceiptJobStatusFailed,
+		"error":       message,
+		"finished_at": time.Now(),
+	}).Error; err != nil {
+		getLogger().ErrorF("não foi possível registrar falha do job de recibo %s: %v", job.ID, err)
+	}
+}
+
+func toReceiptJobResponse(job *schemas.ReceiptJob) ReceiptJobResponse {
+	resp := ReceiptJobResponse{
+		ID:         job.ID.String(),
+		Status:     string(job.Status),
+		Attempts:   job.Attempts,
+		Error:      job.Error,
+		ExpenseID:  uuidPtrString(job.ExpenseID),
+		DraftID:    uuidPtrString(job.DraftID),
+		CreatedAt:  job.CreatedAt,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+	}
+	if len(job.Result) > 0 {
+		result := ReceiptScanResponse{}
+		if err := json.Unmarshal(job.Result, &result); err == nil {
+			resp.Result = &result
+		}
+	}
+	return resp
+}
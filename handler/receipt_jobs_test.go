@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/Pmmvito/Golang-Api-Exemple/service/storage"
+	"github.com/google/uuid"
+)
+
+func createTestReceiptJob(t *testing.T, store *storage.MemoryStore, job schemas.ReceiptJob) *schemas.ReceiptJob {
+	t.Helper()
+
+	job.ImageKey = receiptImageKey(job.UserID, "image/png")
+	job.ContentType = "image/png"
+	job.Currency = "BRL"
+	if err := store.Put(context.Background(), job.ImageKey, bytes.NewReader(pngHeader)); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := getDB().Create(&job).Error; err != nil {
+		t.Fatalf("erro criando job: %v", err)
+	}
+	return &job
+}
+
+func reloadReceiptJob(t *testing.T, id uuid.UUID) schemas.ReceiptJob {
+	t.Helper()
+
+	job := schemas.ReceiptJob{}
+	if err := getDB().First(&job, "id = ?", id).Error; err != nil {
+		t.Fatalf("erro recarregando job: %v", err)
+	}
+	return job
+}
+
+func TestRequeueInterruptedReceiptJobsHonoursLease(t *testing.T) {
+	setupTestDB(t)
+	store := useReceiptLimits(t, 1<<20)
+	user := createTestUser(t, "ana@example.com")
+	now := time.Now()
+	expenseID := uuid.New()
+
+	processing := func(startedAt time.Time, attempts int) schemas.ReceiptJob {
+		return schemas.ReceiptJob{UserID: user.ID, Status: schemas.ReceiptJobStatusProcessing, StartedAt: &startedAt, Attempts: attempts}
+	}
+	leased := createTestReceiptJob(t, store, processing(now.Add(-time.Minute), 1))
+	exhaustedLeased := createTestReceiptJob(t, store, processing(now.Add(-time.Minute), maxReceiptJobAttempts))
+	expired := createTestReceiptJob(t, store, processing(now.Add(-receiptJobLease-time.Second), 1))
+	exhausted := createTestReceiptJob(t, store, processing(now.Add(-receiptJobLease-time.Second), maxReceiptJobAttempts))
+	savedJob := processing(now.Add(-receiptJobLease-time.Second), maxReceiptJobAttempts)
+	savedJob.ExpenseID = &expenseID
+	saved := createTestReceiptJob(t, store, savedJob)
+	unreserved := createTestReceiptJob(t, store, schemas.ReceiptJob{UserID: user.ID, Status: schemas.ReceiptJobStatusProcessing, Attempts: 1})
+	queued := createTestReceiptJob(t, store, schemas.ReceiptJob{UserID: user.ID, Status: schemas.ReceiptJobStatusQueued})
+
+	if err := requeueInterruptedReceiptJobs(context.Background(), now); err != nil {
+		t.Fatalf("requeueInterruptedReceiptJobs: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		job        *schemas.ReceiptJob
+		want       schemas.ReceiptJobStatus
+		imageKept  bool
+		keepsStart bool
+	}{
+		{"reservado há pouco continua com o worker", leased, schemas.ReceiptJobStatusProcessing, true, true},
+		{"esgotado mas reservado há pouco não falha", exhaustedLeased, schemas.ReceiptJobStatusProcessing, true, true},
+		{"reserva vencida volta para a fila", expired, schemas.ReceiptJobStatusQueued, true, false},
+		{"reserva vencida e tentativas esgotadas falha", exhausted, schemas.ReceiptJobStatusFailed, false, true},
+		{"recibo já registrado volta para a fila para ser concluído", saved, schemas.ReceiptJobStatusQueued, true, false},
+		{"em processamento sem reserva volta para a fila", unreserved, schemas.ReceiptJobStatusQueued, true, false},
+		{"na fila não muda", queued, schemas.ReceiptJobStatusQueued, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := reloadReceiptJob(t, tt.job.ID)
+			if got.Status != tt.want {
+				t.Fatalf("status = %s, esperado %s", got.Status, tt.want)
+			}
+			if (got.StartedAt != nil) != tt.keepsStart {
+				t.Fatalf("started_at = %v", got.StartedAt)
+			}
+			_, _, err := store.Get(context.Background(), tt.job.ImageKey)
+			if kept := err == nil; kept != tt.imageKept {
+				t.Fatalf("imagem mantida = %v, esperado %v (%v)", kept, tt.imageKept, err)
+			}
+		})
+	}
+}
+
+func TestPersistReceiptDataRecordsJobOnce(t *testing.T) {
+	setupTestDB(t)
+	store := useReceiptLimits(t, 1<<20)
+	user := createTestUser(t, "ana@example.com")
+	job := createTestReceiptJob(t, store, schemas.ReceiptJob{UserID: user.ID, Status: schemas.ReceiptJobStatusProcessing})
+	image := &receiptImage{Key: job.ImageKey, ContentType: job.ContentType}
+	payload := &ReceiptScanResponse{SuggestedAmount: 42.5, SuggestedDate: "2024-03-10", Currency: "BRL", Merchant: "Mercado", Confidence: 0.9}
+
+	expense, err := persistReceiptData(context.Background(), user, personalScope(user.ID), payload, "", image, &job.ID)
+	if err != nil {
+		t.Fatalf("persistReceiptData: %v", err)
+	}
+	if got := reloadReceiptJob(t, job.ID); got.ExpenseID == nil || *got.ExpenseID != expense.ID {
+		t.Fatalf("expense_id = %v, esperado %s", got.ExpenseID, expense.ID)
+	}
+
+	if _, err := persistReceiptData(context.Background(), user, personalScope(user.ID), payload, "", image, &job.ID); !errors.Is(err, errReceiptJobAlreadySaved) {
+		t.Fatalf("segunda gravação = %v, esperado errReceiptJobAlreadySaved", err)
+	}
+	if _, err := createReceiptDraft(context.Background(), user, personalScope(user.ID), payload, "", image, &job.ID); !errors.Is(err, errReceiptJobAlreadySaved) {
+		t.Fatalf("rascunho após a despesa = %v, esperado errReceiptJobAlreadySaved", err)
+	}
+
+	var expenses, receipts, drafts int64
+	getDB().Model(&schemas.Expense{}).Count(&expenses)
+	getDB().Model(&schemas.Receipt{}).Count(&receipts)
+	getDB().Model(&schemas.ReceiptDraft{}).Count(&drafts)
+	if expenses != 1 || receipts != 1 || drafts != 0 {
+		t.Fatalf("despesas = %d, recibos = %d, rascunhos = %d; esperado 1, 1 e 0", expenses, receipts, drafts)
+	}
+
+	// Sem job, cada chamada registra uma despesa nova.
+	if _, err := persistReceiptData(context.Background(), user, personalScope(user.ID), payload, "", nil, nil); err != nil {
+		t.Fatalf("persistReceiptData sem job: %v", err)
+	}
+}
+
+func TestProcessReceiptJobFinishesSavedReceipt(t *testing.T) {
+	setupTestDB(t)
+	store := useReceiptLimits(t, 1<<20)
+	user := createTestUser(t, "ana@example.com")
+	job := createTestReceiptJob(t, store, schemas.ReceiptJob{UserID: user.ID, Status: schemas.ReceiptJobStatusProcessing, Attempts: 2})
+	payload := &ReceiptScanResponse{SuggestedAmount: 42.5, SuggestedDate: "2024-03-10", Currency: "BRL", Confidence: 0.9}
+
+	expense, err := persistReceiptData(context.Background(), user, personalScope(user.ID), payload, "", &receiptImage{Key: job.ImageKey, ContentType: job.ContentType}, &job.ID)
+	if err != nil {
+		t.Fatalf("persistReceiptData: %v", err)
+	}
+
+	// A tentativa anterior foi interrompida depois de registrar a despesa: o job foi reservado de novo.
+	claimed := reloadReceiptJob(t, job.ID)
+	processReceiptJob(context.Background(), &claimed)
+
+	got := reloadReceiptJob(t, job.ID)
+	if got.Status != schemas.ReceiptJobStatusDone || got.FinishedAt == nil {
+		t.Fatalf("status = %s, finished_at = %v; esperado done", got.Status, got.FinishedAt)
+	}
+	response := toReceiptJobResponse(&got)
+	if response.Result == nil || response.Result.SavedExpense == nil || response.Result.SavedExpense.ID != expense.ID.String() {
+		t.Fatalf("resultado deveria trazer a despesa registrada: %+v", response.Result)
+	}
+	if response.Result.SuggestedAmount != 42.5 || response.Result.SuggestedDate != "2024-03-10" {
+		t.Fatalf("resultado = %+v", response.Result)
+	}
+
+	var expenses int64
+	getDB().Model(&schemas.Expense{}).Count(&expenses)
+	if expenses != 1 {
+		t.Fatalf("despesas = %d, esperado 1", expenses)
+	}
+	if _, _, err := store.Get(context.Background(), job.ImageKey); err != nil {
+		t.Fatalf("a imagem do recibo registrado deveria ser mantida: %v", err)
+	}
+}
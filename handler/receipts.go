@@ -1,11 +1,14 @@
 package handler
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -23,6 +26,7 @@ type receiptLLMItem struct {
 	Quantity    float64 `json:"quantity"`
 	UnitPrice   float64 `json:"unitPrice"`
 	Total       float64 `json:"total"`
+	CategoryID  string  `json:"categoryId"`
 }
 
 type receiptLLMResult struct {
@@ -33,20 +37,36 @@ type receiptLLMResult struct {
 	Items      []receiptLLMItem `json:"items"`
 	RawText    string           `json:"raw_text"`
 	RawTextAlt string           `json:"rawText"`
+	Merchant   string           `json:"merchant"`
+	CNPJ       string           `json:"merchant_cnpj"`
+	Address    string           `json:"merchant_address"`
+	CategoryID string           `json:"categoryId"`
 	Notes      string           `json:"notes"`
 }
 
 // ScanReceiptHandler godoc
 // @Summary Processar recibo com OCR
-// @Description Analisa uma imagem em Base64 usando Gemini e retorna extrações estruturadas
+// @Description Analisa a imagem de um recibo usando Gemini e retorna extrações estruturadas. Aceita JSON com a imagem em Base64 (imageBase64) ou multipart/form-data com o arquivo no campo image e os demais dados como campos do formulário. O formato é conferido pelo conteúdo (JPEG, PNG ou WebP) e o tamanho é limitado por RECEIPT_MAX_UPLOAD_MB (padrão 10). Quando a despesa é registrada, a imagem original é armazenada e fica disponível em /receipts/{id}/image. O estabelecimento extraído (nome, CNPJ e endereço) é vinculado à despesa e reaproveitado pelo CNPJ ou pelo nome normalizado; os totais por estabelecimento ficam em /merchants. Com async=true o recibo é enfileirado e a resposta 202 traz o job, acompanhado em /receipts/jobs/{id}. Com mode=draft o resultado vira um rascunho editável (/receipts/drafts) em vez de despesa; no modo auto (padrão) isso também acontece, com reviewRequired=true, quando a confiança fica abaixo de OCR_REVIEW_THRESHOLD (padrão 0.6).
 // @Tags Recibos
 // @Security Bearer
-// @Accept json
+// @Accept json,mpfd
 // @Produce json
-// @Param body body ReceiptScanRequest true "Dados do recibo"
+// @Param body body ReceiptScanRequest false "Dados do recibo (JSON)"
+// @Param image formData file false "Imagem do recibo (multipart)"
+// @Param currency formData string false "Moeda (multipart)"
+// @Param amountHint formData number false "Valor aproximado (multipart)"
+// @Param locale formData string false "Idioma do recibo (multipart)"
+// @Param returnRaw formData boolean false "Retornar a saída bruta do modelo (multipart)"
+// @Param async formData boolean false "Processar em segundo plano (multipart)"
+// @Param mode formData string false "auto (padrão) ou draft (multipart)"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
 // @Success 200 {object} ReceiptScanResponse
+// @Success 202 {object} ReceiptJobSuccess
 // @Failure 400 {object} APIError
 // @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 413 {object} APIError
+// @Failure 415 {object} APIError
 // @Failure 500 {object} APIError
 // @Router /receipts/scan [post]
 func ScanReceiptHandler(ctx *gin.Context) {
@@ -56,29 +76,25 @@ func ScanReceiptHandler(ctx *gin.Context) {
 		return
 	}
 
-	var request ReceiptScanRequest
-	if !bindJSON(ctx, &request) {
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
 		return
 	}
 
-	rawImage := strings.TrimSpace(request.ImageBase64)
-	if rawImage == "" {
-		respondError(ctx, 400, "imagem é obrigatória", nil)
+	var request ReceiptScanRequest
+	data, ok := readReceiptScanRequest(ctx, &request)
+	if !ok {
 		return
 	}
-
-	mimeType, payload := extractMimeAndPayload(rawImage)
-	if payload == "" {
-		respondError(ctx, 400, "imagem inválida", "payload base64 vazio")
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
 		return
 	}
 
-	data, err := base64.StdEncoding.DecodeString(payload)
-	if err != nil {
-		respondError(ctx, 400, "imagem inválida", err.Error())
+	mimeType, ok := sniffReceiptImage(ctx, data)
+	if !ok {
 		return
 	}
-
 	currency := strings.ToUpper(strings.TrimSpace(request.Currency))
 	if currency == "" && user.Config != nil {
 		currency = strings.ToUpper(strings.TrimSpace(user.Config.Currency))
@@ -95,15 +111,74 @@ func ScanReceiptHandler(ctx *gin.Context) {
 		locale = "pt-BR"
 	}
 
+	input := receiptScanInput{
+		Data:       data,
+		Image:      receiptImage{Key: receiptImageKey(user.ID, mimeType), ContentType: mimeType},
+		Currency:   currency,
+		Locale:     locale,
+		AmountHint: request.AmountHint,
+		ReturnRaw:  request.ReturnRaw,
+		Draft:      request.Mode == receiptScanModeDraft,
+	}
+
+	if request.Async {
+		job, err := enqueueReceiptJob(ctx.Request.Context(), user, scope, input)
+		if err != nil {
+			respondError(ctx, 500, "não foi possível enfileirar o recibo", err.Error())
+			return
+		}
+		ctx.JSON(http.StatusAccepted, APISuccess{Message: "recibo enfileirado", Data: toReceiptJobResponse(job)})
+		return
+	}
+
+	response, _, err := scanReceipt(ctx.Request.Context(), user, scope, input)
+	if err != nil {
+		if errors.Is(err, errReceiptImageStore) {
+			respondError(ctx, 500, errReceiptImageStore.Error(), err.Error())
+			return
+		}
+		respondError(ctx, 500, "não foi possível salvar o recibo", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "recebido", response)
+}
+
+// receiptScanInput reúne o que é preciso para processar um recibo, seja na própria requisição ou em um job.
+type receiptScanInput struct {
+	Data        []byte
+	Image       receiptImage
+	ImageStored bool
+	Currency    string
+	Locale      string
+	AmountHint  *float64
+	ReturnRaw   bool
+	Draft       bool
+	JobID       *uuid.UUID
+}
+
+var errReceiptImageStore = errors.New("não foi possível armazenar a imagem do recibo")
+
+// scanReceipt extrai os dados do recibo com o Gemini e registra a despesa com a imagem original. No modo
+// rascunho, ou quando a confiança fica abaixo de OCR_REVIEW_THRESHOLD, registra um rascunho para revisão no
+// lugar da despesa. Sem o Gemini configurado ou quando ele falha, devolve uma estimativa heurística sem
+// registrar nada. A despesa retornada é nil sempre que nenhuma despesa é criada.
+func scanReceipt(ctx context.Context, user *schemas.User, scope dataScope, input receiptScanInput) (*ReceiptScanResponse, *schemas.Expense, error) {
+	payload := base64.StdEncoding.EncodeToString(input.Data)
+
 	client, err := gemini.NewClientFromEnv()
 	if err != nil {
 		getLogger().ErrorF("erro ao configurar cliente gemini: %v", err)
-		fallback := buildFallbackResponse(len(data), currency, request.AmountHint)
-		respondSuccess(ctx, "recebido", fallback)
-		return
+		fallback := buildFallbackResponse(len(input.Data), input.Currency, input.AmountHint)
+		return &fallback, nil, nil
+	}
+
+	categorizer, err := newCategorizer(getDB().WithContext(ctx), scope)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	prompt := buildReceiptPrompt(currency, locale, request.AmountHint)
+	prompt := buildReceiptPrompt(input.Currency, input.Locale, input.AmountHint, categorizer.promptCategories())
 	model := detectModelName()
 
 	geminiRequest := gemini.GenerateContentRequest{
@@ -112,22 +187,21 @@ func ScanReceiptHandler(ctx *gin.Context) {
 				Role: "user",
 				Parts: []gemini.ContentPart{
 					gemini.NewTextPart(prompt),
-					gemini.NewInlineImagePart(mimeType, payload),
+					gemini.NewInlineImagePart(input.Image.ContentType, payload),
 				},
 			},
 		},
 	}
 
-	ctxTimeout, cancel := context.WithTimeout(ctx.Request.Context(), 45*time.Second)
+	ctxTimeout, cancel := context.WithTimeout(ctx, 45*time.Second)
 	defer cancel()
 
 	result, err := client.GenerateContent(ctxTimeout, geminiRequest)
 	if err != nil {
 		getLogger().WarnF("falha ao gerar conteúdo com gemini: %v", err)
-		fallback := buildFallbackResponse(len(data), currency, request.AmountHint)
+		fallback := buildFallbackResponse(len(input.Data), input.Currency, input.AmountHint)
 		fallback.Model = model
-		respondSuccess(ctx, "recebido", fallback)
-		return
+		return &fallback, nil, nil
 	}
 
 	sanitized := gemini.SanitizeJSON(result.Text)
@@ -136,32 +210,57 @@ func ScanReceiptHandler(ctx *gin.Context) {
 		getLogger().WarnF("não foi possível interpretar resposta do gemini: %v", parseErr)
 	}
 
-	response := buildResponseFromLLM(llmResult, len(data), currency, request.AmountHint)
+	response := buildResponseFromLLM(llmResult, len(input.Data), input.Currency, input.AmountHint)
+	categorizeReceipt(categorizer, &response, llmResult)
 	response.Model = model
 	response.TokensUsed = result.Usage.TotalTokenCount
 
 	metadata := datatypes.JSONMap{
 		"currency":      response.Currency,
-		"locale":        locale,
-		"mimeType":      mimeType,
+		"locale":        input.Locale,
+		"mimeType":      input.Image.ContentType,
 		"itemsDetected": len(response.Items),
-		"returnRaw":     request.ReturnRaw,
-		"hasAmountHint": request.AmountHint != nil,
+		"returnRaw":     input.ReturnRaw,
+		"hasAmountHint": input.AmountHint != nil,
 		"model":         model,
 	}
 
-	if request.ReturnRaw {
+	if input.ReturnRaw {
 		response.RawModelOutput = sanitized
 	}
 
-	savedExpense, persistErr := persistReceiptData(ctx.Request.Context(), user, &response, sanitized)
-	if persistErr != nil {
-		respondError(ctx, 500, "não foi possível salvar o recibo", persistErr.Error())
-		return
+	if !input.ImageStored {
+		if err := getBlobStore().Put(ctx, input.Image.Key, bytes.NewReader(input.Data)); err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", errReceiptImageStore, err)
+		}
+	}
+
+	var savedExpense *schemas.Expense
+	if input.Draft || response.Confidence < getOcrReviewThreshold() {
+		response.ReviewRequired = !input.Draft
+		draft, draftErr := createReceiptDraft(ctx, user, scope, &response, sanitized, &input.Image, input.JobID)
+		if draftErr != nil {
+			// Se outra execução do job já registrou o recibo, a imagem pertence ao que ela gravou.
+			if !errors.Is(draftErr, errReceiptJobAlreadySaved) {
+				deleteReceiptImages(ctx, []string{input.Image.Key})
+			}
+			return nil, nil, draftErr
+		}
+		response.Draft = toReceiptDraftResponse(draft)
+		metadata["draftId"] = draft.ID.String()
+	} else {
+		expense, persistErr := persistReceiptData(ctx, user, scope, &response, sanitized, &input.Image, input.JobID)
+		if persistErr != nil {
+			if !errors.Is(persistErr, errReceiptJobAlreadySaved) {
+				deleteReceiptImages(ctx, []string{input.Image.Key})
+			}
+			return nil, nil, persistErr
+		}
+		savedExpense = expense
 	}
 
 	if savedExpense != nil {
-		recorded, loadErr := loadExpenseForResponse(ctx.Request.Context(), savedExpense.ID)
+		recorded, loadErr := loadExpenseForResponse(ctx, savedExpense.ID)
 		if loadErr != nil {
 			getLogger().WarnF("não foi possível carregar despesa salva: %v", loadErr)
 		} else {
@@ -170,7 +269,7 @@ func ScanReceiptHandler(ctx *gin.Context) {
 		metadata["expenseId"] = savedExpense.ID.String()
 	}
 
-	recordCtx, cancelRecord := context.WithTimeout(ctx.Request.Context(), 5*time.Second)
+	recordCtx, cancelRecord := context.WithTimeout(ctx, 5*time.Second)
 	defer cancelRecord()
 
 	entry, logErr := recordTokenUsage(recordCtx, user.ID, schemas.RequestTypeReceipt, result.Usage, metadata)
@@ -180,7 +279,7 @@ func ScanReceiptHandler(ctx *gin.Context) {
 		response.TokenCostCents = entry.CostInCents
 	}
 
-	respondSuccess(ctx, "recebido", response)
+	return &response, savedExpense, nil
 }
 
 func buildResponseFromLLM(result *receiptLLMResult, imageSize int, currency string, amountHint *float64) ReceiptScanResponse {
@@ -222,6 +321,7 @@ func buildResponseFromLLM(result *receiptLLMResult, imageSize int, currency stri
 			Quantity:    roundFloat(item.Quantity),
 			UnitPrice:   roundFloat(item.UnitPrice),
 			Total:       roundFloat(item.Total),
+			CategoryID:  item.CategoryID,
 		})
 	}
 
@@ -237,6 +337,9 @@ func buildResponseFromLLM(result *receiptLLMResult, imageSize int, currency stri
 		ExtractedText:   extractedText,
 		Items:           items,
 		Confidence:      roundFloat(confidence),
+		Merchant:        strings.TrimSpace(result.Merchant),
+		MerchantCNPJ:    formatCNPJ(normalizeCNPJ(result.CNPJ)),
+		MerchantAddress: strings.TrimSpace(result.Address),
 	}
 }
 
@@ -271,13 +374,21 @@ func parseReceiptLLMResult(raw string) (*receiptLLMResult, error) {
 	return &result, nil
 }
 
-func buildReceiptPrompt(currency, locale string, amountHint *float64) string {
+func buildReceiptPrompt(currency, locale string, amountHint *float64, categories string) string {
 	var builder strings.Builder
 	builder.WriteString("Você é um assistente de finanças que extrai dados estruturados de recibos em imagem.\n")
 	builder.WriteString("Retorne apenas JSON, sem comentários nem texto adicional.\n")
 	builder.WriteString("Formato esperado:\n")
 	builder.WriteString("{" +
-		"\"total\": number, \"currency\": \"" + currency + "\", \"confidence\": number entre 0 e 1, \"date\": \"YYYY-MM-DD\", \"items\": [ {\"description\": string, \"quantity\": number, \"unitPrice\": number, \"total\": number} ], \"raw_text\": string, \"notes\": string }\n")
+		"\"merchant\": string, \"merchant_cnpj\": string, \"merchant_address\": string, \"total\": number, \"currency\": \"" + currency + "\", \"confidence\": number entre 0 e 1, \"date\": \"YYYY-MM-DD\", \"categoryId\": string, \"items\": [ {\"description\": string, \"quantity\": number, \"unitPrice\": number, \"total\": number, \"categoryId\": string} ], \"raw_text\": string, \"notes\": string }\n")
+	builder.WriteString("Em merchant, informe o nome do estabelecimento como aparece no recibo, sem CNPJ nem endereço.\n")
+	builder.WriteString("Em merchant_cnpj, informe o CNPJ do estabelecimento no formato 00.000.000/0000-00 e, em merchant_address, o endereço em uma linha.\n")
+	if categories != "" {
+		builder.WriteString("Em categoryId, do recibo e de cada item, use o id da categoria abaixo que melhor descreve a compra ou o item, ou string vazia se nenhuma servir. Categorias disponíveis (id: nome):\n")
+		builder.WriteString(categories)
+	} else {
+		builder.WriteString("Deixe categoryId como string vazia.\n")
+	}
 	builder.WriteString("Se algum valor não estiver presente, use null ou string vazia.\n")
 	builder.WriteString("Use ponto como separador decimal.\n")
 	builder.WriteString("Interprete quantias na moeda " + currency + " e utilize o formato de data " + locale + " convertendo para YYYY-MM-DD.\n")
@@ -357,102 +468,187 @@ func detectModelName() string {
 
 const defaultOcrCategoryName = "Compras OCR"
 
-func persistReceiptData(ctx context.Context, user *schemas.User, payload *ReceiptScanResponse, rawModel string) (*schemas.Expense, error) {
+const (
+	receiptScanModeAuto  = "auto"
+	receiptScanModeDraft = "draft"
+)
+
+// receiptExpense reúne os dados de um recibo prontos para virar despesa, vindos direto do scan ou de um
+// rascunho revisado.
+type receiptExpense struct {
+	CategoryID      *uuid.UUID
+	Merchant        string
+	MerchantCNPJ    string
+	MerchantAddress string
+	Date            time.Time
+	Amount          float64
+	Items           []ReceiptItem
+	ExtractedText   string
+	Confidence      float64
+	Image           *receiptImage
+}
+
+// persistReceiptData registra a despesa do recibo. Quando o recibo vem de um job, o job é vinculado à despesa na
+// mesma transação, e a gravação é recusada com errReceiptJobAlreadySaved se outra tentativa já a fez.
+func persistReceiptData(ctx context.Context, user *schemas.User, scope dataScope, payload *ReceiptScanResponse, rawModel string, image *receiptImage, jobID *uuid.UUID) (*schemas.Expense, error) {
 	if user == nil || payload == nil {
 		return nil, fmt.Errorf("dados insuficientes para persistir recibo")
 	}
 
-	var savedExpense schemas.Expense
-
-	err := getDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		category, err := ensureOcrCategory(ctx, tx, user)
+	parsedDate, err := time.Parse("2006-01-02", payload.SuggestedDate)
+	if err != nil {
+		parsedDate = time.Now()
+	}
+
+	receiptText := strings.TrimSpace(payload.ExtractedText)
+	if receiptText == "" {
+		receiptText = strings.TrimSpace(rawModel)
+	}
+
+	var savedExpense *schemas.Expense
+	err = getDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		expense, err := createReceiptExpense(ctx, tx, user, scope, receiptExpense{
+			CategoryID:      parseOptionalUUID(payload.CategoryID),
+			Merchant:        payload.Merchant,
+			MerchantCNPJ:    payload.MerchantCNPJ,
+			MerchantAddress: payload.MerchantAddress,
+			Date:            parsedDate,
+			Amount:          payload.SuggestedAmount,
+			Items:           payload.Items,
+			ExtractedText:   receiptText,
+			Confidence:      payload.Confidence,
+			Image:           image,
+		})
 		if err != nil {
 			return err
 		}
+		savedExpense = expense
+		return markReceiptJobSaved(tx, jobID, "expense_id", expense.ID)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	checkBudgetAlerts(ctx, scope, savedExpense.Date)
+
+	return savedExpense, nil
+}
 
-		parsedDate, err := time.Parse("2006-01-02", payload.SuggestedDate)
+// createReceiptExpense grava a despesa de origem OCR com itens e recibo, ligada ao estabelecimento do recibo.
+// Sem categoria informada, usa a categoria "Compras OCR" do escopo, criada quando ainda não existe; itens sem
+// categoria ficam na categoria da despesa.
+func createReceiptExpense(ctx context.Context, tx *gorm.DB, user *schemas.User, scope dataScope, data receiptExpense) (*schemas.Expense, error) {
+	categoryID := data.CategoryID
+	if categoryID == nil {
+		category, err := ensureOcrCategory(ctx, tx, user, scope)
 		if err != nil {
-			parsedDate = time.Now()
+			return nil, err
 		}
+		categoryID = &category.ID
+	}
 
-		description := fmt.Sprintf("Compra no mercado (%s)", parsedDate.Format("02/01"))
-		amount := payload.SuggestedAmount
-		if amount < 0 {
-			amount = 0
-		}
+	merchant, err := resolveMerchant(tx, user, scope, data.Merchant, data.MerchantCNPJ, data.MerchantAddress)
+	if err != nil {
+		return nil, err
+	}
 
-		expense := schemas.Expense{
-			UserID:      user.ID,
-			CategoryID:  category.ID,
-			Description: description,
-			Amount:      roundFloat(amount),
-			Date:        parsedDate,
-			Origin:      schemas.ExpenseOriginOCR,
+	description := strings.TrimSpace(data.Merchant)
+	var merchantID *uuid.UUID
+	if merchant != nil {
+		merchantID = &merchant.ID
+		if description == "" {
+			description = merchant.Name
 		}
+	}
+	if description == "" {
+		description = fmt.Sprintf("Compra no mercado (%s)", data.Date.Format("02/01"))
+	}
+	amount := data.Amount
+	if amount < 0 {
+		amount = 0
+	}
 
-		if err := tx.Create(&expense).Error; err != nil {
-			return err
-		}
+	expense := schemas.Expense{
+		UserID:      user.ID,
+		HouseholdID: scope.HouseholdID,
+		CategoryID:  *categoryID,
+		Description: description,
+		Amount:      roundFloat(amount),
+		Date:        data.Date,
+		Origin:      schemas.ExpenseOriginOCR,
+		MerchantID:  merchantID,
+	}
 
-		for _, item := range payload.Items {
-			name := strings.TrimSpace(item.Description)
-			if name == "" {
-				continue
-			}
-			i := schemas.ExpenseItem{
-				ExpenseID:  expense.ID,
-				Name:       name,
-				Quantity:   roundFloat(item.Quantity),
-				UnitPrice:  roundFloat(item.UnitPrice),
-				TotalPrice: roundFloat(item.Total),
+	if err := tx.Create(&expense).Error; err != nil {
+		return nil, err
+	}
+
+	// Categorias de item fora do escopo, como as de um rascunho editado com um id qualquer, dão lugar à da despesa.
+	itemCategories := map[uuid.UUID]bool{*categoryID: true}
+	for _, item := range data.Items {
+		name := strings.TrimSpace(item.Description)
+		if name == "" {
+			continue
+		}
+		itemCategoryID := *categoryID
+		if id := parseOptionalUUID(&item.CategoryID); id != nil {
+			valid, checked := itemCategories[*id]
+			if !checked {
+				valid = categoryInScope(tx, scope, *id, schemas.CategoryKindExpense)
+				itemCategories[*id] = valid
 			}
-			if err := tx.Create(&i).Error; err != nil {
-				return err
+			if valid {
+				itemCategoryID = *id
 			}
 		}
-
-		receiptText := strings.TrimSpace(payload.ExtractedText)
-		if receiptText == "" {
-			receiptText = strings.TrimSpace(rawModel)
-		}
-
-		receipt := schemas.Receipt{
-			ExpenseID:     expense.ID,
-			ExtractedText: receiptText,
-			OcrConfidence: payload.Confidence,
+		i := schemas.ExpenseItem{
+			ExpenseID:  expense.ID,
+			Name:       name,
+			Quantity:   roundFloat(item.Quantity),
+			UnitPrice:  roundFloat(item.UnitPrice),
+			TotalPrice: roundFloat(item.Total),
+			CategoryID: &itemCategoryID,
 		}
-		if err := tx.Create(&receipt).Error; err != nil {
-			return err
+		if err := tx.Create(&i).Error; err != nil {
+			return nil, err
 		}
+	}
 
-		savedExpense = expense
-		return nil
-	})
-
-	if err != nil {
+	receipt := schemas.Receipt{
+		ExpenseID:     expense.ID,
+		ExtractedText: data.ExtractedText,
+		OcrConfidence: data.Confidence,
+	}
+	if data.Image != nil {
+		receipt.FilePath = data.Image.Key
+		receipt.ContentType = data.Image.ContentType
+	}
+	if err := tx.Create(&receipt).Error; err != nil {
 		return nil, err
 	}
 
-	return &savedExpense, nil
+	return &expense, nil
 }
 
-func ensureOcrCategory(ctx context.Context, tx *gorm.DB, user *schemas.User) (*schemas.Category, error) {
+func ensureOcrCategory(ctx context.Context, tx *gorm.DB, user *schemas.User, scope dataScope) (*schemas.Category, error) {
 	category := schemas.Category{}
-	if err := tx.WithContext(ctx).
-		Where("user_id = ? AND name = ?", user.ID, defaultOcrCategoryName).
+	if err := scope.apply(tx.WithContext(ctx), "categories").
+		Where("name = ? AND kind = ?", defaultOcrCategoryName, schemas.CategoryKindExpense).
 		First(&category).Error; err != nil {
 		if err != gorm.ErrRecordNotFound {
 			return nil, err
 		}
 
 		category = schemas.Category{
-			UserID:   user.ID,
-			Name:     defaultOcrCategoryName,
-			Icon:     "shopping_cart",
-			ColorHex: "#2E7D32",
-			Type:     schemas.CategoryTypeVariable,
-			Order:    999,
-			Active:   true,
+			UserID:      user.ID,
+			HouseholdID: scope.HouseholdID,
+			Name:        defaultOcrCategoryName,
+			Icon:        "shopping_cart",
+			ColorHex:    "#2E7D32",
+			Type:        schemas.CategoryTypeVariable,
+			Kind:        schemas.CategoryKindExpense,
+			Order:       999,
+			Active:      true,
 		}
 		if err := tx.Create(&category).Error; err != nil {
 			return nil, err
@@ -467,6 +663,7 @@ func loadExpenseForResponse(ctx context.Context, expenseID uuid.UUID) (*schemas.
 		Preload("Category").
 		Preload("Receipt").
 		Preload("Items").
+		Preload("Allocations").
 		First(&expense, "id = ?", expenseID).Error; err != nil {
 		return nil, err
 	}
@@ -0,0 +1,936 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	maxRecurrenceInterval = 60
+	// maxOccurrencesPerRun limita quantas ocorrências uma regra materializa de uma vez; regras com
+	// início muito antigo completam o histórico nas execuções seguintes do agendador.
+	maxOccurrencesPerRun = 400
+	defaultPreviewLimit  = 5
+	maxPreviewLimit      = 60
+)
+
+var (
+	errRecurrenceExists        = errors.New("o lançamento já faz parte de uma recorrência")
+	errOccurrenceNotFound      = errors.New("ocorrência não encontrada")
+	errRecurrenceCategoryScope = errors.New("categoria não pertence ao usuário")
+)
+
+// CreateRecurrenceHandler godoc
+// @Summary Tornar despesa recorrente
+// @Description Cria uma regra de recorrência usando a despesa como modelo e primeira ocorrência (índice 0). As ocorrências seguintes são geradas pelo agendador quando a data chega; ocorrências já vencidas são geradas imediatamente. Frequências: weekly, monthly (dayOfMonth opcional, padrão o dia da despesa) e yearly, a cada interval unidades, até endDate ou count ocorrências.
+// @Tags Recorrências
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da despesa modelo"
+// @Param body body RecurrenceRuleRequest true "Regra de recorrência"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} RecurrenceRuleSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /expenses/{id}/recurrence [post]
+func CreateRecurrenceHandler(ctx *gin.Context) {
+	createRecurrence(ctx, schemas.CategoryKindExpense)
+}
+
+// CreateIncomeRecurrenceHandler godoc
+// @Summary Tornar receita recorrente
+// @Description Cria uma regra de recorrência usando a receita como modelo e primeira ocorrência (índice 0), com as mesmas opções de agendamento das despesas. As ocorrências são gerenciadas pelas rotas /recurrences.
+// @Tags Recorrências
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da receita modelo"
+// @Param body body RecurrenceRuleRequest true "Regra de recorrência"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} RecurrenceRuleSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 409 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /incomes/{id}/recurrence [post]
+func CreateIncomeRecurrenceHandler(ctx *gin.Context) {
+	createRecurrence(ctx, schemas.CategoryKindIncome)
+}
+
+func createRecurrence(ctx *gin.Context, kind schemas.CategoryKind) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	templateID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	var request RecurrenceRuleRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+	endDate, err := parseOptionalEndDate(request.EndDate)
+	if err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	model, table := occurrenceTarget(kind)
+	var rule schemas.RecurrenceRule
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		template := occurrenceRow{}
+		if err := scope.apply(tx.Model(model), table).Where("id = ?", templateID).Take(&template).Error; err != nil {
+			return err
+		}
+		if template.RecurrenceRuleID != nil {
+			return errRecurrenceExists
+		}
+
+		origin := schemas.ExpenseOriginManual
+		if kind == schemas.CategoryKindExpense {
+			if err := tx.Model(model).Select("origin").Where("id = ?", template.ID).Scan(&origin).Error; err != nil {
+				return err
+			}
+		}
+
+		dayOfMonth := request.DayOfMonth
+		if dayOfMonth == 0 && schemas.RecurrenceFrequency(request.Frequency) != schemas.RecurrenceFrequencyWeekly {
+			dayOfMonth = template.Date.Day()
+		}
+
+		rule = schemas.RecurrenceRule{
+			UserID:      user.ID,
+			HouseholdID: template.HouseholdID,
+			Kind:        kind,
+			TemplateID:  template.ID,
+			CategoryID:  template.CategoryID,
+			Description: template.Description,
+			Amount:      template.Amount,
+			Origin:      origin,
+			AccountID:   template.AccountID,
+			Frequency:   schemas.RecurrenceFrequency(request.Frequency),
+			Interval:    request.Interval,
+			DayOfMonth:  dayOfMonth,
+			StartDate:   template.Date,
+			EndDate:     endDate,
+			Count:       request.Count,
+			NextIndex:   1,
+		}
+		if next, ok := rule.OccurrenceDate(1); ok {
+			rule.NextOccurrenceAt = &next
+		}
+		if err := tx.Create(&rule).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(model).Where("id = ?", template.ID).Updates(map[string]interface{}{
+			"recurring":          true,
+			"recurrence_rule_id": rule.ID,
+			"recurrence_index":   0,
+		}).Error; err != nil {
+			return err
+		}
+
+		return materializeRecurrence(tx, &rule, time.Now())
+	})
+	if err != nil {
+		switch {
+		case err == gorm.ErrRecordNotFound:
+			if kind == schemas.CategoryKindIncome {
+				respondError(ctx, 404, "receita não encontrada", nil)
+			} else {
+				respondError(ctx, 404, "despesa não encontrada", nil)
+			}
+		case errors.Is(err, errRecurrenceExists):
+			respondError(ctx, 409, err.Error(), nil)
+		default:
+			respondError(ctx, 500, "erro ao criar recorrência", err.Error())
+		}
+		return
+	}
+
+	respondSuccess(ctx, "recorrência criada", toRecurrenceRuleResponse(&rule))
+}
+
+// ListRecurrencesHandler godoc
+// @Summary Listar recorrências
+// @Description Lista as regras de recorrência de despesas e receitas do escopo atual, ordenadas pela próxima ocorrência. Chaves de API veem apenas as naturezas que seus escopos permitem ler.
+// @Tags Recorrências
+// @Security Bearer
+// @Produce json
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} RecurrenceRuleListSuccess
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /recurrences [get]
+func ListRecurrencesHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	kinds := []schemas.CategoryKind{}
+	for _, kind := range []schemas.CategoryKind{schemas.CategoryKindExpense, schemas.CategoryKindIncome} {
+		if apiKeyAllows(ctx, recurrenceScope(kind, false)) {
+			kinds = append(kinds, kind)
+		}
+	}
+	if len(kinds) == 0 {
+		respondInsufficientScope(ctx, schemas.APIKeyScopeExpensesRead)
+		return
+	}
+
+	var rules []schemas.RecurrenceRule
+	if err := getDataScope(ctx, user).apply(getDB(), "recurrence_rules").
+		Where("kind IN ?", kinds).
+		Order("next_occurrence_at IS NULL, next_occurrence_at ASC, created_at ASC").
+		Find(&rules).Error; err != nil {
+		respondError(ctx, 500, "erro ao listar recorrências", err.Error())
+		return
+	}
+
+	responses := make([]RecurrenceRuleResponse, len(rules))
+	for i := range rules {
+		responses[i] = toRecurrenceRuleResponse(&rules[i])
+	}
+
+	respondSuccess(ctx, "recorrências", responses)
+}
+
+// GetRecurrenceHandler godoc
+// @Summary Detalhar recorrência
+// @Description Retorna a regra de recorrência informada
+// @Tags Recorrências
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID da recorrência"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} RecurrenceRuleSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /recurrences/{id} [get]
+func GetRecurrenceHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	rule, ok := loadRecurrenceRule(ctx, getDB(), getDataScope(ctx, user), false)
+	if !ok {
+		return
+	}
+
+	respondSuccess(ctx, "recorrência", toRecurrenceRuleResponse(rule))
+}
+
+// DeleteRecurrenceHandler godoc
+// @Summary Encerrar recorrência
+// @Description Exclui a regra de recorrência; nenhuma nova ocorrência é gerada e os lançamentos já gerados são mantidos
+// @Tags Recorrências
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID da recorrência"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /recurrences/{id} [delete]
+func DeleteRecurrenceHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	rule, ok := loadRecurrenceRule(ctx, getDB(), scope, true)
+	if !ok {
+		return
+	}
+
+	if err := getDB().Delete(rule).Error; err != nil {
+		respondError(ctx, 500, "erro ao encerrar recorrência", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "recorrência encerrada", nil)
+}
+
+// PreviewRecurrenceHandler godoc
+// @Summary Prévia das próximas ocorrências
+// @Description Lista as próximas ocorrências ainda não geradas pelo agendador, incluindo as puladas (skipped) e as já alteradas individualmente (com entryId, o ID da despesa ou receita)
+// @Tags Recorrências
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID da recorrência"
+// @Param limit query int false "Quantidade de ocorrências (padrão 5, máximo 60)"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} RecurrenceOccurrenceListSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /recurrences/{id}/preview [get]
+func PreviewRecurrenceHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	limit := parseIntDefault(ctx.Query("limit"), defaultPreviewLimit)
+	if limit <= 0 {
+		limit = defaultPreviewLimit
+	}
+	if limit > maxPreviewLimit {
+		limit = maxPreviewLimit
+	}
+
+	db := getDB()
+	rule, ok := loadRecurrenceRule(ctx, db, getDataScope(ctx, user), false)
+	if !ok {
+		return
+	}
+
+	skipped, err := recurrenceSkips(db, rule)
+	if err != nil {
+		respondError(ctx, 500, "erro ao carregar ocorrências puladas", err.Error())
+		return
+	}
+
+	model, _ := occurrenceTarget(rule.Kind)
+	var rows []occurrenceRow
+	if err := db.Model(model).Where("recurrence_rule_id = ? AND recurrence_index >= ?", rule.ID, rule.NextIndex).
+		Find(&rows).Error; err != nil {
+		respondError(ctx, 500, "erro ao carregar ocorrências", err.Error())
+		return
+	}
+	materialized := map[int]*occurrenceRow{}
+	for i := range rows {
+		materialized[*rows[i].RecurrenceIndex] = &rows[i]
+	}
+
+	occurrences := []RecurrenceOccurrenceResponse{}
+	for index := rule.NextIndex; len(occurrences) < limit; index++ {
+		date, ok := rule.OccurrenceDate(index)
+		if !ok {
+			break
+		}
+		occurrence := RecurrenceOccurrenceResponse{
+			Index:       index,
+			Date:        date,
+			CategoryID:  rule.CategoryID.String(),
+			Description: rule.Description,
+			Amount:      rule.Amount,
+			Skipped:     skipped[index],
+		}
+		if row := materialized[index]; row != nil {
+			occurrence.Date = row.Date
+			occurrence.CategoryID = row.CategoryID.String()
+			occurrence.Description = row.Description
+			occurrence.Amount = row.Amount
+			occurrence.EntryID = uuidPtrString(&row.ID)
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+
+	respondSuccess(ctx, "próximas ocorrências", occurrences)
+}
+
+// SkipRecurrenceOccurrenceHandler godoc
+// @Summary Pular ocorrência
+// @Description Impede que a ocorrência de índice informado seja gerada; se ela já foi gerada, a despesa ou receita é excluída
+// @Tags Recorrências
+// @Security Bearer
+// @Produce json
+// @Param id path string true "ID da recorrência"
+// @Param index path int true "Índice da ocorrência (0 é o lançamento modelo)"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /recurrences/{id}/occurrences/{index}/skip [post]
+func SkipRecurrenceOccurrenceHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	index, ok := parseOccurrenceIndex(ctx)
+	if !ok {
+		return
+	}
+
+	rule, ok := loadRecurrenceRule(ctx, getDB(), scope, true)
+	if !ok {
+		return
+	}
+	if _, ok := rule.OccurrenceDate(index); !ok {
+		respondError(ctx, 404, errOccurrenceNotFound.Error(), nil)
+		return
+	}
+
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		skip := schemas.RecurrenceSkip{RecurrenceRuleID: rule.ID, OccurrenceIndex: index}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&skip).Error; err != nil {
+			return err
+		}
+		model, _ := occurrenceTarget(rule.Kind)
+		return tx.Where("recurrence_rule_id = ? AND recurrence_index = ?", rule.ID, index).
+			Delete(model).Error
+	})
+	if err != nil {
+		respondError(ctx, 500, "erro ao pular ocorrência", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "ocorrência pulada", nil)
+}
+
+// UpdateRecurrenceOccurrenceHandler godoc
+// @Summary Alterar uma ocorrência
+// @Description Altera apenas a ocorrência informada e devolve a despesa ou receita correspondente. Se ela ainda não foi gerada, o lançamento é criado agora com os valores alterados e o agendador não o gera de novo; se estava pulada, volta a valer.
+// @Tags Recorrências
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da recorrência"
+// @Param index path int true "Índice da ocorrência (0 é o lançamento modelo)"
+// @Param body body RecurrenceOccurrenceRequest true "Campos da ocorrência"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} ExpenseItemSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /recurrences/{id}/occurrences/{index} [put]
+func UpdateRecurrenceOccurrenceHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	index, ok := parseOccurrenceIndex(ctx)
+	if !ok {
+		return
+	}
+
+	var request RecurrenceOccurrenceRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	rule, ok := loadRecurrenceRule(ctx, getDB(), scope, true)
+	if !ok {
+		return
+	}
+	date, ok := rule.OccurrenceDate(index)
+	if !ok {
+		respondError(ctx, 404, errOccurrenceNotFound.Error(), nil)
+		return
+	}
+
+	updates := map[string]interface{}{}
+	if request.CategoryID != nil {
+		categoryID, err := uuid.Parse(*request.CategoryID)
+		if err != nil {
+			respondError(ctx, 400, "categoryId inválido", nil)
+			return
+		}
+		if !categoryInScope(getDB(), scope, categoryID, rule.Kind) {
+			respondError(ctx, 403, errRecurrenceCategoryScope.Error(), nil)
+			return
+		}
+		updates["category_id"] = categoryID
+	}
+	if request.Description != nil {
+		updates["description"] = strings.TrimSpace(*request.Description)
+	}
+	if request.Amount != nil {
+		updates["amount"] = *request.Amount
+	}
+	if request.Date != nil {
+		parsedDate, err := parseDate(*request.Date)
+		if err != nil {
+			respondError(ctx, 400, "data inválida", nil)
+			return
+		}
+		updates["date"] = parsedDate
+	}
+
+	model, _ := occurrenceTarget(rule.Kind)
+	var entryID uuid.UUID
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().
+			Where("recurrence_rule_id = ? AND occurrence_index = ?", rule.ID, index).
+			Delete(&schemas.RecurrenceSkip{}).Error; err != nil {
+			return err
+		}
+
+		row := occurrenceRow{}
+		err := tx.Unscoped().Model(model).
+			Where("recurrence_rule_id = ? AND recurrence_index = ?", rule.ID, index).
+			Take(&row).Error
+		if err == gorm.ErrRecordNotFound {
+			entry, id := newOccurrence(rule, index, date)
+			if err := tx.Create(entry).Error; err != nil {
+				return err
+			}
+			row.ID = id
+		} else if err != nil {
+			return err
+		} else if row.DeletedAt.Valid {
+			updates["deleted_at"] = nil
+		}
+
+		entryID = row.ID
+		return tx.Unscoped().Model(model).Where("id = ?", row.ID).Updates(updates).Error
+	})
+	if err != nil {
+		respondError(ctx, 500, "erro ao alterar ocorrência", err.Error())
+		return
+	}
+
+	if rule.Kind == schemas.CategoryKindIncome {
+		income := schemas.Income{}
+		if err := getDB().Preload("Category").First(&income, "id = ?", entryID).Error; err != nil {
+			respondError(ctx, 500, "erro ao carregar ocorrência", err.Error())
+			return
+		}
+		respondSuccess(ctx, "ocorrência alterada", toIncomeResponse(&income))
+		return
+	}
+
+	expense := schemas.Expense{}
+	if err := getDB().Preload("Category").Preload("Receipt").Preload("InstallmentPurchase").Preload("Allocations").First(&expense, "id = ?", entryID).Error; err != nil {
+		respondError(ctx, 500, "erro ao carregar ocorrência", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "ocorrência alterada", toExpenseResponse(&expense))
+}
+
+// UpdateRecurrenceFutureHandler godoc
+// @Summary Alterar esta e as próximas ocorrências
+// @Description Encerra a regra antes da ocorrência informada e cria uma nova regra a partir dela com os valores e o agendamento alterados. Ocorrências já geradas a partir desse índice são substituídas pelas da nova regra, descartando alterações individuais e ocorrências puladas.
+// @Tags Recorrências
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da recorrência"
+// @Param index path int true "Índice da primeira ocorrência alterada"
+// @Param body body RecurrenceFutureRequest true "Valores e agendamento a partir da ocorrência"
+// @Param X-Household-ID header string false "ID do grupo familiar ativo; omita para usar os dados pessoais"
+// @Success 200 {object} RecurrenceRuleSuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 403 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /recurrences/{id}/occurrences/{index}/future [put]
+func UpdateRecurrenceFutureHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	scope, ok := writableDataScope(ctx, user)
+	if !ok {
+		return
+	}
+
+	index, ok := parseOccurrenceIndex(ctx)
+	if !ok {
+		return
+	}
+
+	var request RecurrenceFutureRequest
+	if !bindJSON(ctx, &request) {
+		return
+	}
+	if err := request.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+
+	rule, ok := loadRecurrenceRule(ctx, getDB(), scope, true)
+	if !ok {
+		return
+	}
+	startDate, ok := rule.OccurrenceDate(index)
+	if !ok {
+		respondError(ctx, 404, errOccurrenceNotFound.Error(), nil)
+		return
+	}
+
+	next := *rule
+	next.UUIDModel = schemas.UUIDModel{}
+	next.StartDate = startDate
+	next.NextIndex = 0
+	next.NextOccurrenceAt = &startDate
+	if rule.Count != nil {
+		remaining := *rule.Count - index
+		next.Count = &remaining
+	}
+
+	if request.CategoryID != nil {
+		categoryID, err := uuid.Parse(*request.CategoryID)
+		if err != nil {
+			respondError(ctx, 400, "categoryId inválido", nil)
+			return
+		}
+		if !categoryInScope(getDB(), scope, categoryID, rule.Kind) {
+			respondError(ctx, 403, errRecurrenceCategoryScope.Error(), nil)
+			return
+		}
+		next.CategoryID = categoryID
+	}
+	if request.Description != nil {
+		next.Description = strings.TrimSpace(*request.Description)
+	}
+	if request.Amount != nil {
+		next.Amount = *request.Amount
+	}
+
+	schedule := RecurrenceRuleRequest{
+		Frequency:  string(rule.Frequency),
+		Interval:   rule.Interval,
+		DayOfMonth: rule.DayOfMonth,
+		Count:      next.Count,
+	}
+	if request.Frequency != nil {
+		schedule.Frequency = *request.Frequency
+		if schemas.RecurrenceFrequency(strings.ToLower(*request.Frequency)) == schemas.RecurrenceFrequencyWeekly {
+			schedule.DayOfMonth = 0
+		}
+	}
+	if request.Interval != nil {
+		schedule.Interval = *request.Interval
+	}
+	if request.DayOfMonth != nil {
+		schedule.DayOfMonth = *request.DayOfMonth
+	}
+	if request.Count != nil {
+		schedule.Count = request.Count
+		if *request.Count == 0 {
+			schedule.Count = nil
+		}
+	}
+	if err := schedule.Validate(); err != nil {
+		respondError(ctx, 400, err.Error(), nil)
+		return
+	}
+	next.Frequency = schemas.RecurrenceFrequency(schedule.Frequency)
+	next.Interval = schedule.Interval
+	next.DayOfMonth = schedule.DayOfMonth
+	next.Count = schedule.Count
+
+	if request.EndDate != nil {
+		next.EndDate = nil
+		if *request.EndDate != "" {
+			if next.EndDate, err = parseOptionalEndDate(request.EndDate); err != nil {
+				respondError(ctx, 400, err.Error(), nil)
+				return
+			}
+		}
+	}
+	if _, ok := next.OccurrenceDate(0); !ok {
+		respondError(ctx, 400, "a nova regra não tem nenhuma ocorrência", nil)
+		return
+	}
+
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		if index == 0 {
+			if err := tx.Delete(rule).Error; err != nil {
+				return err
+			}
+		} else {
+			updates := map[string]interface{}{"count": index}
+			if rule.NextIndex >= index {
+				updates["next_index"] = index
+				updates["next_occurrence_at"] = nil
+			}
+			if err := tx.Model(rule).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+
+		model, _ := occurrenceTarget(rule.Kind)
+		if err := tx.Where("recurrence_rule_id = ? AND recurrence_index >= ?", rule.ID, index).
+			Delete(model).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("recurrence_rule_id = ? AND occurrence_index >= ?", rule.ID, index).
+			Delete(&schemas.RecurrenceSkip{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&next).Error; err != nil {
+			return err
+		}
+		return materializeRecurrence(tx, &next, time.Now())
+	})
+	if err != nil {
+		respondError(ctx, 500, "erro ao alterar recorrência", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "recorrência alterada a partir da ocorrência", toRecurrenceRuleResponse(&next))
+}
+
+// materializeDueRecurrences gera as ocorrências vencidas de todas as regras; é idempotente e pode
+// rodar em paralelo, pois os índices únicos de despesas e receitas descartam ocorrências repetidas.
+func materializeDueRecurrences(ctx context.Context) error {
+	now := time.Now()
+	db := getDB().WithContext(ctx)
+
+	var rules []schemas.RecurrenceRule
+	if err := db.Where("next_occurrence_at IS NOT NULL AND next_occurrence_at <= ?", now).
+		Find(&rules).Error; err != nil {
+		return err
+	}
+
+	for i := range rules {
+		rule := &rules[i]
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			return materializeRecurrence(tx, rule, now)
+		}); err != nil {
+			getLogger().ErrorF("erro ao gerar ocorrências da recorrência %s: %v", rule.ID, err)
+		}
+	}
+	return nil
+}
+
+// materializeRecurrence cria as despesas ou receitas das ocorrências da regra com data até o horizonte, a partir
+// de NextIndex, e avança NextIndex e NextOccurrenceAt.
+func materializeRecurrence(tx *gorm.DB, rule *schemas.RecurrenceRule, horizon time.Time) error {
+	skipped, err := recurrenceSkips(tx, rule)
+	if err != nil {
+		return err
+	}
+
+	index := rule.NextIndex
+	var nextAt *time.Time
+	for generated := 0; ; generated++ {
+		date, ok := rule.OccurrenceDate(index)
+		if !ok {
+			break
+		}
+		if date.After(horizon) || generated >= maxOccurrencesPerRun {
+			nextAt = &date
+			break
+		}
+		if !skipped[index] {
+			entry, _ := newOccurrence(rule, index, date)
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(entry).Error; err != nil {
+				return err
+			}
+		}
+		index++
+	}
+
+	rule.NextIndex = index
+	rule.NextOccurrenceAt = nextAt
+	return tx.Model(rule).Updates(map[string]interface{}{
+		"next_index":         index,
+		"next_occurrence_at": nextAt,
+	}).Error
+}
+
+// occurrenceRow reúne as colunas comuns a despesas e receitas usadas pelas recorrências; a origem,
+// exclusiva das despesas, é lida à parte.
+type occurrenceRow struct {
+	ID               uuid.UUID
+	HouseholdID      *uuid.UUID
+	CategoryID       uuid.UUID
+	Description      string
+	Amount           float64
+	Date             time.Time
+	AccountID        *uuid.UUID
+	RecurrenceRuleID *uuid.UUID
+	RecurrenceIndex  *int
+	DeletedAt        gorm.DeletedAt
+}
+
+// occurrenceTarget devolve o model e a tabela dos lançamentos gerados por regras da natureza informada.
+func occurrenceTarget(kind schemas.CategoryKind) (interface{}, string) {
+	if kind == schemas.CategoryKindIncome {
+		return &schemas.Income{}, "incomes"
+	}
+	return &schemas.Expense{}, "expenses"
+}
+
+// newOccurrence monta a despesa ou receita da ocorrência, já com o ID definido.
+func newOccurrence(rule *schemas.RecurrenceRule, index int, date time.Time) (interface{}, uuid.UUID) {
+	id := uuid.New()
+	ruleID := rule.ID
+	if rule.Kind == schemas.CategoryKindIncome {
+		return &schemas.Income{
+			UUIDModel:        schemas.UUIDModel{ID: id},
+			UserID:           rule.UserID,
+			HouseholdID:      rule.HouseholdID,
+			CategoryID:       rule.CategoryID,
+			Description:      rule.Description,
+			Amount:           rule.Amount,
+			Date:             date,
+			Recurring:        true,
+			AccountID:        rule.AccountID,
+			RecurrenceRuleID: &ruleID,
+			RecurrenceIndex:  &index,
+		}, id
+	}
+	return &schemas.Expense{
+		UUIDModel:        schemas.UUIDModel{ID: id},
+		UserID:           rule.UserID,
+		HouseholdID:      rule.HouseholdID,
+		CategoryID:       rule.CategoryID,
+		Description:      rule.Description,
+		Amount:           rule.Amount,
+		Date:             date,
+		Recurring:        true,
+		Origin:           rule.Origin,
+		AccountID:        rule.AccountID,
+		RecurrenceRuleID: &ruleID,
+		RecurrenceIndex:  &index,
+	}, id
+}
+
+// recurrenceScope é o escopo de chave de API exigido para ler ou alterar regras da natureza informada.
+func recurrenceScope(kind schemas.CategoryKind, write bool) schemas.APIKeyScope {
+	switch {
+	case kind == schemas.CategoryKindIncome && write:
+		return schemas.APIKeyScopeIncomesWrite
+	case kind == schemas.CategoryKindIncome:
+		return schemas.APIKeyScopeIncomesRead
+	case write:
+		return schemas.APIKeyScopeExpensesWrite
+	default:
+		return schemas.APIKeyScopeExpensesRead
+	}
+}
+
+// recurrenceSkips devolve os índices pulados da regra a partir de NextIndex.
+func recurrenceSkips(db *gorm.DB, rule *schemas.RecurrenceRule) (map[int]bool, error) {
+	var skips []schemas.RecurrenceSkip
+	if err := db.Where("recurrence_rule_id = ? AND occurrence_index >= ?", rule.ID, rule.NextIndex).
+		Find(&skips).Error; err != nil {
+		return nil, err
+	}
+	skipped := make(map[int]bool, len(skips))
+	for _, skip := range skips {
+		skipped[skip.OccurrenceIndex] = true
+	}
+	return skipped, nil
+}
+
+// loadRecurrenceRule carrega a regra do parâmetro id dentro do escopo e confere o escopo da chave de API
+// para a natureza da regra, respondendo 400/403/404/500 em caso de erro.
+func loadRecurrenceRule(ctx *gin.Context, db *gorm.DB, scope dataScope, write bool) (*schemas.RecurrenceRule, bool) {
+	ruleID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return nil, false
+	}
+
+	rule := schemas.RecurrenceRule{}
+	if err := scope.apply(db, "recurrence_rules").Where("id = ?", ruleID).First(&rule).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "recorrência não encontrada", nil)
+			return nil, false
+		}
+		respondError(ctx, 500, "erro ao carregar recorrência", err.Error())
+		return nil, false
+	}
+	if required := recurrenceScope(rule.Kind, write); !apiKeyAllows(ctx, required) {
+		respondInsufficientScope(ctx, required)
+		return nil, false
+	}
+	return &rule, true
+}
+
+func parseOccurrenceIndex(ctx *gin.Context) (int, bool) {
+	index, err := strconv.Atoi(ctx.Param("index"))
+	if err != nil || index < 0 {
+		respondError(ctx, 400, "índice de ocorrência inválido", nil)
+		return 0, false
+	}
+	return index, true
+}
+
+// parseOptionalEndDate interpreta a data final da regra como inclusiva, valendo até o fim do dia.
+func parseOptionalEndDate(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	date, err := parseDate(*value)
+	if err != nil {
+		return nil, errors.New("endDate inválido")
+	}
+	end := time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, date.Location())
+	return &end, nil
+}
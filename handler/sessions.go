@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type sessionClient struct {
+	DeviceName string
+	UserAgent  string
+	IPAddress  string
+}
+
+func newSessionClient(ctx *gin.Context, deviceName string) sessionClient {
+	name := strings.TrimSpace(deviceName)
+	if name == "" {
+		name = strings.TrimSpace(ctx.GetHeader("X-Device-Name"))
+	}
+	return sessionClient{
+		DeviceName: truncateString(name, 120),
+		UserAgent:  truncateString(ctx.Request.UserAgent(), 255),
+		IPAddress:  truncateString(ctx.ClientIP(), 45),
+	}
+}
+
+// ListSessionsHandler godoc
+// @Summary Listar sessões ativas
+// @Description Lista os dispositivos com sessão ativa do usuário autenticado
+// @Tags Auth
+// @Security Bearer
+// @Produce json
+// @Success 200 {object} SessionListSuccess
+// @Failure 401 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/sessions [get]
+func ListSessionsHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	var sessions []schemas.Session
+	if err := getDB().
+		Where("user_id = ? AND valid = ? AND expires_at > ?", user.ID, true, time.Now()).
+		Order("last_seen_at DESC, created_at DESC").
+		Find(&sessions).Error; err != nil {
+		respondError(ctx, 500, "erro ao listar sessões", err.Error())
+		return
+	}
+
+	currentToken := ""
+	if current, ok := getCurrentSession(ctx); ok {
+		currentToken = current.Token
+	}
+
+	responses := make([]SessionResponse, len(sessions))
+	for i := range sessions {
+		responses[i] = toSessionResponse(&sessions[i], sessions[i].Token == currentToken)
+	}
+
+	respondSuccess(ctx, "sessões", responses)
+}
+
+// RevokeSessionHandler godoc
+// @Summary Revogar sessão
+// @Description Encerra uma sessão específica do usuário autenticado e revoga os refresh tokens do dispositivo
+// @Tags Auth
+// @Security Bearer
+// @Produce json
+// @Param id path string true "Identificador da sessão"
+// @Success 200 {object} APISuccess
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 404 {object} APIError
+// @Failure 500 {object} APIError
+// @Router /auth/sessions/{id} [delete]
+func RevokeSessionHandler(ctx *gin.Context) {
+	user, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		respondError(ctx, 401, "não autenticado", nil)
+		return
+	}
+
+	sessionID, err := parseUUIDParam(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, 400, "id inválido", nil)
+		return
+	}
+
+	err = getDB().Transaction(func(tx *gorm.DB) error {
+		session := schemas.Session{}
+		if err := tx.Where("id = ? AND user_id = ? AND valid = ?", sessionID, user.ID, true).First(&session).Error; err != nil {
+			return err
+		}
+		if err := revokeRefreshTokensForSession(tx, session.Token); err != nil {
+			return err
+		}
+		return tx.Model(&schemas.Session{}).
+			Where("token = ?", session.Token).
+			Updates(map[string]interface{}{"valid": false}).Error
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(ctx, 404, "sessão não encontrada", nil)
+			return
+		}
+		respondError(ctx, 500, "erro ao revogar sessão", err.Error())
+		return
+	}
+
+	respondSuccess(ctx, "sessão revogada", nil)
+}
+
+// touchSession renova a validade deslizante e registra o último acesso da sessão,
+// gravando no banco no máximo uma vez por intervalo de atualização.
+func touchSession(ctx *gin.Context, session *schemas.Session) error {
+	now := time.Now()
+	updates := map[string]interface{}{}
+
+	if session.LastSeenAt == nil || now.Sub(*session.LastSeenAt) >= getSessionTouchInterval() {
+		updates["last_seen_at"] = now
+		updates["ip_address"] = truncateString(ctx.ClientIP(), 45)
+	}
+
+	ttl := getSessionTTL()
+	if time.Until(session.ExpiresAt) <= ttl/2 {
+		newExpiry := now.Add(ttl)
+		if maxExpiry := session.CreatedAt.Add(getRefreshTTL()); newExpiry.After(maxExpiry) {
+			newExpiry = maxExpiry
+		}
+		if newExpiry.After(session.ExpiresAt) {
+			updates["expires_at"] = newExpiry
+		}
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	if err := getDB().Model(&schemas.Session{}).
+		Where("token = ?", session.Token).
+		Updates(updates).Error; err != nil {
+		return err
+	}
+
+	if lastSeen, ok := updates["last_seen_at"].(time.Time); ok {
+		session.LastSeenAt = &lastSeen
+		session.IPAddress = updates["ip_address"].(string)
+	}
+	if expiry, ok := updates["expires_at"].(time.Time); ok {
+		session.ExpiresAt = expiry
+	}
+	return nil
+}
+
+func truncateString(value string, max int) string {
+	runes := []rune(value)
+	if len(runes) <= max {
+		return value
+	}
+	return string(runes[:max])
+}
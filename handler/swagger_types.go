@@ -12,6 +12,96 @@ type UserProfileSuccess struct {
 	Data    UserResponse `json:"data"`
 }
 
+// AdminUserListSuccess representa a listagem paginada de usuários para administração.
+type AdminUserListSuccess struct {
+	Message string                `json:"message"`
+	Data    AdminUserListResponse `json:"data"`
+}
+
+// AdminUserDetailSuccess representa os detalhes administrativos de um usuário.
+type AdminUserDetailSuccess struct {
+	Message string                  `json:"message"`
+	Data    AdminUserDetailResponse `json:"data"`
+}
+
+// AdminTokenUsageSuccess representa o consumo de tokens agregado de todos os usuários.
+type AdminTokenUsageSuccess struct {
+	Message string                  `json:"message"`
+	Data    AdminTokenUsageResponse `json:"data"`
+}
+
+// HouseholdSuccess representa um grupo familiar com seus membros.
+type HouseholdSuccess struct {
+	Message string            `json:"message"`
+	Data    HouseholdResponse `json:"data"`
+}
+
+// HouseholdListSuccess representa os grupos familiares dos quais o usuário participa.
+type HouseholdListSuccess struct {
+	Message string              `json:"message"`
+	Data    []HouseholdResponse `json:"data"`
+}
+
+// HouseholdInvitationSuccess representa um convite para o grupo familiar.
+type HouseholdInvitationSuccess struct {
+	Message string                      `json:"message"`
+	Data    HouseholdInvitationResponse `json:"data"`
+}
+
+// HouseholdInvitationListSuccess representa os convites pendentes do grupo familiar.
+type HouseholdInvitationListSuccess struct {
+	Message string                        `json:"message"`
+	Data    []HouseholdInvitationResponse `json:"data"`
+}
+
+// APIKeyListSuccess representa as chaves de API ativas do usuário.
+type APIKeyListSuccess struct {
+	Message string           `json:"message"`
+	Data    []APIKeyResponse `json:"data"`
+}
+
+// APIKeyCreatedSuccess representa a chave de API recém-criada, exibida uma única vez.
+type APIKeyCreatedSuccess struct {
+	Message string                `json:"message"`
+	Data    CreatedAPIKeyResponse `json:"data"`
+}
+
+// IdentityListSuccess representa os provedores externos vinculados ao usuário.
+type IdentityListSuccess struct {
+	Message string             `json:"message"`
+	Data    []IdentityResponse `json:"data"`
+}
+
+// AccountDeletionSuccess representa o agendamento da exclusão da conta.
+type AccountDeletionSuccess struct {
+	Message string                  `json:"message"`
+	Data    AccountDeletionResponse `json:"data"`
+}
+
+// MFAChallengeSuccess representa o desafio de segundo fator retornado pelo login.
+type MFAChallengeSuccess struct {
+	Message string               `json:"message"`
+	Data    MFAChallengeResponse `json:"data"`
+}
+
+// MFAEnrollSuccess representa o segredo TOTP gerado no cadastro do segundo fator.
+type MFAEnrollSuccess struct {
+	Message string            `json:"message"`
+	Data    MFAEnrollResponse `json:"data"`
+}
+
+// MFARecoveryCodesSuccess representa a lista de códigos de recuperação, exibida uma única vez.
+type MFARecoveryCodesSuccess struct {
+	Message string                   `json:"message"`
+	Data    MFARecoveryCodesResponse `json:"data"`
+}
+
+// SessionListSuccess representa a listagem de sessões ativas do usuário.
+type SessionListSuccess struct {
+	Message string            `json:"message"`
+	Data    []SessionResponse `json:"data"`
+}
+
 // CategoryListSuccess representa a listagem de categorias embrulhada no padrão APISuccess.
 type CategoryListSuccess struct {
 	Message string             `json:"message"`
@@ -30,6 +120,126 @@ type ExpenseItemSuccess struct {
 	Data    ExpenseResponse `json:"data"`
 }
 
+// IncomeItemSuccess representa respostas com uma única receita.
+type IncomeItemSuccess struct {
+	Message string         `json:"message"`
+	Data    IncomeResponse `json:"data"`
+}
+
+// IncomeListSuccess representa a listagem de receitas com resumo agregado.
+type IncomeListSuccess struct {
+	Message string              `json:"message"`
+	Data    IncomesListResponse `json:"data"`
+}
+
+// AccountSuccess representa uma conta com o saldo atual.
+type AccountSuccess struct {
+	Message string          `json:"message"`
+	Data    AccountResponse `json:"data"`
+}
+
+// AccountListSuccess representa as contas do escopo com os saldos atuais.
+type AccountListSuccess struct {
+	Message string            `json:"message"`
+	Data    []AccountResponse `json:"data"`
+}
+
+// AccountBalanceListSuccess representa o histórico de saldo acumulado de cada conta no período.
+type AccountBalanceListSuccess struct {
+	Message string                  `json:"message"`
+	Data    []AccountBalanceHistory `json:"data"`
+}
+
+// GoalSuccess representa uma meta de economia com o progresso.
+type GoalSuccess struct {
+	Message string       `json:"message"`
+	Data    GoalResponse `json:"data"`
+}
+
+// GoalListSuccess representa a listagem de metas de economia.
+type GoalListSuccess struct {
+	Message string         `json:"message"`
+	Data    []GoalResponse `json:"data"`
+}
+
+// GoalProgressSuccess representa o resumo do progresso das metas ativas.
+type GoalProgressSuccess struct {
+	Message string              `json:"message"`
+	Data    GoalProgressSummary `json:"data"`
+}
+
+// BudgetSuccess representa um orçamento mensal de categoria.
+type BudgetSuccess struct {
+	Message string         `json:"message"`
+	Data    BudgetResponse `json:"data"`
+}
+
+// BudgetListSuccess representa a listagem de orçamentos.
+type BudgetListSuccess struct {
+	Message string           `json:"message"`
+	Data    []BudgetResponse `json:"data"`
+}
+
+// BudgetStatusSuccess representa a situação dos orçamentos no mês.
+type BudgetStatusSuccess struct {
+	Message string               `json:"message"`
+	Data    BudgetStatusResponse `json:"data"`
+}
+
+// BudgetAlertListSuccess representa os alertas de orçamento do mês.
+type BudgetAlertListSuccess struct {
+	Message string                `json:"message"`
+	Data    []BudgetAlertResponse `json:"data"`
+}
+
+// StatementListSuccess representa as faturas do cartão de crédito com suas despesas.
+type StatementListSuccess struct {
+	Message string              `json:"message"`
+	Data    []StatementResponse `json:"data"`
+}
+
+// InstallmentPurchaseSuccess representa uma compra parcelada com suas parcelas.
+type InstallmentPurchaseSuccess struct {
+	Message string                      `json:"message"`
+	Data    InstallmentPurchaseResponse `json:"data"`
+}
+
+// InstallmentPurchaseListSuccess representa a listagem de compras parceladas.
+type InstallmentPurchaseListSuccess struct {
+	Message string                        `json:"message"`
+	Data    []InstallmentPurchaseResponse `json:"data"`
+}
+
+// TransferSuccess representa uma transferência entre contas.
+type TransferSuccess struct {
+	Message string           `json:"message"`
+	Data    TransferResponse `json:"data"`
+}
+
+// TransferListSuccess representa a listagem de transferências entre contas.
+type TransferListSuccess struct {
+	Message string             `json:"message"`
+	Data    []TransferResponse `json:"data"`
+}
+
+// RecurrenceRuleSuccess representa uma regra de recorrência de despesa ou receita.
+type RecurrenceRuleSuccess struct {
+	Message string                 `json:"message"`
+	Data    RecurrenceRuleResponse `json:"data"`
+}
+
+// RecurrenceRuleListSuccess representa as regras de recorrência ativas.
+type RecurrenceRuleListSuccess struct {
+	Message string                   `json:"message"`
+	Data    []RecurrenceRuleResponse `json:"data"`
+}
+
+// RecurrenceOccurrenceListSuccess representa a prévia das próximas ocorrências de uma recorrência.
+type RecurrenceOccurrenceListSuccess struct {
+	Message string                         `json:"message"`
+	Data    []RecurrenceOccurrenceResponse `json:"data"`
+}
+
 // ExpenseListSuccess representa a listagem de despesas com resumo agregado.
 type ExpenseListSuccess struct {
 	Message string               `json:"message"`
@@ -42,12 +252,48 @@ type DashboardSummarySuccess struct {
 	Data    DashboardSummaryResponse `json:"data"`
 }
 
+// CashFlowSuccess representa o fluxo de caixa mensal (receitas, despesas e saldo).
+type CashFlowSuccess struct {
+	Message string           `json:"message"`
+	Data    CashFlowResponse `json:"data"`
+}
+
 // ReceiptScanSuccess representa a resposta do processamento de recibos com OCR.
 type ReceiptScanSuccess struct {
 	Message string              `json:"message"`
 	Data    ReceiptScanResponse `json:"data"`
 }
 
+// ReceiptDraftSuccess representa respostas com um único rascunho de recibo.
+type ReceiptDraftSuccess struct {
+	Message string               `json:"message"`
+	Data    ReceiptDraftResponse `json:"data"`
+}
+
+// ReceiptDraftListSuccess representa a listagem de rascunhos de recibo.
+type ReceiptDraftListSuccess struct {
+	Message string                 `json:"message"`
+	Data    []ReceiptDraftResponse `json:"data"`
+}
+
+// ReceiptJobSuccess representa um processamento de recibo enfileirado e sua situação.
+type ReceiptJobSuccess struct {
+	Message string             `json:"message"`
+	Data    ReceiptJobResponse `json:"data"`
+}
+
+// CategorySuggestionSuccess representa a sugestão de categoria para um lançamento e seus itens.
+type CategorySuggestionSuccess struct {
+	Message string                     `json:"message"`
+	Data    CategorySuggestionResponse `json:"data"`
+}
+
+// MerchantListSuccess representa a listagem de estabelecimentos com os totais gastos.
+type MerchantListSuccess struct {
+	Message string             `json:"message"`
+	Data    []MerchantResponse `json:"data"`
+}
+
 // TipsListSuccess representa a listagem de dicas financeiras.
 type TipsListSuccess struct {
 	Message string        `json:"message"`
@@ -157,9 +157,14 @@ func generateTipsWithAI(ctx *gin.Context, user *schemas.User, month, year int) (
 	}
 
 	start, end := monthInterval(month, year)
-	total := aggregateTotal(user.ID, start, end)
-	topCategories := fetchTopCategories(user.ID, start, end)
+	total := aggregateTotal(personalScope(user.ID), start, end)
+	income := aggregateIncome(personalScope(user.ID), start, end)
+	topCategories, err := fetchTopCategories(personalScope(user.ID), start, end)
+	if err != nil {
+		return nil, nil, "", err
+	}
 	recentExpenses := fetchRecentExpenses(ctx.Request.Context(), user.ID, 6)
+	goals := fetchGoalProgress(personalScope(user.ID))
 
 	currency := "BRL"
 	monthlyLimit := 0.0
@@ -176,7 +181,7 @@ func generateTipsWithAI(ctx *gin.Context, user *schemas.User, month, year int) (
 		}
 	}
 
-	prompt := buildTipsPrompt(user.Name, currency, language, month, year, total, monthlyLimit, topCategories, recentExpenses)
+	prompt := buildTipsPrompt(user.Name, currency, language, month, year, total, income, monthlyLimit, topCategories, recentExpenses, goals)
 	modelName := detectModelName()
 
 	req := gemini.GenerateContentRequest{
@@ -249,9 +254,8 @@ func fetchRecentExpenses(ctx context.Context, userID uuid.UUID, limit int) []sch
 	if limit <= 0 {
 		limit = 5
 	}
-	getDB().WithContext(ctx).Preload("Category").
-		Where("user_id = ?", userID).
-		Order("date DESC").
+	personalScope(userID).apply(getDB().WithContext(ctx).Preload("Category"), "expenses").
+		Order("expenses.date DESC").
 		Limit(limit).
 		Find(&expenses)
 	return expenses
@@ -322,7 +326,7 @@ func clampRelevance(value int) int {
 	return value
 }
 
-func buildTipsPrompt(name, currency, language string, month, year int, total, limit float64, categories []CategoryAggregate, expenses []schemas.Expense) string {
+func buildTipsPrompt(name, currency, language string, month, year int, total, income, limit float64, categories []CategoryAggregate, expenses []schemas.Expense, goals []GoalResponse) string {
 	var builder strings.Builder
 	builder.WriteString("Você é um assistente financeiro pessoal.\n")
 	builder.WriteString("Use os dados fornecidos para criar de 3 a 5 dicas práticas e motivacionais.\n")
@@ -332,6 +336,10 @@ func buildTipsPrompt(name, currency, language string, month, year int, total, li
 	builder.WriteString(fmt.Sprintf("- Nome: %s\n", strings.TrimSpace(name)))
 	builder.WriteString(fmt.Sprintf("- Mês analisado: %02d/%d\n", month, year))
 	builder.WriteString(fmt.Sprintf("- Total gasto no período: %.2f %s\n", total, currency))
+	if income > 0 {
+		builder.WriteString(fmt.Sprintf("- Receitas no período: %.2f %s\n", income, currency))
+		builder.WriteString(fmt.Sprintf("- Saldo (receitas - despesas): %.2f %s, taxa de poupança de %.1f%%\n", income-total, currency, savingsRate(income, total)))
+	}
 	if limit > 0 {
 		builder.WriteString(fmt.Sprintf("- Limite mensal configurado: %.2f %s\n", limit, currency))
 	}
@@ -354,8 +362,19 @@ func buildTipsPrompt(name, currency, language string, month, year int, total, li
 			builder.WriteString(fmt.Sprintf("  - %s: %s em %s (%.2f %s)\n", exp.Date.Format("02/01"), exp.Description, catName, exp.Amount, currency))
 		}
 	}
+	if len(goals) > 0 {
+		builder.WriteString("- Metas de economia:\n")
+		for _, goal := range goals {
+			builder.WriteString(fmt.Sprintf("  - %s: %.2f de %.2f %s guardados (%.0f%%), prazo %s, aporte mensal necessário de %.2f %s, situação: %s\n",
+				goal.Name, goal.Progress.Saved, goal.TargetAmount, currency, goal.Progress.PercentComplete, goal.Deadline.Format("01/2006"),
+				goal.Progress.RequiredMonthly, currency, goalStatusLabels[goal.Progress.Status]))
+		}
+	}
 	builder.WriteString("Considera que o idioma preferido do usuário é " + language + ". Sempre inclua orientações acionáveis, curtas e claras.\n")
-	builder.WriteString("Se o usuário estiver perto ou acima do limite, priorize dicas de alerta e planejamento.\n")
+	builder.WriteString("Se o usuário estiver perto ou acima do limite, ou gastando mais do que recebe, priorize dicas de alerta e planejamento.\n")
+	if len(goals) > 0 {
+		builder.WriteString("Inclua ao menos uma dica sobre as metas de economia, especialmente as atrasadas, citando o aporte mensal necessário.\n")
+	}
 	builder.WriteString("Garanta que cada dica esteja adaptada ao contexto apresentado.\n")
 	return builder.String()
 }
@@ -366,11 +385,25 @@ func generateHeuristicTips(user *schemas.User) ([]schemas.GeneratedTip, error) {
 	year := now.Year()
 	start, end := monthInterval(month, year)
 
-	total := aggregateTotal(user.ID, start, end)
-	tops := fetchTopCategories(user.ID, start, end)
+	total := aggregateTotal(personalScope(user.ID), start, end)
+	income := aggregateIncome(personalScope(user.ID), start, end)
+	tops, err := fetchTopCategories(personalScope(user.ID), start, end)
+	if err != nil {
+		return nil, err
+	}
 
 	tips := []schemas.GeneratedTip{}
 
+	if income > 0 && total > income {
+		tips = append(tips, schemas.GeneratedTip{
+			UserID:      user.ID,
+			Type:        schemas.TipTypeAlert,
+			Text:        fmt.Sprintf("Seus gastos deste mês (R$ %.2f) já superam suas receitas (R$ %.2f). Corte despesas variáveis até equilibrar o saldo.", total, income),
+			ModelSource: "heuristic",
+			Relevance:   90,
+		})
+	}
+
 	if user.Config != nil && user.Config.MonthlyLimit > 0 {
 		limit := user.Config.MonthlyLimit
 		if total > limit {
@@ -392,6 +425,34 @@ func generateHeuristicTips(user *schemas.User) ([]schemas.GeneratedTip, error) {
 		}
 	}
 
+	if periods, err := budgetPeriods(personalScope(user.ID), start); err == nil {
+		for _, period := range periods {
+			if period.Budget.Category == nil || period.percentUsed() < 100 {
+				continue
+			}
+			tips = append(tips, schemas.GeneratedTip{
+				UserID:      user.ID,
+				Type:        schemas.TipTypeAlert,
+				Text:        fmt.Sprintf("O orçamento de %s (R$ %.2f) já foi atingido neste mês, com R$ %.2f gastos. Adie compras dessa categoria até o próximo mês.", period.Budget.Category.Name, period.limit(), period.Spent),
+				ModelSource: "heuristic",
+				Relevance:   92,
+			})
+		}
+	}
+
+	for _, goal := range fetchGoalProgress(personalScope(user.ID)) {
+		if goal.Progress.Status != goalStatusBehind {
+			continue
+		}
+		tips = append(tips, schemas.GeneratedTip{
+			UserID:      user.ID,
+			Type:        schemas.TipTypePlanning,
+			Text:        fmt.Sprintf("A meta %s está atrasada: faltam R$ %.2f. Guarde R$ %.2f por mês para chegar lá até %s.", goal.Name, goal.Progress.Remaining, goal.Progress.RequiredMonthly, goal.Deadline.Format("01/2006")),
+			ModelSource: "heuristic",
+			Relevance:   85,
+		})
+	}
+
 	if len(tops) > 0 {
 		top := tops[0]
 		tips = append(tips, schemas.GeneratedTip{
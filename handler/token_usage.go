@@ -107,12 +107,7 @@ func ListTokenUsageHandler(ctx *gin.Context) {
 
 	response := TokenUsageListResponse{
 		Entries: entries,
-		Summary: TokenUsageSummary{
-			TotalPromptTokens:   totals.PromptSum,
-			TotalResponseTokens: totals.ResponseSum,
-			TotalTokens:         totals.TotalSum,
-			TotalCostCents:      totals.CostSum,
-		},
+		Summary: totals.summary(),
 		Pagination: TokenUsagePagination{
 			Page:         page,
 			Limit:        limit,
@@ -123,6 +118,8 @@ func ListTokenUsageHandler(ctx *gin.Context) {
 	respondSuccess(ctx, "consumo de tokens", response)
 }
 
+const tokenUsageTotalsSelect = "COALESCE(SUM(prompt_tokens),0) AS prompt_sum, COALESCE(SUM(response_tokens),0) AS response_sum, COALESCE(SUM(total_tokens),0) AS total_sum, COALESCE(SUM(cost_in_cents),0) AS cost_sum"
+
 type tokenUsageTotals struct {
 	PromptSum   int64 `gorm:"column:prompt_sum"`
 	ResponseSum int64 `gorm:"column:response_sum"`
@@ -130,10 +127,19 @@ type tokenUsageTotals struct {
 	CostSum     int64 `gorm:"column:cost_sum"`
 }
 
+func (t tokenUsageTotals) summary() TokenUsageSummary {
+	return TokenUsageSummary{
+		TotalPromptTokens:   t.PromptSum,
+		TotalResponseTokens: t.ResponseSum,
+		TotalTokens:         t.TotalSum,
+		TotalCostCents:      t.CostSum,
+	}
+}
+
 func aggregateTokenUsageTotals(userID uuid.UUID) (tokenUsageTotals, error) {
 	totals := tokenUsageTotals{}
 	err := getDB().Model(&schemas.TokenUsage{}).
-		Select("COALESCE(SUM(prompt_tokens),0) AS prompt_sum, COALESCE(SUM(response_tokens),0) AS response_sum, COALESCE(SUM(total_tokens),0) AS total_sum, COALESCE(SUM(cost_in_cents),0) AS cost_sum").
+		Select(tokenUsageTotalsSelect).
 		Where("user_id = ?", userID).
 		Scan(&totals).Error
 	return totals, err
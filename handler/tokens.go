@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	errRefreshTokenInvalid = errors.New("refresh token inválido")
+	errRefreshTokenExpired = errors.New("refresh token expirado")
+	errRefreshTokenReused  = errors.New("refresh token reutilizado")
+)
+
+type issuedTokens struct {
+	Session          *schemas.Session
+	AccessToken      string
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+func (t *issuedTokens) toAuthResponse(user *schemas.User) AuthResponse {
+	return AuthResponse{
+		Token:            t.AccessToken,
+		ExpiresAt:        t.Session.ExpiresAt,
+		RefreshToken:     t.RefreshToken,
+		RefreshExpiresAt: t.RefreshExpiresAt,
+		User:             toUserResponse(user),
+	}
+}
+
+// issueTokens cria uma sessão de acesso e inicia uma nova família de refresh tokens.
+func issueTokens(tx *gorm.DB, userID uuid.UUID, client sessionClient) (*issuedTokens, error) {
+	return issueTokensInFamily(tx, userID, client, uuid.New(), nil)
+}
+
+func issueTokensInFamily(tx *gorm.DB, userID uuid.UUID, client sessionClient, familyID uuid.UUID, parentID *uuid.UUID) (*issuedTokens, error) {
+	session, accessToken, err := createSession(tx, userID, client)
+	if err != nil {
+		return nil, err
+	}
+
+	rawRefresh, err := generateSessionToken()
+	if err != nil {
+		return nil, err
+	}
+
+	refresh := schemas.RefreshToken{
+		UserID:       userID,
+		FamilyID:     familyID,
+		ParentID:     parentID,
+		TokenHash:    schemas.HashToken(rawRefresh),
+		SessionToken: session.Token,
+		ExpiresAt:    time.Now().Add(getRefreshTTL()),
+	}
+	if err := tx.Create(&refresh).Error; err != nil {
+		return nil, err
+	}
+
+	return &issuedTokens{
+		Session:          session,
+		AccessToken:      accessToken,
+		RefreshToken:     rawRefresh,
+		RefreshExpiresAt: refresh.ExpiresAt,
+	}, nil
+}
+
+// rotateRefreshToken consome o refresh token informado e emite um novo par na mesma família.
+// Quando um token já utilizado ou revogado é reapresentado, a família inteira é revogada e
+// errRefreshTokenReused é retornado; o chamador deve confirmar a transação nesse caso.
+func rotateRefreshToken(tx *gorm.DB, rawToken string, client sessionClient) (*issuedTokens, error) {
+	current := schemas.RefreshToken{}
+	if err := tx.Where("token_hash = ?", schemas.HashToken(rawToken)).First(&current).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errRefreshTokenInvalid
+		}
+		return nil, err
+	}
+
+	if current.UsedAt != nil || current.RevokedAt != nil {
+		if err := revokeRefreshFamily(tx, current.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, errRefreshTokenReused
+	}
+
+	if current.ExpiresAt.Before(time.Now()) {
+		return nil, errRefreshTokenExpired
+	}
+
+	result := tx.Model(&schemas.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL", current.ID).
+		Updates(map[string]interface{}{"used_at": time.Now()})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		if err := revokeRefreshFamily(tx, current.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, errRefreshTokenReused
+	}
+
+	previous := schemas.Session{}
+	if err := tx.Where("token = ?", current.SessionToken).First(&previous).Error; err == nil {
+		if client.DeviceName == "" {
+			client.DeviceName = previous.DeviceName
+		}
+	} else if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	if err := tx.Model(&schemas.Session{}).
+		Where("token = ?", current.SessionToken).
+		Updates(map[string]interface{}{"valid": false}).Error; err != nil {
+		return nil, err
+	}
+
+	return issueTokensInFamily(tx, current.UserID, client, current.FamilyID, &current.ID)
+}
+
+// revokeRefreshFamily revoga todos os refresh tokens da família e invalida as sessões emitidas por ela.
+func revokeRefreshFamily(tx *gorm.DB, familyID uuid.UUID) error {
+	var sessionTokens []string
+	if err := tx.Model(&schemas.RefreshToken{}).
+		Where("family_id = ?", familyID).
+		Pluck("session_token", &sessionTokens).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(&schemas.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Updates(map[string]interface{}{"revoked_at": time.Now()}).Error; err != nil {
+		return err
+	}
+
+	if len(sessionTokens) == 0 {
+		return nil
+	}
+	return tx.Model(&schemas.Session{}).
+		Where("token IN ?", sessionTokens).
+		Updates(map[string]interface{}{"valid": false}).Error
+}
+
+func revokeRefreshTokensForSession(tx *gorm.DB, sessionToken string) error {
+	var familyIDs []uuid.UUID
+	if err := tx.Model(&schemas.RefreshToken{}).
+		Where("session_token = ?", sessionToken).
+		Distinct().
+		Pluck("family_id", &familyIDs).Error; err != nil {
+		return err
+	}
+	for _, familyID := range familyIDs {
+		if err := revokeRefreshFamily(tx, familyID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func revokeUserRefreshTokens(tx *gorm.DB, userID uuid.UUID) error {
+	return tx.Model(&schemas.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{"revoked_at": time.Now()}).Error
+}
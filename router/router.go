@@ -1,6 +1,10 @@
 package router
 
 import (
+	"context"
+
+	"github.com/Pmmvito/Golang-Api-Exemple/config"
+	"github.com/Pmmvito/Golang-Api-Exemple/handler"
 	"github.com/gin-gonic/gin"
 )
 
@@ -8,7 +12,12 @@ func Initialize() {
 	//Initialize Router
 	router := gin.Default()
 	//Initialize routes
-	InitializeRoutes(router)
+	if err := InitializeRoutes(router); err != nil {
+		config.GetLogger("router").ErrorF("erro ao inicializar rotas: %v", err)
+		return
+	}
+	//Start background jobs
+	handler.StartBackgroundJobs(context.Background())
 	
 	router.Run(":8080")
 }
@@ -5,20 +5,23 @@ import (
 
 	docs "github.com/Pmmvito/Golang-Api-Exemple/docs"
 	"github.com/Pmmvito/Golang-Api-Exemple/handler"
+	"github.com/Pmmvito/Golang-Api-Exemple/schemas"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func InitializeRoutes(router *gin.Engine) {
-	handler.InitializerHandler()
+func InitializeRoutes(router *gin.Engine) error {
+	if err := handler.InitializerHandler(); err != nil {
+		return err
+	}
 
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Authorization", "Content-Type"},
-		ExposeHeaders:    []string{"Content-Type"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Authorization", "Content-Type", "X-Device-Name", "X-Household-ID"},
+		ExposeHeaders:    []string{"Content-Type", "X-Session-Expires-At"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
@@ -32,39 +35,176 @@ func InitializeRoutes(router *gin.Engine) {
 	{
 		authGroup.POST("/register", handler.RegisterHandler)
 		authGroup.POST("/login", handler.LoginHandler)
-		authGroup.Use(handler.AuthMiddleware())
+		authGroup.POST("/refresh", handler.RefreshTokenHandler)
+		authGroup.POST("/password/forgot", handler.ForgotPasswordHandler)
+		authGroup.POST("/password/reset", handler.ResetPasswordHandler)
+		authGroup.POST("/verify-email", handler.VerifyEmailHandler)
+		authGroup.POST("/mfa/verify", handler.VerifyMFAHandler)
+		authGroup.POST("/oidc/:provider", handler.OIDCLoginHandler)
+		authGroup.Use(handler.AuthMiddleware(), handler.RequireSession())
 		authGroup.POST("/logout", handler.LogoutHandler)
 		authGroup.GET("/me", handler.MeHandler)
+		authGroup.PATCH("/me", handler.UpdateProfileHandler)
+		authGroup.PUT("/me/config", handler.UpdateConfigHandler)
+		authGroup.POST("/me/password", handler.ChangePasswordHandler)
+		authGroup.GET("/me/export", handler.ExportPersonalDataHandler)
+		authGroup.DELETE("/me", handler.DeleteAccountHandler)
+		authGroup.POST("/me/deletion/cancel", handler.CancelAccountDeletionHandler)
+		authGroup.POST("/verify-email/resend", handler.ResendVerificationHandler)
+		authGroup.GET("/sessions", handler.ListSessionsHandler)
+		authGroup.DELETE("/sessions/:id", handler.RevokeSessionHandler)
+		authGroup.GET("/identities", handler.ListIdentitiesHandler)
+		authGroup.DELETE("/identities/:id", handler.UnlinkIdentityHandler)
+		authGroup.POST("/mfa/enroll", handler.EnrollMFAHandler)
+		authGroup.POST("/mfa/confirm", handler.ConfirmMFAHandler)
+		authGroup.POST("/mfa/disable", handler.DisableMFAHandler)
+		authGroup.POST("/mfa/recovery-codes", handler.RegenerateRecoveryCodesHandler)
+		authGroup.GET("/api-keys", handler.ListAPIKeysHandler)
+		authGroup.POST("/api-keys", handler.CreateAPIKeyHandler)
+		authGroup.DELETE("/api-keys/:id", handler.RevokeAPIKeyHandler)
 	}
 
-	protected := api.Group("")
-	protected.Use(handler.AuthMiddleware())
+	householdsGroup := api.Group("/households")
+	householdsGroup.Use(handler.AuthMiddleware(), handler.RequireSession())
 	{
-		protected.GET("/categories", handler.ListCategoriesHandler)
-		protected.POST("/categories", handler.CreateCategoryHandler)
-		protected.PUT("/categories/:id", handler.UpdateCategoryHandler)
-		protected.DELETE("/categories/:id", handler.DeleteCategoryHandler)
-
-		protected.GET("/expenses", handler.ListExpensesHandler)
-		protected.POST("/expenses", handler.CreateExpenseHandler)
-		protected.GET("/expenses/:id", handler.GetExpenseHandler)
-		protected.PUT("/expenses/:id", handler.UpdateExpenseHandler)
-		protected.DELETE("/expenses/:id", handler.DeleteExpenseHandler)
-
-		protected.POST("/receipts/scan", handler.ScanReceiptHandler)
-
-		protected.GET("/dashboard/summary", handler.DashboardSummaryHandler)
-
-		protected.POST("/sync/jobs", handler.TriggerSyncHandler)
-
-		protected.GET("/tips", handler.ListTipsHandler)
-		protected.POST("/tips/generate", handler.GenerateTipsHandler)
+		householdsGroup.GET("", handler.ListHouseholdsHandler)
+		householdsGroup.POST("", handler.CreateHouseholdHandler)
+		householdsGroup.POST("/invitations/accept", handler.AcceptHouseholdInvitationHandler)
+		householdsGroup.GET("/:id", handler.GetHouseholdHandler)
+		householdsGroup.PATCH("/:id", handler.UpdateHouseholdHandler)
+		householdsGroup.DELETE("/:id", handler.DeleteHouseholdHandler)
+		householdsGroup.GET("/:id/invitations", handler.ListHouseholdInvitationsHandler)
+		householdsGroup.POST("/:id/invitations", handler.CreateHouseholdInvitationHandler)
+		householdsGroup.DELETE("/:id/invitations/:invitationId", handler.RevokeHouseholdInvitationHandler)
+		householdsGroup.PUT("/:id/members/:userId", handler.UpdateHouseholdMemberHandler)
+		householdsGroup.DELETE("/:id/members/:userId", handler.RemoveHouseholdMemberHandler)
+	}
 
-		protected.GET("/token-usage", handler.ListTokenUsageHandler)
+	protected := api.Group("")
+	protected.Use(handler.AuthMiddleware(), handler.HouseholdContext())
+	{
+		categoriesRead := protected.Group("", handler.RequireScope(schemas.APIKeyScopeCategoriesRead))
+		categoriesRead.GET("/categories", handler.ListCategoriesHandler)
+		categoriesWrite := protected.Group("", handler.RequireScope(schemas.APIKeyScopeCategoriesWrite))
+		categoriesWrite.POST("/categories", handler.CreateCategoryHandler)
+		categoriesWrite.PUT("/categories/:id", handler.UpdateCategoryHandler)
+		categoriesWrite.DELETE("/categories/:id", handler.DeleteCategoryHandler)
+
+		expensesRead := protected.Group("", handler.RequireScope(schemas.APIKeyScopeExpensesRead))
+		expensesRead.GET("/expenses", handler.ListExpensesHandler)
+		expensesRead.GET("/expenses/:id", handler.GetExpenseHandler)
+		expensesWrite := protected.Group("", handler.RequireScope(schemas.APIKeyScopeExpensesWrite))
+		expensesWrite.POST("/expenses", handler.CreateExpenseHandler)
+		expensesWrite.PUT("/expenses/:id", handler.UpdateExpenseHandler)
+		expensesWrite.DELETE("/expenses/:id", handler.DeleteExpenseHandler)
+		expensesWrite.PUT("/expenses/:id/allocations", handler.SetExpenseAllocationsHandler)
+		expensesWrite.POST("/expenses/suggest-category", handler.SuggestCategoryHandler)
+
+		expensesRead.GET("/installments", handler.ListInstallmentPurchasesHandler)
+		expensesRead.GET("/installments/:id", handler.GetInstallmentPurchaseHandler)
+		expensesWrite.POST("/installments", handler.CreateInstallmentPurchaseHandler)
+		expensesWrite.DELETE("/installments/:id", handler.DeleteInstallmentPurchaseHandler)
+
+		incomesRead := protected.Group("", handler.RequireScope(schemas.APIKeyScopeIncomesRead))
+		incomesRead.GET("/incomes", handler.ListIncomesHandler)
+		incomesRead.GET("/incomes/:id", handler.GetIncomeHandler)
+		incomesWrite := protected.Group("", handler.RequireScope(schemas.APIKeyScopeIncomesWrite))
+		incomesWrite.POST("/incomes", handler.CreateIncomeHandler)
+		incomesWrite.PUT("/incomes/:id", handler.UpdateIncomeHandler)
+		incomesWrite.DELETE("/incomes/:id", handler.DeleteIncomeHandler)
+
+		accountsRead := protected.Group("", handler.RequireScope(schemas.APIKeyScopeAccountsRead))
+		accountsRead.GET("/accounts", handler.ListAccountsHandler)
+		accountsRead.GET("/accounts/balances", handler.AccountBalancesHandler)
+		accountsRead.GET("/accounts/:id", handler.GetAccountHandler)
+		accountsRead.GET("/accounts/:id/statements", handler.AccountStatementsHandler)
+		accountsRead.GET("/transfers", handler.ListTransfersHandler)
+		accountsWrite := protected.Group("", handler.RequireScope(schemas.APIKeyScopeAccountsWrite))
+		accountsWrite.POST("/accounts", handler.CreateAccountHandler)
+		accountsWrite.PUT("/accounts/:id", handler.UpdateAccountHandler)
+		accountsWrite.DELETE("/accounts/:id", handler.DeactivateAccountHandler)
+		accountsWrite.POST("/transfers", handler.CreateTransferHandler)
+		accountsWrite.DELETE("/transfers/:id", handler.DeleteTransferHandler)
+
+		budgetsRead := protected.Group("", handler.RequireScope(schemas.APIKeyScopeBudgetsRead))
+		budgetsRead.GET("/budgets", handler.ListBudgetsHandler)
+		budgetsRead.GET("/budgets/status", handler.BudgetStatusHandler)
+		budgetsRead.GET("/budgets/alerts", handler.ListBudgetAlertsHandler)
+		budgetsWrite := protected.Group("", handler.RequireScope(schemas.APIKeyScopeBudgetsWrite))
+		budgetsWrite.POST("/budgets", handler.CreateBudgetHandler)
+		budgetsWrite.PUT("/budgets/:id", handler.UpdateBudgetHandler)
+		budgetsWrite.DELETE("/budgets/:id", handler.DeleteBudgetHandler)
+
+		goalsRead := protected.Group("", handler.RequireScope(schemas.APIKeyScopeGoalsRead))
+		goalsRead.GET("/goals", handler.ListGoalsHandler)
+		goalsRead.GET("/goals/progress", handler.GoalProgressHandler)
+		goalsRead.GET("/goals/:id", handler.GetGoalHandler)
+		goalsWrite := protected.Group("", handler.RequireScope(schemas.APIKeyScopeGoalsWrite))
+		goalsWrite.POST("/goals", handler.CreateGoalHandler)
+		goalsWrite.PUT("/goals/:id", handler.UpdateGoalHandler)
+		goalsWrite.DELETE("/goals/:id", handler.DeleteGoalHandler)
+		goalsWrite.POST("/goals/:id/contributions", handler.CreateGoalContributionHandler)
+		goalsWrite.DELETE("/goals/:id/contributions/:contributionId", handler.DeleteGoalContributionHandler)
+
+		// As regras valem para despesas e receitas; o escopo da chave é conferido pela natureza da regra.
+		expensesWrite.POST("/expenses/:id/recurrence", handler.CreateRecurrenceHandler)
+		incomesWrite.POST("/incomes/:id/recurrence", handler.CreateIncomeRecurrenceHandler)
+		protected.GET("/recurrences", handler.ListRecurrencesHandler)
+		protected.GET("/recurrences/:id", handler.GetRecurrenceHandler)
+		protected.GET("/recurrences/:id/preview", handler.PreviewRecurrenceHandler)
+		protected.DELETE("/recurrences/:id", handler.DeleteRecurrenceHandler)
+		protected.POST("/recurrences/:id/occurrences/:index/skip", handler.SkipRecurrenceOccurrenceHandler)
+		protected.PUT("/recurrences/:id/occurrences/:index", handler.UpdateRecurrenceOccurrenceHandler)
+		protected.PUT("/recurrences/:id/occurrences/:index/future", handler.UpdateRecurrenceFutureHandler)
+
+		receiptsScan := protected.Group("", handler.RequireScope(schemas.APIKeyScopeReceiptsScan))
+		receiptsScan.POST("/receipts/scan", handler.ScanReceiptHandler)
+		receiptsScan.GET("/receipts/jobs/:id", handler.GetReceiptJobHandler)
+		expensesRead.GET("/receipts/:id/image", handler.GetReceiptImageHandler)
+		expensesRead.GET("/receipts/drafts", handler.ListReceiptDraftsHandler)
+		expensesRead.GET("/receipts/drafts/:id", handler.GetReceiptDraftHandler)
+		expensesWrite.PUT("/receipts/drafts/:id", handler.UpdateReceiptDraftHandler)
+		expensesWrite.POST("/receipts/drafts/:id/confirm", handler.ConfirmReceiptDraftHandler)
+		expensesWrite.DELETE("/receipts/drafts/:id", handler.DiscardReceiptDraftHandler)
+		expensesRead.GET("/merchants", handler.ListMerchantsHandler)
+
+		dashboardRead := protected.Group("", handler.RequireScope(schemas.APIKeyScopeDashboardRead))
+		dashboardRead.GET("/dashboard/summary", handler.DashboardSummaryHandler)
+		dashboardRead.GET("/dashboard/cash-flow", handler.CashFlowHandler)
+
+		syncWrite := protected.Group("", handler.RequireScope(schemas.APIKeyScopeSyncWrite))
+		syncWrite.POST("/sync/jobs", handler.TriggerSyncHandler)
+
+		tipsRead := protected.Group("", handler.RequireScope(schemas.APIKeyScopeTipsRead))
+		tipsRead.GET("/tips", handler.ListTipsHandler)
+		tipsWrite := protected.Group("", handler.RequireScope(schemas.APIKeyScopeTipsWrite))
+		tipsWrite.POST("/tips/generate", handler.GenerateTipsHandler)
+
+		tokenUsageRead := protected.Group("", handler.RequireScope(schemas.APIKeyScopeTokenUsageRead))
+		tokenUsageRead.GET("/token-usage", handler.ListTokenUsageHandler)
+
+		mealPlansRead := protected.Group("", handler.RequireScope(schemas.APIKeyScopeMealPlansRead))
+		mealPlansRead.GET("/meal-plans", handler.GetMealPlanHandler)
+		mealPlansWrite := protected.Group("", handler.RequireScope(schemas.APIKeyScopeMealPlansWrite))
+		mealPlansWrite.POST("/meal-plans/generate", handler.GenerateMealPlanHandler)
+	}
 
-		protected.GET("/meal-plans", handler.GetMealPlanHandler)
-		protected.POST("/meal-plans/generate", handler.GenerateMealPlanHandler)
+	adminGroup := api.Group("/admin")
+	adminGroup.Use(handler.AuthMiddleware(), handler.RequireSession(), handler.RequireRole(schemas.UserRoleSupport, schemas.UserRoleAdmin))
+	{
+		adminGroup.GET("/users", handler.AdminListUsersHandler)
+		adminGroup.GET("/users/:id", handler.AdminGetUserHandler)
+		adminGroup.POST("/users/:id/logout", handler.AdminForceLogoutHandler)
+		adminGroup.POST("/users/:id/unlock", handler.AdminUnlockUserHandler)
+		adminGroup.GET("/token-usage", handler.AdminTokenUsageHandler)
+
+		adminOnly := handler.RequireRole(schemas.UserRoleAdmin)
+		adminGroup.POST("/users/:id/deactivate", adminOnly, handler.AdminDeactivateUserHandler)
+		adminGroup.POST("/users/:id/activate", adminOnly, handler.AdminActivateUserHandler)
+		adminGroup.PUT("/users/:id/role", adminOnly, handler.AdminUpdateUserRoleHandler)
 	}
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
+
+	return nil
 }
@@ -1,6 +1,8 @@
 package schemas
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 
 	"github.com/google/uuid"
@@ -29,6 +31,14 @@ const (
 	CategoryTypeVariable CategoryType = "variavel"
 )
 
+// CategoryKind separa as categorias de despesas das categorias de receitas.
+type CategoryKind string
+
+const (
+	CategoryKindExpense CategoryKind = "despesa"
+	CategoryKindIncome  CategoryKind = "receita"
+)
+
 type ExpenseOrigin string
 
 const (
@@ -37,6 +47,16 @@ const (
 	ExpenseOriginAI     ExpenseOrigin = "ia"
 )
 
+// AccountType classifica as contas e carteiras de onde saem e para onde vão os lançamentos.
+type AccountType string
+
+const (
+	AccountTypeChecking   AccountType = "corrente"
+	AccountTypeSavings    AccountType = "poupanca"
+	AccountTypeCreditCard AccountType = "cartao_credito"
+	AccountTypeCash       AccountType = "dinheiro"
+)
+
 type Theme string
 
 const (
@@ -90,20 +110,36 @@ const (
 	SyncStatusPartial SyncStatus = "parcial"
 )
 
+type UserRole string
+
+const (
+	UserRoleUser    UserRole = "user"
+	UserRoleSupport UserRole = "support"
+	UserRoleAdmin   UserRole = "admin"
+)
+
 type User struct {
 	UUIDModel
-	Name         string         `gorm:"size:120" json:"name"`
-	Email        string         `gorm:"size:180;uniqueIndex" json:"email"`
-	PasswordHash string         `gorm:"size:255" json:"-"`
-	Active       bool           `gorm:"default:true" json:"active"`
-	LastLogin    *time.Time     `json:"lastLogin,omitempty"`
-	Categories   []Category     `gorm:"constraint:OnDelete:CASCADE;" json:"categories,omitempty"`
-	Expenses     []Expense      `gorm:"constraint:OnDelete:CASCADE;" json:"expenses,omitempty"`
-	Sessions     []Session      `gorm:"constraint:OnDelete:CASCADE;" json:"sessions,omitempty"`
-	Tips         []GeneratedTip `gorm:"constraint:OnDelete:CASCADE;" json:"tips,omitempty"`
-	MealPlans    []MealPlan     `gorm:"constraint:OnDelete:CASCADE;" json:"mealPlans,omitempty"`
-	SyncJobs     []SyncJob      `gorm:"constraint:OnDelete:CASCADE;" json:"syncJobs,omitempty"`
-	Config       *UserConfig    `gorm:"constraint:OnDelete:CASCADE;" json:"config,omitempty"`
+	Name            string         `gorm:"size:120" json:"name"`
+	Email           string         `gorm:"size:180;uniqueIndex" json:"email"`
+	PasswordHash    string         `gorm:"size:255" json:"-"`
+	Active          bool           `gorm:"default:true" json:"active"`
+	Role            UserRole       `gorm:"type:varchar(10);default:'user'" json:"role"`
+	EmailVerifiedAt *time.Time     `json:"emailVerifiedAt,omitempty"`
+	PendingEmail    string         `gorm:"size:180" json:"pendingEmail,omitempty"`
+	LockedUntil     *time.Time     `json:"lockedUntil,omitempty"`
+	MFAEnabled      bool           `gorm:"default:false" json:"mfaEnabled"`
+	MFASecret       string         `gorm:"size:64" json:"-"`
+	MFALastStep     int64          `gorm:"default:0" json:"-"`
+	LastLogin       *time.Time     `json:"lastLogin,omitempty"`
+	DeletionDueAt   *time.Time     `gorm:"index" json:"deletionDueAt,omitempty"`
+	Categories      []Category     `gorm:"constraint:OnDelete:CASCADE;" json:"categories,omitempty"`
+	Expenses        []Expense      `gorm:"constraint:OnDelete:CASCADE;" json:"expenses,omitempty"`
+	Sessions        []Session      `gorm:"constraint:OnDelete:CASCADE;" json:"sessions,omitempty"`
+	Tips            []GeneratedTip `gorm:"constraint:OnDelete:CASCADE;" json:"tips,omitempty"`
+	MealPlans       []MealPlan     `gorm:"constraint:OnDelete:CASCADE;" json:"mealPlans,omitempty"`
+	SyncJobs        []SyncJob      `gorm:"constraint:OnDelete:CASCADE;" json:"syncJobs,omitempty"`
+	Config          *UserConfig    `gorm:"constraint:OnDelete:CASCADE;" json:"config,omitempty"`
 }
 
 type UserConfig struct {
@@ -118,43 +154,323 @@ type UserConfig struct {
 	User                 *User     `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
 }
 
+// Category pertence ao usuário ou, quando HouseholdID é preenchido, ao grupo familiar;
+// nesse caso UserID indica apenas quem a criou.
 type Category struct {
 	UUIDModel
-	UserID   uuid.UUID    `gorm:"type:uuid;index" json:"userId"`
-	Name     string       `gorm:"size:80" json:"name"`
-	Icon     string       `gorm:"size:40" json:"icon"`
-	ColorHex string       `gorm:"size:7" json:"colorHex"`
-	Type     CategoryType `gorm:"type:varchar(12)" json:"type"`
-	Order    int          `gorm:"default:0" json:"order"`
-	Active   bool         `gorm:"default:true" json:"active"`
-	User     *User        `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
-	Expenses []Expense    `json:"expenses,omitempty"`
+	UserID      uuid.UUID    `gorm:"type:uuid;index" json:"userId"`
+	HouseholdID *uuid.UUID   `gorm:"type:uuid;index" json:"householdId,omitempty"`
+	Name        string       `gorm:"size:80" json:"name"`
+	Icon        string       `gorm:"size:40" json:"icon"`
+	ColorHex    string       `gorm:"size:7" json:"colorHex"`
+	Type        CategoryType `gorm:"type:varchar(12)" json:"type"`
+	Kind        CategoryKind `gorm:"type:varchar(10);default:'despesa';index" json:"kind"`
+	Order       int          `gorm:"default:0" json:"order"`
+	Active      bool         `gorm:"default:true" json:"active"`
+	User        *User        `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	Expenses    []Expense    `json:"expenses,omitempty"`
+}
+
+// Merchant é um estabelecimento onde o usuário compra, com a mesma regra de posse de Category. É identificado
+// no escopo pelo CNPJ (apenas dígitos) ou, quando o recibo não traz CNPJ, pelo nome normalizado.
+type Merchant struct {
+	UUIDModel
+	UserID         uuid.UUID  `gorm:"type:uuid;index" json:"userId"`
+	HouseholdID    *uuid.UUID `gorm:"type:uuid;index" json:"householdId,omitempty"`
+	Name           string     `gorm:"size:180" json:"name"`
+	NormalizedName string     `gorm:"size:180;index" json:"normalizedName"`
+	CNPJ           string     `gorm:"size:14;index" json:"cnpj"`
+	Address        string     `gorm:"size:255" json:"address"`
+	User           *User      `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+// CategoryRule guarda a categoria escolhida pelo usuário ao corrigir uma despesa, com a mesma regra de posse de
+// Category. Despesas do mesmo estabelecimento (MerchantID) ou, sem estabelecimento, com a mesma descrição
+// normalizada (Pattern) passam a receber essa categoria como sugestão. Hits conta as confirmações.
+type CategoryRule struct {
+	UUIDModel
+	UserID      uuid.UUID  `gorm:"type:uuid;index" json:"userId"`
+	HouseholdID *uuid.UUID `gorm:"type:uuid;index" json:"householdId,omitempty"`
+	MerchantID  *uuid.UUID `gorm:"type:uuid;index" json:"merchantId,omitempty"`
+	Pattern     string     `gorm:"size:180;index" json:"pattern"`
+	CategoryID  uuid.UUID  `gorm:"type:uuid;index" json:"categoryId"`
+	Hits        int        `gorm:"default:1" json:"hits"`
+	User        *User      `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	Category    *Category  `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	Merchant    *Merchant  `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
 }
 
+// Expense segue a mesma regra de posse de Category. Ocorrências geradas por uma RecurrenceRule guardam
+// a regra e o índice da ocorrência; o índice único sobre o par torna a materialização idempotente.
+// Parcelas de uma InstallmentPurchase guardam a compra e o número da parcela (1 a N). Allocations
+// dividem o valor entre outras categorias.
 type Expense struct {
 	UUIDModel
-	UserID      uuid.UUID     `gorm:"type:uuid;index" json:"userId"`
-	CategoryID  uuid.UUID     `gorm:"type:uuid;index" json:"categoryId"`
-	Description string        `gorm:"size:200" json:"description"`
-	Amount      float64       `gorm:"type:numeric(12,2)" json:"amount"`
-	Date        time.Time     `gorm:"index" json:"date"`
-	Recurring   bool          `gorm:"default:false" json:"recurring"`
-	Origin      ExpenseOrigin `gorm:"type:varchar(10);default:'manual'" json:"origin"`
-	Receipt     *Receipt      `json:"receipt,omitempty"`
-	User        *User         `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
-	Category    *Category     `gorm:"constraint:OnDelete:SET NULL" json:"category,omitempty"`
-	Items       []ExpenseItem `gorm:"constraint:OnDelete:CASCADE;" json:"items"`
+	UserID                uuid.UUID            `gorm:"type:uuid;index" json:"userId"`
+	HouseholdID           *uuid.UUID           `gorm:"type:uuid;index" json:"householdId,omitempty"`
+	CategoryID            uuid.UUID            `gorm:"type:uuid;index" json:"categoryId"`
+	Description           string               `gorm:"size:200" json:"description"`
+	Amount                float64              `gorm:"type:numeric(12,2)" json:"amount"`
+	Date                  time.Time            `gorm:"index" json:"date"`
+	Recurring             bool                 `gorm:"default:false" json:"recurring"`
+	Origin                ExpenseOrigin        `gorm:"type:varchar(10);default:'manual'" json:"origin"`
+	AccountID             *uuid.UUID           `gorm:"type:uuid;index" json:"accountId,omitempty"`
+	MerchantID            *uuid.UUID           `gorm:"type:uuid;index" json:"merchantId,omitempty"`
+	RecurrenceRuleID      *uuid.UUID           `gorm:"type:uuid;uniqueIndex:idx_expense_recurrence" json:"recurrenceRuleId,omitempty"`
+	RecurrenceIndex       *int                 `gorm:"uniqueIndex:idx_expense_recurrence" json:"recurrenceIndex,omitempty"`
+	InstallmentPurchaseID *uuid.UUID           `gorm:"type:uuid;uniqueIndex:idx_expense_installment" json:"installmentPurchaseId,omitempty"`
+	InstallmentNumber     *int                 `gorm:"uniqueIndex:idx_expense_installment" json:"installmentNumber,omitempty"`
+	InstallmentPurchase   *InstallmentPurchase `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	Receipt               *Receipt             `json:"receipt,omitempty"`
+	User                  *User                `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	Category              *Category            `gorm:"constraint:OnDelete:SET NULL" json:"category,omitempty"`
+	Account               *Account             `gorm:"constraint:OnDelete:SET NULL" json:"account,omitempty"`
+	Merchant              *Merchant            `gorm:"constraint:OnDelete:SET NULL" json:"merchant,omitempty"`
+	Items                 []ExpenseItem        `gorm:"constraint:OnDelete:CASCADE;" json:"items"`
+	Allocations           []ExpenseAllocation  `gorm:"constraint:OnDelete:CASCADE;" json:"allocations,omitempty"`
+	RecurrenceRule        *RecurrenceRule      `gorm:"constraint:OnDelete:SET NULL" json:"-"`
+}
+
+// Income é uma receita (salário, freelance, reembolso...) e segue as mesmas regras de posse e de
+// recorrência de Expense, usando categorias do tipo receita.
+type Income struct {
+	UUIDModel
+	UserID           uuid.UUID       `gorm:"type:uuid;index" json:"userId"`
+	HouseholdID      *uuid.UUID      `gorm:"type:uuid;index" json:"householdId,omitempty"`
+	CategoryID       uuid.UUID       `gorm:"type:uuid;index" json:"categoryId"`
+	Description      string          `gorm:"size:200" json:"description"`
+	Amount           float64         `gorm:"type:numeric(12,2)" json:"amount"`
+	Date             time.Time       `gorm:"index" json:"date"`
+	Recurring        bool            `gorm:"default:false" json:"recurring"`
+	AccountID        *uuid.UUID      `gorm:"type:uuid;index" json:"accountId,omitempty"`
+	RecurrenceRuleID *uuid.UUID      `gorm:"type:uuid;uniqueIndex:idx_income_recurrence" json:"recurrenceRuleId,omitempty"`
+	RecurrenceIndex  *int            `gorm:"uniqueIndex:idx_income_recurrence" json:"recurrenceIndex,omitempty"`
+	User             *User           `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	Category         *Category       `gorm:"constraint:OnDelete:SET NULL" json:"category,omitempty"`
+	Account          *Account        `gorm:"constraint:OnDelete:SET NULL" json:"account,omitempty"`
+	RecurrenceRule   *RecurrenceRule `gorm:"constraint:OnDelete:SET NULL" json:"-"`
+}
+
+// Account é uma conta corrente, poupança, cartão de crédito ou carteira de dinheiro e segue a mesma regra
+// de posse de Category. O saldo não é armazenado: parte de OpeningBalance e soma receitas, despesas e
+// transferências da conta. Contas desativadas mantêm o histórico, mas não recebem novos lançamentos.
+type Account struct {
+	UUIDModel
+	UserID         uuid.UUID   `gorm:"type:uuid;index" json:"userId"`
+	HouseholdID    *uuid.UUID  `gorm:"type:uuid;index" json:"householdId,omitempty"`
+	Name           string      `gorm:"size:80" json:"name"`
+	Type           AccountType `gorm:"type:varchar(16)" json:"type"`
+	Currency       string      `gorm:"size:3;default:'BRL'" json:"currency"`
+	OpeningBalance float64     `gorm:"type:numeric(12,2);default:0" json:"openingBalance"`
+	ClosingDay     int         `gorm:"default:0" json:"closingDay,omitempty"`
+	DueDay         int         `gorm:"default:0" json:"dueDay,omitempty"`
+	Active         bool        `gorm:"default:true" json:"active"`
+	User           *User       `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+// StatementPeriod devolve o intervalo [start, end) de datas da fatura do cartão que fecha no mês informado
+// e o vencimento dela. A fatura vai do dia seguinte ao fechamento anterior até o dia de fechamento, que é
+// limitado ao último dia do mês; o vencimento cai no mesmo mês quando DueDay é posterior ao fechamento,
+// senão no mês seguinte.
+func (a *Account) StatementPeriod(year int, month time.Month) (time.Time, time.Time, time.Time) {
+	closing := clampedDate(year, month, a.ClosingDay)
+	previous := clampedDate(year, month-1, a.ClosingDay)
+	dueMonth := month
+	if a.DueDay <= a.ClosingDay {
+		dueMonth++
+	}
+	return previous.AddDate(0, 0, 1), closing.AddDate(0, 0, 1), clampedDate(year, dueMonth, a.DueDay)
+}
+
+// StatementMonth devolve o mês de fechamento da fatura em que cai uma compra feita na data informada.
+func (a *Account) StatementMonth(date time.Time) (int, time.Month) {
+	closing := clampedDate(date.Year(), date.Month(), a.ClosingDay)
+	if date.Before(closing.AddDate(0, 0, 1)) {
+		return date.Year(), date.Month()
+	}
+	next := clampedDate(date.Year(), date.Month()+1, 1)
+	return next.Year(), next.Month()
+}
+
+// clampedDate monta a data do dia informado no mês, limitando-o ao último dia do mês (o mês pode transbordar o ano).
+func clampedDate(year int, month time.Month, day int) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	if day < 1 {
+		day = 1
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// InstallmentPurchase é uma compra parcelada no cartão de crédito e segue a mesma regra de posse de
+// Category. Cada parcela é uma Expense na conta do cartão, datada um mês após a anterior para cair na
+// fatura seguinte; a primeira parcela absorve a diferença de centavos da divisão.
+type InstallmentPurchase struct {
+	UUIDModel
+	UserID       uuid.UUID  `gorm:"type:uuid;index" json:"userId"`
+	HouseholdID  *uuid.UUID `gorm:"type:uuid;index" json:"householdId,omitempty"`
+	AccountID    uuid.UUID  `gorm:"type:uuid;index" json:"accountId"`
+	CategoryID   uuid.UUID  `gorm:"type:uuid" json:"categoryId"`
+	Description  string     `gorm:"size:200" json:"description"`
+	TotalAmount  float64    `gorm:"type:numeric(12,2)" json:"totalAmount"`
+	Installments int        `json:"installments"`
+	PurchaseDate time.Time  `json:"purchaseDate"`
+	User         *User      `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	Account      *Account   `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+type BudgetRollover string
+
+const (
+	BudgetRolloverNone    BudgetRollover = "none"
+	BudgetRolloverSurplus BudgetRollover = "surplus"
+	BudgetRolloverFull    BudgetRollover = "full"
+)
+
+// Budget é o limite mensal de gastos de uma categoria, válido a partir de StartMonth (primeiro dia do mês), e
+// segue a mesma regra de posse de Category. Com Rollover surplus, a sobra do mês anterior aumenta o limite;
+// com full, o estouro também passa adiante e reduz o limite seguinte.
+type Budget struct {
+	UUIDModel
+	UserID      uuid.UUID      `gorm:"type:uuid;index" json:"userId"`
+	HouseholdID *uuid.UUID     `gorm:"type:uuid;index" json:"householdId,omitempty"`
+	CategoryID  uuid.UUID      `gorm:"type:uuid;index" json:"categoryId"`
+	Amount      float64        `gorm:"type:numeric(12,2)" json:"amount"`
+	Rollover    BudgetRollover `gorm:"type:varchar(10);default:'none'" json:"rollover"`
+	StartMonth  time.Time      `json:"startMonth"`
+	User        *User          `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	Category    *Category      `gorm:"constraint:OnDelete:CASCADE;" json:"category,omitempty"`
+}
+
+// BudgetAlert registra que os gastos de um orçamento atingiram um limiar (50, 80 ou 100%) do limite do mês.
+// O índice único garante um único alerta por limiar em cada mês, mesmo que os gastos oscilem.
+type BudgetAlert struct {
+	UUIDModel
+	UserID      uuid.UUID  `gorm:"type:uuid;index" json:"userId"`
+	HouseholdID *uuid.UUID `gorm:"type:uuid;index" json:"householdId,omitempty"`
+	BudgetID    uuid.UUID  `gorm:"type:uuid;uniqueIndex:idx_budget_alert" json:"budgetId"`
+	Year        int        `gorm:"uniqueIndex:idx_budget_alert" json:"year"`
+	Month       int        `gorm:"uniqueIndex:idx_budget_alert" json:"month"`
+	Threshold   int        `gorm:"uniqueIndex:idx_budget_alert" json:"threshold"`
+	Spent       float64    `gorm:"type:numeric(12,2)" json:"spent"`
+	Limit       float64    `gorm:"type:numeric(12,2)" json:"limit"`
+	Budget      *Budget    `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+// Goal é uma meta de economia (viagem, reserva de emergência...) e segue a mesma regra de posse de Category.
+// O valor guardado é a soma das contribuições; AccountID indica a conta onde o dinheiro fica reservado.
+type Goal struct {
+	UUIDModel
+	UserID        uuid.UUID          `gorm:"type:uuid;index" json:"userId"`
+	HouseholdID   *uuid.UUID         `gorm:"type:uuid;index" json:"householdId,omitempty"`
+	Name          string             `gorm:"size:120" json:"name"`
+	TargetAmount  float64            `gorm:"type:numeric(12,2)" json:"targetAmount"`
+	Deadline      time.Time          `json:"deadline"`
+	AccountID     *uuid.UUID         `gorm:"type:uuid;index" json:"accountId,omitempty"`
+	Archived      bool               `gorm:"default:false" json:"archived"`
+	User          *User              `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	Account       *Account           `gorm:"constraint:OnDelete:SET NULL" json:"-"`
+	Contributions []GoalContribution `gorm:"constraint:OnDelete:CASCADE;" json:"contributions,omitempty"`
+}
+
+// GoalContribution é um aporte em uma Goal ou, com valor negativo, um resgate. Aportes feitos a partir de
+// uma conta geram uma Transfer para a conta da meta, guardada em TransferID.
+type GoalContribution struct {
+	UUIDModel
+	GoalID     uuid.UUID  `gorm:"type:uuid;index" json:"goalId"`
+	Amount     float64    `gorm:"type:numeric(12,2)" json:"amount"`
+	Date       time.Time  `gorm:"index" json:"date"`
+	Note       string     `gorm:"size:200" json:"note"`
+	TransferID *uuid.UUID `gorm:"type:uuid" json:"transferId,omitempty"`
+	Goal       *Goal      `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	Transfer   *Transfer  `gorm:"constraint:OnDelete:SET NULL" json:"-"`
+}
+
+// Transfer move dinheiro entre duas contas do mesmo escopo e não conta como despesa nem receita.
+// ToAmount é o valor creditado no destino e só difere de Amount quando as moedas das contas diferem.
+type Transfer struct {
+	UUIDModel
+	UserID        uuid.UUID  `gorm:"type:uuid;index" json:"userId"`
+	HouseholdID   *uuid.UUID `gorm:"type:uuid;index" json:"householdId,omitempty"`
+	FromAccountID uuid.UUID  `gorm:"type:uuid;index" json:"fromAccountId"`
+	ToAccountID   uuid.UUID  `gorm:"type:uuid;index" json:"toAccountId"`
+	Amount        float64    `gorm:"type:numeric(12,2)" json:"amount"`
+	ToAmount      float64    `gorm:"type:numeric(12,2)" json:"toAmount"`
+	Date          time.Time  `gorm:"index" json:"date"`
+	Description   string     `gorm:"size:200" json:"description"`
+	User          *User      `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	FromAccount   *Account   `gorm:"foreignKey:FromAccountID;constraint:OnDelete:CASCADE;" json:"-"`
+	ToAccount     *Account   `gorm:"foreignKey:ToAccountID;constraint:OnDelete:CASCADE;" json:"-"`
 }
 
 type Receipt struct {
 	UUIDModel
 	ExpenseID     uuid.UUID `gorm:"type:uuid;uniqueIndex" json:"expenseId"`
 	FilePath      string    `gorm:"size:255" json:"filePath"`
+	ContentType   string    `gorm:"size:100" json:"contentType"`
 	ExtractedText string    `gorm:"type:text" json:"extractedText"`
 	OcrConfidence float64   `gorm:"type:numeric(5,2)" json:"ocrConfidence"`
 	Expense       *Expense  `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
 }
 
+// ReceiptDraft guarda o resultado de um scan de recibo aguardando revisão. Ao ser confirmado vira uma
+// despesa com itens e recibo; ao ser descartado é removido junto com a imagem.
+type ReceiptDraft struct {
+	UUIDModel
+	UserID          uuid.UUID      `gorm:"type:uuid;index" json:"userId"`
+	HouseholdID     *uuid.UUID     `gorm:"type:uuid;index" json:"householdId,omitempty"`
+	Merchant        string         `gorm:"size:180" json:"merchant"`
+	MerchantCNPJ    string         `gorm:"size:14" json:"merchantCnpj"`
+	MerchantAddress string         `gorm:"size:255" json:"merchantAddress"`
+	Date            time.Time      `json:"date"`
+	Amount          float64        `gorm:"type:numeric(12,2)" json:"amount"`
+	Currency        string         `gorm:"size:3" json:"currency"`
+	CategoryID      *uuid.UUID     `gorm:"type:uuid" json:"categoryId,omitempty"`
+	Items           datatypes.JSON `gorm:"type:jsonb" json:"items"`
+	ExtractedText   string         `gorm:"type:text" json:"extractedText"`
+	OcrConfidence   float64        `gorm:"type:numeric(5,2)" json:"ocrConfidence"`
+	ImageKey        string         `gorm:"size:255" json:"imageKey"`
+	ContentType     string         `gorm:"size:100" json:"contentType"`
+	User            *User          `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	Category        *Category      `gorm:"constraint:OnDelete:SET NULL;" json:"category,omitempty"`
+}
+
+type ReceiptJobStatus string
+
+const (
+	ReceiptJobStatusQueued     ReceiptJobStatus = "queued"
+	ReceiptJobStatusProcessing ReceiptJobStatus = "processing"
+	ReceiptJobStatusDone       ReceiptJobStatus = "done"
+	ReceiptJobStatusFailed     ReceiptJobStatus = "failed"
+)
+
+// ReceiptJob é um processamento de recibo enfileirado. A imagem fica no armazenamento de arquivos
+// desde o envio, para que o job possa ser retomado depois de um reinício. ExpenseID ou DraftID é gravado na
+// mesma transação que cria a despesa ou o rascunho, o que impede que uma nova tentativa registre o recibo de novo.
+type ReceiptJob struct {
+	UUIDModel
+	UserID      uuid.UUID        `gorm:"type:uuid;index" json:"userId"`
+	HouseholdID *uuid.UUID       `gorm:"type:uuid;index" json:"householdId,omitempty"`
+	Status      ReceiptJobStatus `gorm:"type:varchar(12);index" json:"status"`
+	ImageKey    string           `gorm:"size:255" json:"imageKey"`
+	ContentType string           `gorm:"size:100" json:"contentType"`
+	Currency    string           `gorm:"size:3" json:"currency"`
+	Locale      string           `gorm:"size:20" json:"locale"`
+	AmountHint  *float64         `gorm:"type:numeric(12,2)" json:"amountHint,omitempty"`
+	ReturnRaw   bool             `json:"returnRaw"`
+	Draft       bool             `json:"draft"`
+	Attempts    int              `json:"attempts"`
+	Result      datatypes.JSON   `gorm:"type:jsonb" json:"result,omitempty"`
+	Error       string           `gorm:"type:text" json:"error,omitempty"`
+	ExpenseID   *uuid.UUID       `gorm:"type:uuid" json:"expenseId,omitempty"`
+	DraftID     *uuid.UUID       `gorm:"type:uuid" json:"draftId,omitempty"`
+	StartedAt   *time.Time       `json:"startedAt,omitempty"`
+	FinishedAt  *time.Time       `json:"finishedAt,omitempty"`
+	User        *User            `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+}
+
 type GeneratedTip struct {
 	UUIDModel
 	UserID      uuid.UUID `gorm:"type:uuid;index" json:"userId"`
@@ -188,13 +504,220 @@ type MealItem struct {
 	MealPlan      *MealPlan      `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
 }
 
+// Session guarda apenas o digest SHA-256 do token de acesso (ver HashToken);
+// o token em texto puro é entregue ao cliente uma única vez na emissão.
 type Session struct {
-	Token     string    `gorm:"size:64;primaryKey" json:"token"`
-	UserID    uuid.UUID `gorm:"type:uuid;index" json:"userId"`
-	CreatedAt time.Time `json:"createdAt"`
-	ExpiresAt time.Time `gorm:"index" json:"expiresAt"`
-	Valid     bool      `gorm:"default:true" json:"valid"`
-	User      *User     `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	Token       string     `gorm:"size:64;primaryKey" json:"-"`
+	TokenHashed bool       `gorm:"default:false" json:"-"`
+	ID          uuid.UUID  `gorm:"type:uuid;uniqueIndex" json:"id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;index" json:"userId"`
+	DeviceName  string     `gorm:"size:120" json:"deviceName"`
+	UserAgent   string     `gorm:"size:255" json:"userAgent"`
+	IPAddress   string     `gorm:"size:45" json:"ipAddress"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	LastSeenAt  *time.Time `json:"lastSeenAt,omitempty"`
+	ExpiresAt   time.Time  `gorm:"index" json:"expiresAt"`
+	Valid       bool       `gorm:"default:true" json:"valid"`
+	User        *User      `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (s *Session) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// HashToken calcula o digest hexadecimal usado para persistir tokens opacos.
+func HashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+type RefreshToken struct {
+	UUIDModel
+	UserID       uuid.UUID  `gorm:"type:uuid;index" json:"userId"`
+	FamilyID     uuid.UUID  `gorm:"type:uuid;index" json:"familyId"`
+	ParentID     *uuid.UUID `gorm:"type:uuid" json:"parentId,omitempty"`
+	TokenHash    string     `gorm:"size:64;uniqueIndex" json:"-"`
+	SessionToken string     `gorm:"size:64;index" json:"-"`
+	ExpiresAt    time.Time  `gorm:"index" json:"expiresAt"`
+	UsedAt       *time.Time `json:"usedAt,omitempty"`
+	RevokedAt    *time.Time `json:"revokedAt,omitempty"`
+	User         *User      `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+type UserTokenPurpose string
+
+const (
+	UserTokenPasswordReset     UserTokenPurpose = "password_reset"
+	UserTokenEmailVerification UserTokenPurpose = "email_verification"
+	UserTokenMFAChallenge      UserTokenPurpose = "mfa_challenge"
+	UserTokenEmailChange       UserTokenPurpose = "email_change"
+)
+
+// UserToken representa um token de uso único (links enviados por email ou desafio de login); apenas o digest é persistido.
+type UserToken struct {
+	UUIDModel
+	UserID    uuid.UUID        `gorm:"type:uuid;index" json:"userId"`
+	Purpose   UserTokenPurpose `gorm:"type:varchar(30);index" json:"purpose"`
+	TokenHash string           `gorm:"size:64;uniqueIndex" json:"-"`
+	ExpiresAt time.Time        `json:"expiresAt"`
+	UsedAt    *time.Time       `json:"usedAt,omitempty"`
+	Attempts  int              `gorm:"default:0" json:"attempts"`
+	User      *User            `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+// MFARecoveryCode é um código de recuperação de uso único do segundo fator; apenas o digest é persistido.
+type MFARecoveryCode struct {
+	UUIDModel
+	UserID   uuid.UUID  `gorm:"type:uuid;index" json:"userId"`
+	CodeHash string     `gorm:"size:64;index" json:"-"`
+	UsedAt   *time.Time `json:"usedAt,omitempty"`
+	User     *User      `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+// UserIdentity vincula o usuário a uma conta de um provedor OpenID Connect (ex.: Google).
+type UserIdentity struct {
+	UUIDModel
+	UserID      uuid.UUID  `gorm:"type:uuid;index" json:"userId"`
+	Provider    string     `gorm:"size:30;uniqueIndex:idx_identity_provider_subject" json:"provider"`
+	Subject     string     `gorm:"size:255;uniqueIndex:idx_identity_provider_subject" json:"subject"`
+	Email       string     `gorm:"size:180" json:"email"`
+	LastLoginAt *time.Time `json:"lastLoginAt,omitempty"`
+	User        *User      `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+type HouseholdRole string
+
+const (
+	HouseholdRoleOwner  HouseholdRole = "owner"
+	HouseholdRoleEditor HouseholdRole = "editor"
+	HouseholdRoleViewer HouseholdRole = "viewer"
+)
+
+// Household é um grupo familiar que compartilha categorias e despesas entre seus membros.
+type Household struct {
+	UUIDModel
+	Name        string            `gorm:"size:80" json:"name"`
+	CreatedByID uuid.UUID         `gorm:"type:uuid" json:"createdById"`
+	Members     []HouseholdMember `gorm:"constraint:OnDelete:CASCADE;" json:"members,omitempty"`
+}
+
+type HouseholdMember struct {
+	UUIDModel
+	HouseholdID uuid.UUID     `gorm:"type:uuid;uniqueIndex:idx_household_member" json:"householdId"`
+	UserID      uuid.UUID     `gorm:"type:uuid;uniqueIndex:idx_household_member;index" json:"userId"`
+	Role        HouseholdRole `gorm:"type:varchar(10)" json:"role"`
+	Household   *Household    `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	User        *User         `gorm:"constraint:OnDelete:CASCADE;" json:"user,omitempty"`
+}
+
+// HouseholdInvitation é um convite enviado por email para entrar no grupo familiar; apenas o digest do token é persistido.
+type HouseholdInvitation struct {
+	UUIDModel
+	HouseholdID uuid.UUID     `gorm:"type:uuid;index" json:"householdId"`
+	Email       string        `gorm:"size:180;index" json:"email"`
+	Role        HouseholdRole `gorm:"type:varchar(10)" json:"role"`
+	TokenHash   string        `gorm:"size:64;uniqueIndex" json:"-"`
+	InvitedByID uuid.UUID     `gorm:"type:uuid" json:"invitedById"`
+	ExpiresAt   time.Time     `json:"expiresAt"`
+	AcceptedAt  *time.Time    `json:"acceptedAt,omitempty"`
+	RevokedAt   *time.Time    `json:"revokedAt,omitempty"`
+	Household   *Household    `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+type APIKeyScope string
+
+const (
+	APIKeyScopeExpensesRead    APIKeyScope = "expenses:read"
+	APIKeyScopeExpensesWrite   APIKeyScope = "expenses:write"
+	APIKeyScopeIncomesRead     APIKeyScope = "incomes:read"
+	APIKeyScopeIncomesWrite    APIKeyScope = "incomes:write"
+	APIKeyScopeAccountsRead    APIKeyScope = "accounts:read"
+	APIKeyScopeAccountsWrite   APIKeyScope = "accounts:write"
+	APIKeyScopeBudgetsRead     APIKeyScope = "budgets:read"
+	APIKeyScopeBudgetsWrite    APIKeyScope = "budgets:write"
+	APIKeyScopeGoalsRead       APIKeyScope = "goals:read"
+	APIKeyScopeGoalsWrite      APIKeyScope = "goals:write"
+	APIKeyScopeCategoriesRead  APIKeyScope = "categories:read"
+	APIKeyScopeCategoriesWrite APIKeyScope = "categories:write"
+	APIKeyScopeReceiptsScan    APIKeyScope = "receipts:scan"
+	APIKeyScopeDashboardRead   APIKeyScope = "dashboard:read"
+	APIKeyScopeTipsRead        APIKeyScope = "tips:read"
+	APIKeyScopeTipsWrite       APIKeyScope = "tips:write"
+	APIKeyScopeMealPlansRead   APIKeyScope = "meal-plans:read"
+	APIKeyScopeMealPlansWrite  APIKeyScope = "meal-plans:write"
+	APIKeyScopeSyncWrite       APIKeyScope = "sync:write"
+	APIKeyScopeTokenUsageRead  APIKeyScope = "token-usage:read"
+)
+
+// APIKeyScopes lista todos os escopos que podem ser concedidos a uma chave de API.
+var APIKeyScopes = []APIKeyScope{
+	APIKeyScopeExpensesRead,
+	APIKeyScopeExpensesWrite,
+	APIKeyScopeIncomesRead,
+	APIKeyScopeIncomesWrite,
+	APIKeyScopeAccountsRead,
+	APIKeyScopeAccountsWrite,
+	APIKeyScopeBudgetsRead,
+	APIKeyScopeBudgetsWrite,
+	APIKeyScopeGoalsRead,
+	APIKeyScopeGoalsWrite,
+	APIKeyScopeCategoriesRead,
+	APIKeyScopeCategoriesWrite,
+	APIKeyScopeReceiptsScan,
+	APIKeyScopeDashboardRead,
+	APIKeyScopeTipsRead,
+	APIKeyScopeTipsWrite,
+	APIKeyScopeMealPlansRead,
+	APIKeyScopeMealPlansWrite,
+	APIKeyScopeSyncWrite,
+	APIKeyScopeTokenUsageRead,
+}
+
+// APIKey é uma chave pessoal de acesso para scripts e integrações, limitada aos escopos concedidos;
+// apenas o digest é persistido (ver HashToken).
+type APIKey struct {
+	UUIDModel
+	UserID     uuid.UUID     `gorm:"type:uuid;index" json:"userId"`
+	Name       string        `gorm:"size:80" json:"name"`
+	Prefix     string        `gorm:"size:16" json:"prefix"`
+	KeyHash    string        `gorm:"size:64;uniqueIndex" json:"-"`
+	Scopes     []APIKeyScope `gorm:"type:text;serializer:json" json:"scopes"`
+	ExpiresAt  *time.Time    `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time    `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time    `json:"revokedAt,omitempty"`
+	User       *User         `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+// HasScope informa se a chave concede o escopo pedido.
+func (k *APIKey) HasScope(scope APIKeyScope) bool {
+	for _, granted := range k.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// LoginThrottle guarda o contador de falhas de login por chave (email ou IP).
+type LoginThrottle struct {
+	Key           string     `gorm:"size:200;primaryKey" json:"key"`
+	Failures      int        `gorm:"default:0" json:"failures"`
+	LastFailureAt time.Time  `json:"lastFailureAt"`
+	LockedUntil   *time.Time `json:"lockedUntil,omitempty"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+// LoginAttempt é o registro de auditoria de cada tentativa de login malsucedida.
+type LoginAttempt struct {
+	UUIDModel
+	UserID    *uuid.UUID `gorm:"type:uuid;index" json:"userId,omitempty"`
+	Email     string     `gorm:"size:180;index" json:"email"`
+	IPAddress string     `gorm:"size:45;index" json:"ipAddress"`
+	UserAgent string     `gorm:"size:255" json:"userAgent"`
+	Reason    string     `gorm:"size:40" json:"reason"`
 }
 
 type SyncJob struct {
@@ -213,6 +736,8 @@ const (
 	RequestTypeReceipt  RequestType = "receipt"
 	RequestTypeInsight  RequestType = "insight"
 	RequestTypeMealPlan RequestType = "meal_plan"
+	// RequestTypeCategorize registra as sugestões de categoria feitas pelo modelo para lançamentos manuais.
+	RequestTypeCategorize RequestType = "categorize"
 )
 
 type TokenUsage struct {
@@ -230,11 +755,107 @@ type TokenUsage struct {
 
 type ExpenseItem struct {
 	UUIDModel
-	ExpenseID   uuid.UUID `gorm:"type:uuid;index" json:"expenseId"`
-	Name        string    `gorm:"size:180" json:"name"`
-	Quantity    float64   `gorm:"type:numeric(12,3)" json:"quantity"`
-	UnitPrice   float64   `gorm:"type:numeric(12,2)" json:"unitPrice"`
-	TotalPrice  float64   `gorm:"type:numeric(12,2)" json:"totalPrice"`
-	CategoryTag string    `gorm:"size:80" json:"category"`
-	Expense     *Expense  `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	ExpenseID   uuid.UUID  `gorm:"type:uuid;index" json:"expenseId"`
+	Name        string     `gorm:"size:180" json:"name"`
+	Quantity    float64    `gorm:"type:numeric(12,3)" json:"quantity"`
+	UnitPrice   float64    `gorm:"type:numeric(12,2)" json:"unitPrice"`
+	TotalPrice  float64    `gorm:"type:numeric(12,2)" json:"totalPrice"`
+	CategoryTag string     `gorm:"size:80" json:"category"`
+	CategoryID  *uuid.UUID `gorm:"type:uuid;index" json:"categoryId,omitempty"`
+	Expense     *Expense   `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	Category    *Category  `gorm:"constraint:OnDelete:SET NULL;" json:"-"`
+}
+
+// ExpenseAllocation destina parte do valor de uma despesa a outra categoria. A soma das alocações não passa
+// do valor da despesa e o restante permanece na categoria principal (Expense.CategoryID). Alocações feitas
+// por item do cupom guardam ExpenseItemID e usam o total do item como Amount.
+type ExpenseAllocation struct {
+	UUIDModel
+	ExpenseID     uuid.UUID    `gorm:"type:uuid;index" json:"expenseId"`
+	CategoryID    uuid.UUID    `gorm:"type:uuid;index" json:"categoryId"`
+	ExpenseItemID *uuid.UUID   `gorm:"type:uuid;index" json:"expenseItemId,omitempty"`
+	Amount        float64      `gorm:"type:numeric(12,2)" json:"amount"`
+	Expense       *Expense     `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+	Category      *Category    `gorm:"constraint:OnDelete:SET NULL" json:"category,omitempty"`
+	ExpenseItem   *ExpenseItem `gorm:"constraint:OnDelete:SET NULL" json:"-"`
+}
+
+type RecurrenceFrequency string
+
+const (
+	RecurrenceFrequencyWeekly  RecurrenceFrequency = "weekly"
+	RecurrenceFrequencyMonthly RecurrenceFrequency = "monthly"
+	RecurrenceFrequencyYearly  RecurrenceFrequency = "yearly"
+)
+
+// RecurrenceRule gera despesas ou receitas periódicas, conforme Kind, a partir de um lançamento modelo.
+// A ocorrência de índice 0 cai em StartDate; as seguintes avançam Interval semanas, meses ou anos.
+// NextIndex e NextOccurrenceAt apontam a próxima ocorrência ainda não materializada (nil quando a regra terminou).
+type RecurrenceRule struct {
+	UUIDModel
+	UserID           uuid.UUID           `gorm:"type:uuid;index" json:"userId"`
+	HouseholdID      *uuid.UUID          `gorm:"type:uuid;index" json:"householdId,omitempty"`
+	Kind             CategoryKind        `gorm:"type:varchar(10);default:'despesa'" json:"kind"`
+	TemplateID       uuid.UUID           `gorm:"type:uuid;index" json:"templateId"`
+	CategoryID       uuid.UUID           `gorm:"type:uuid" json:"categoryId"`
+	Description      string              `gorm:"size:200" json:"description"`
+	Amount           float64             `gorm:"type:numeric(12,2)" json:"amount"`
+	Origin           ExpenseOrigin       `gorm:"type:varchar(10);default:'manual'" json:"origin"`
+	AccountID        *uuid.UUID          `gorm:"type:uuid" json:"accountId,omitempty"`
+	Frequency        RecurrenceFrequency `gorm:"type:varchar(10)" json:"frequency"`
+	Interval         int                 `gorm:"default:1" json:"interval"`
+	DayOfMonth       int                 `json:"dayOfMonth"`
+	StartDate        time.Time           `json:"startDate"`
+	EndDate          *time.Time          `json:"endDate,omitempty"`
+	Count            *int                `json:"count,omitempty"`
+	NextIndex        int                 `gorm:"default:0" json:"nextIndex"`
+	NextOccurrenceAt *time.Time          `gorm:"index" json:"nextOccurrenceAt,omitempty"`
+	User             *User               `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+// OccurrenceDate calcula a data da ocorrência de índice informado. Em regras mensais e anuais o dia
+// é limitado ao último dia do mês (dia 31 cai em 30 ou 28/29). Retorna false depois do fim da regra.
+func (r *RecurrenceRule) OccurrenceDate(index int) (time.Time, bool) {
+	if index < 0 || (r.Count != nil && index >= *r.Count) {
+		return time.Time{}, false
+	}
+
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	start := r.StartDate
+
+	var date time.Time
+	switch r.Frequency {
+	case RecurrenceFrequencyWeekly:
+		date = start.AddDate(0, 0, 7*interval*index)
+	default:
+		months := interval * index
+		if r.Frequency == RecurrenceFrequencyYearly {
+			months *= 12
+		}
+		firstOfMonth := time.Date(start.Year(), start.Month(), 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location()).AddDate(0, months, 0)
+		day := r.DayOfMonth
+		if day < 1 {
+			day = start.Day()
+		}
+		if last := firstOfMonth.AddDate(0, 1, -1).Day(); day > last {
+			day = last
+		}
+		date = firstOfMonth.AddDate(0, 0, day-1)
+	}
+
+	if r.EndDate != nil && date.After(*r.EndDate) {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// RecurrenceSkip marca uma ocorrência que não deve ser materializada.
+type RecurrenceSkip struct {
+	UUIDModel
+	RecurrenceRuleID uuid.UUID       `gorm:"type:uuid;uniqueIndex:idx_recurrence_skip" json:"recurrenceRuleId"`
+	OccurrenceIndex  int             `gorm:"uniqueIndex:idx_recurrence_skip" json:"occurrenceIndex"`
+	RecurrenceRule   *RecurrenceRule `gorm:"constraint:OnDelete:CASCADE;" json:"-"`
 }
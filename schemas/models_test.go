@@ -0,0 +1,137 @@
+package schemas
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestRecurrenceRuleOccurrenceDate(t *testing.T) {
+	count := 3
+	end := date(2024, time.April, 30)
+
+	tests := []struct {
+		name  string
+		rule  RecurrenceRule
+		index int
+		want  time.Time
+		ok    bool
+	}{
+		{"mensal dia 31 em janeiro", RecurrenceRule{Frequency: RecurrenceFrequencyMonthly, DayOfMonth: 31, StartDate: date(2024, time.January, 31)}, 0, date(2024, time.January, 31), true},
+		{"mensal dia 31 em fevereiro bissexto", RecurrenceRule{Frequency: RecurrenceFrequencyMonthly, DayOfMonth: 31, StartDate: date(2024, time.January, 31)}, 1, date(2024, time.February, 29), true},
+		{"mensal dia 31 volta a 31 em março", RecurrenceRule{Frequency: RecurrenceFrequencyMonthly, DayOfMonth: 31, StartDate: date(2024, time.January, 31)}, 2, date(2024, time.March, 31), true},
+		{"mensal dia 31 em abril", RecurrenceRule{Frequency: RecurrenceFrequencyMonthly, DayOfMonth: 31, StartDate: date(2024, time.January, 31)}, 3, date(2024, time.April, 30), true},
+		{"mensal dia 31 em fevereiro comum", RecurrenceRule{Frequency: RecurrenceFrequencyMonthly, DayOfMonth: 31, StartDate: date(2023, time.January, 31)}, 1, date(2023, time.February, 28), true},
+		{"mensal sem dia usa o dia inicial", RecurrenceRule{Frequency: RecurrenceFrequencyMonthly, StartDate: date(2023, time.December, 30)}, 2, date(2024, time.February, 29), true},
+		{"bimestral atravessa o ano", RecurrenceRule{Frequency: RecurrenceFrequencyMonthly, Interval: 2, DayOfMonth: 31, StartDate: date(2023, time.October, 31)}, 2, date(2024, time.February, 29), true},
+		{"anual em 29 de fevereiro", RecurrenceRule{Frequency: RecurrenceFrequencyYearly, DayOfMonth: 29, StartDate: date(2024, time.February, 29)}, 1, date(2025, time.February, 28), true},
+		{"anual volta a 29 no bissexto", RecurrenceRule{Frequency: RecurrenceFrequencyYearly, DayOfMonth: 29, StartDate: date(2024, time.February, 29)}, 4, date(2028, time.February, 29), true},
+		{"semanal", RecurrenceRule{Frequency: RecurrenceFrequencyWeekly, StartDate: date(2024, time.February, 26)}, 1, date(2024, time.March, 4), true},
+		{"semanal atravessa o mês", RecurrenceRule{Frequency: RecurrenceFrequencyWeekly, StartDate: date(2024, time.January, 31)}, 4, date(2024, time.February, 28), true},
+		{"quinzenal", RecurrenceRule{Frequency: RecurrenceFrequencyWeekly, Interval: 2, StartDate: date(2024, time.December, 23)}, 1, date(2025, time.January, 6), true},
+		{"semanal ignora o dia do mês", RecurrenceRule{Frequency: RecurrenceFrequencyWeekly, DayOfMonth: 31, StartDate: date(2024, time.March, 1)}, 3, date(2024, time.March, 22), true},
+		{"intervalo zero vale um", RecurrenceRule{Frequency: RecurrenceFrequencyWeekly, Interval: 0, StartDate: date(2024, time.March, 1)}, 1, date(2024, time.March, 8), true},
+		{"índice negativo", RecurrenceRule{Frequency: RecurrenceFrequencyWeekly, StartDate: date(2024, time.March, 1)}, -1, time.Time{}, false},
+		{"última ocorrência pelo count", RecurrenceRule{Frequency: RecurrenceFrequencyMonthly, StartDate: date(2024, time.January, 15), Count: &count}, 2, date(2024, time.March, 15), true},
+		{"além do count", RecurrenceRule{Frequency: RecurrenceFrequencyMonthly, StartDate: date(2024, time.January, 15), Count: &count}, 3, time.Time{}, false},
+		{"no limite da data final", RecurrenceRule{Frequency: RecurrenceFrequencyMonthly, DayOfMonth: 31, StartDate: date(2024, time.January, 31), EndDate: &end}, 3, date(2024, time.April, 30), true},
+		{"depois da data final", RecurrenceRule{Frequency: RecurrenceFrequencyMonthly, DayOfMonth: 31, StartDate: date(2024, time.January, 31), EndDate: &end}, 4, time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.rule.OccurrenceDate(tt.index)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, esperado %v", ok, tt.ok)
+			}
+			if ok && !got.Equal(tt.want) {
+				t.Fatalf("OccurrenceDate(%d) = %s, esperado %s", tt.index, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestRecurrenceRuleKeepsTimeOfDay(t *testing.T) {
+	location := time.FixedZone("BRT", -3*60*60)
+	rule := RecurrenceRule{Frequency: RecurrenceFrequencyMonthly, DayOfMonth: 31, StartDate: time.Date(2024, time.January, 31, 9, 30, 0, 0, location)}
+
+	got, ok := rule.OccurrenceDate(1)
+	if !ok {
+		t.Fatal("ocorrência deveria existir")
+	}
+	want := time.Date(2024, time.February, 29, 9, 30, 0, 0, location)
+	if !got.Equal(want) || got.Location() != location {
+		t.Fatalf("OccurrenceDate(1) = %v, esperado %v", got, want)
+	}
+}
+
+func TestAccountStatementPeriod(t *testing.T) {
+	tests := []struct {
+		name      string
+		closing   int
+		due       int
+		year      int
+		month     time.Month
+		wantStart time.Time
+		wantEnd   time.Time
+		wantDue   time.Time
+	}{
+		{"vencimento no mesmo mês", 5, 15, 2024, time.March, date(2024, time.February, 6), date(2024, time.March, 6), date(2024, time.March, 15)},
+		{"janeiro começa em dezembro", 5, 15, 2024, time.January, date(2023, time.December, 6), date(2024, time.January, 6), date(2024, time.January, 15)},
+		{"fechamento de dezembro vence em janeiro", 25, 5, 2024, time.December, date(2024, time.November, 26), date(2024, time.December, 26), date(2025, time.January, 5)},
+		{"vencimento antes do fechamento vai ao mês seguinte", 20, 10, 2024, time.June, date(2024, time.May, 21), date(2024, time.June, 21), date(2024, time.July, 10)},
+		{"vencimento igual ao fechamento vai ao mês seguinte", 10, 10, 2024, time.June, date(2024, time.May, 11), date(2024, time.June, 11), date(2024, time.July, 10)},
+		{"fechamento 31 em fevereiro bissexto", 31, 10, 2024, time.February, date(2024, time.February, 1), date(2024, time.March, 1), date(2024, time.March, 10)},
+		{"fechamento 31 depois de fevereiro", 31, 10, 2023, time.March, date(2023, time.March, 1), date(2023, time.April, 1), date(2023, time.April, 10)},
+		{"vencimento 31 limitado ao mês seguinte", 28, 31, 2024, time.March, date(2024, time.February, 29), date(2024, time.March, 29), date(2024, time.March, 31)},
+		{"vencimento 31 em fevereiro", 31, 31, 2024, time.January, date(2024, time.January, 1), date(2024, time.February, 1), date(2024, time.February, 29)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := Account{ClosingDay: tt.closing, DueDay: tt.due}
+			start, end, due := account.StatementPeriod(tt.year, tt.month)
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) || !due.Equal(tt.wantDue) {
+				t.Fatalf("StatementPeriod = [%s, %s) vence %s; esperado [%s, %s) vence %s",
+					start.Format("2006-01-02"), end.Format("2006-01-02"), due.Format("2006-01-02"),
+					tt.wantStart.Format("2006-01-02"), tt.wantEnd.Format("2006-01-02"), tt.wantDue.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestAccountStatementMonth(t *testing.T) {
+	tests := []struct {
+		name      string
+		closing   int
+		purchase  time.Time
+		wantYear  int
+		wantMonth time.Month
+	}{
+		{"antes do fechamento", 10, date(2024, time.May, 9), 2024, time.May},
+		{"no dia do fechamento", 10, time.Date(2024, time.May, 10, 23, 59, 0, 0, time.UTC), 2024, time.May},
+		{"depois do fechamento", 10, date(2024, time.May, 11), 2024, time.June},
+		{"depois do fechamento de dezembro", 25, date(2024, time.December, 26), 2025, time.January},
+		{"fechamento 31 em fevereiro", 31, date(2024, time.February, 29), 2024, time.February},
+		{"fechamento 30 no fim de janeiro", 30, date(2024, time.January, 31), 2024, time.February},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := Account{ClosingDay: tt.closing}
+			year, month := account.StatementMonth(tt.purchase)
+			if year != tt.wantYear || month != tt.wantMonth {
+				t.Fatalf("StatementMonth = %d-%02d, esperado %d-%02d", year, month, tt.wantYear, tt.wantMonth)
+			}
+
+			// A compra precisa cair dentro do período da fatura escolhida.
+			start, end, _ := account.StatementPeriod(year, month)
+			if tt.purchase.Before(start) || !tt.purchase.Before(end) {
+				t.Fatalf("%s fora da fatura [%s, %s)", tt.purchase, start, end)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultFileDir = "./tmp/mail"
+
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewFromEnv escolhe a implementação a partir de MAILER_DRIVER (smtp, file ou log). O driver é obrigatório: o log
+// escreve os tokens de verificação e redefinição de senha na saída padrão e só deve ser usado quando escolhido.
+func NewFromEnv() (Mailer, error) {
+	from := os.Getenv("MAILER_FROM")
+	if from == "" {
+		from = "no-reply@example.com"
+	}
+
+	switch strings.ToLower(os.Getenv("MAILER_DRIVER")) {
+	case "smtp":
+		host := os.Getenv("SMTP_HOST")
+		if host == "" {
+			return nil, fmt.Errorf("variável de ambiente SMTP_HOST não definida")
+		}
+		port := os.Getenv("SMTP_PORT")
+		if port == "" {
+			port = "587"
+		}
+		return NewSMTPMailer(SMTPConfig{
+			Host:     host,
+			Port:     port,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     from,
+		}), nil
+	case "file":
+		dir := os.Getenv("MAILER_FILE_DIR")
+		if dir == "" {
+			dir = defaultFileDir
+		}
+		return NewFileMailer(dir, from)
+	case "log":
+		return NewLogMailer(os.Stdout, from), nil
+	case "":
+		return nil, fmt.Errorf("variável de ambiente MAILER_DRIVER não definida")
+	default:
+		return nil, fmt.Errorf("driver de email desconhecido: %s", os.Getenv("MAILER_DRIVER"))
+	}
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type SMTPMailer struct {
+	config SMTPConfig
+}
+
+func NewSMTPMailer(config SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{config: config}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	addr := m.config.Host + ":" + m.config.Port
+	if err := smtp.SendMail(addr, auth, m.config.From, msg.To, render(m.config.From, msg)); err != nil {
+		return fmt.Errorf("erro enviando email via smtp: %w", err)
+	}
+	return nil
+}
+
+// FileMailer grava cada mensagem como um arquivo .eml, útil em desenvolvimento local.
+type FileMailer struct {
+	dir  string
+	from string
+	mu   sync.Mutex
+	seq  int
+}
+
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("erro criando diretório de emails: %w", err)
+	}
+	return &FileMailer{dir: dir, from: from}, nil
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.seq++
+	name := fmt.Sprintf("%s-%04d.eml", time.Now().Format("20060102-150405"), m.seq)
+	m.mu.Unlock()
+
+	if err := os.WriteFile(filepath.Join(m.dir, name), render(m.from, msg), 0o600); err != nil {
+		return fmt.Errorf("erro gravando email: %w", err)
+	}
+	return nil
+}
+
+// LogMailer escreve as mensagens no writer informado e as mantém em memória para inspeção em testes.
+type LogMailer struct {
+	writer io.Writer
+	from   string
+	mu     sync.Mutex
+	sent   []Message
+}
+
+func NewLogMailer(writer io.Writer, from string) *LogMailer {
+	if writer == nil {
+		writer = io.Discard
+	}
+	return &LogMailer{writer: writer, from: from}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, msg)
+	_, err := fmt.Fprintf(m.writer, "MAIL: %s\n", strings.ReplaceAll(string(render(m.from, msg)), "\r\n", "\n"))
+	return err
+}
+
+func (m *LogMailer) Sent() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sent := make([]Message, len(m.sent))
+	copy(sent, m.sent)
+	return sent
+}
+
+func validate(msg Message) error {
+	if len(msg.To) == 0 {
+		return fmt.Errorf("mensagem sem destinatário")
+	}
+	if strings.TrimSpace(msg.Subject) == "" {
+		return fmt.Errorf("mensagem sem assunto")
+	}
+	return nil
+}
+
+func render(from string, msg Message) []byte {
+	var builder strings.Builder
+	builder.WriteString("From: " + from + "\r\n")
+	builder.WriteString("To: " + strings.Join(msg.To, ", ") + "\r\n")
+	builder.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	builder.WriteString("MIME-Version: 1.0\r\n")
+	builder.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	builder.WriteString("\r\n")
+	builder.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(builder.String())
+}
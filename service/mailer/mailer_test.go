@@ -0,0 +1,149 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		driver  string
+		wantErr bool
+		want    string
+	}{
+		{name: "sem driver", driver: "", wantErr: true},
+		{name: "driver desconhecido", driver: "pombo", wantErr: true},
+		{name: "smtp sem host", driver: "smtp", wantErr: true},
+		{name: "log explícito", driver: "log", want: "*mailer.LogMailer"},
+		{name: "arquivo", driver: "FILE", want: "*mailer.FileMailer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MAILER_DRIVER", tt.driver)
+			t.Setenv("MAILER_FILE_DIR", t.TempDir())
+			t.Setenv("SMTP_HOST", "")
+
+			got, err := NewFromEnv()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("esperava erro, obteve %T", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if typeName := fmt.Sprintf("%T", got); typeName != tt.want {
+				t.Fatalf("tipo = %s, esperado %s", typeName, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileMailerSend(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	m, err := NewFileMailer(dir, "no-reply@example.com")
+	if err != nil {
+		t.Fatalf("NewFileMailer: %v", err)
+	}
+
+	msg := Message{To: []string{"ana@example.com", "bia@example.com"}, Subject: "Redefinição de senha", Body: "linha 1\nlinha 2"}
+	for i := 0; i < 2; i++ {
+		if err := m.Send(context.Background(), msg); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("arquivos = %d, esperado 2", len(entries))
+	}
+	if entries[0].Name() == entries[1].Name() || !strings.HasSuffix(entries[0].Name(), ".eml") {
+		t.Fatalf("nomes inesperados: %s, %s", entries[0].Name(), entries[1].Name())
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	content := string(raw)
+	for _, want := range []string{
+		"From: no-reply@example.com\r\n",
+		"To: ana@example.com, bia@example.com\r\n",
+		"Subject: =?utf-8?q?Redefini=C3=A7=C3=A3o_de_senha?=\r\n",
+		"\r\n\r\nlinha 1\r\nlinha 2",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("mensagem sem %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestFileMailerRejectsInvalidMessage(t *testing.T) {
+	dir := t.TempDir()
+	m, err := NewFileMailer(dir, "no-reply@example.com")
+	if err != nil {
+		t.Fatalf("NewFileMailer: %v", err)
+	}
+
+	invalid := []Message{
+		{Subject: "Sem destinatário"},
+		{To: []string{"ana@example.com"}, Subject: "  "},
+	}
+	for _, msg := range invalid {
+		if err := m.Send(context.Background(), msg); err == nil {
+			t.Errorf("Send(%+v) deveria falhar", msg)
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Fatalf("mensagens inválidas não deveriam ser gravadas, encontrados %d arquivos", len(entries))
+	}
+}
+
+func TestLogMailerSend(t *testing.T) {
+	var out bytes.Buffer
+	m := NewLogMailer(&out, "no-reply@example.com")
+
+	msg := Message{To: []string{"ana@example.com"}, Subject: "Verifique seu email", Body: "token: abc"}
+	if err := m.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if err := m.Send(context.Background(), Message{Subject: "sem destinatário"}); err == nil {
+		t.Fatal("mensagem sem destinatário deveria falhar")
+	}
+
+	if !strings.HasPrefix(out.String(), "MAIL: From: no-reply@example.com\n") || !strings.Contains(out.String(), "\ntoken: abc") {
+		t.Fatalf("saída inesperada: %q", out.String())
+	}
+	if strings.Contains(out.String(), "\r") {
+		t.Fatalf("saída deveria usar apenas \\n: %q", out.String())
+	}
+
+	sent := m.Sent()
+	if len(sent) != 1 || sent[0].Subject != msg.Subject {
+		t.Fatalf("Sent() = %+v", sent)
+	}
+	sent[0].Subject = "alterado"
+	if m.Sent()[0].Subject != msg.Subject {
+		t.Fatal("Sent() deveria devolver uma cópia")
+	}
+}
+
+func TestNewLogMailerNilWriter(t *testing.T) {
+	m := NewLogMailer(nil, "no-reply@example.com")
+	if err := m.Send(context.Background(), Message{To: []string{"ana@example.com"}, Subject: "Oi"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+}
@@ -0,0 +1,367 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTimeout     = 10 * time.Second
+	defaultCacheTTL    = time.Hour
+	defaultLeeway      = time.Minute
+	minRefreshInterval = 30 * time.Second
+)
+
+var (
+	ErrInvalidToken = errors.New("id token inválido")
+	ErrExpiredToken = errors.New("id token expirado")
+)
+
+// Claims reúne as informações do ID token usadas pela aplicação.
+type Claims struct {
+	Issuer        string
+	Subject       string
+	Audience      []string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+	Nonce         string
+	IssuedAt      time.Time
+	ExpiresAt     time.Time
+}
+
+// Verifier valida ID tokens RS256 contra as chaves publicadas em um endpoint JWKS,
+// mantendo as chaves em cache e recarregando-as quando surge um kid desconhecido.
+type Verifier struct {
+	jwksURL    string
+	issuers    []string
+	audiences  []string
+	httpClient *http.Client
+	cacheTTL   time.Duration
+	leeway     time.Duration
+	now        func() time.Time
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	expiresAt   time.Time
+	lastRefresh time.Time
+}
+
+type Option func(*Verifier)
+
+func WithHTTPClient(client *http.Client) Option {
+	return func(v *Verifier) {
+		if client != nil {
+			v.httpClient = client
+		}
+	}
+}
+
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(v *Verifier) {
+		if ttl > 0 {
+			v.cacheTTL = ttl
+		}
+	}
+}
+
+func WithLeeway(leeway time.Duration) Option {
+	return func(v *Verifier) {
+		if leeway >= 0 {
+			v.leeway = leeway
+		}
+	}
+}
+
+func WithClock(now func() time.Time) Option {
+	return func(v *Verifier) {
+		if now != nil {
+			v.now = now
+		}
+	}
+}
+
+// NewVerifier cria um verificador que aceita tokens de qualquer um dos emissores e
+// destinados a qualquer uma das audiências (client IDs) informadas.
+func NewVerifier(jwksURL string, issuers, audiences []string, opts ...Option) *Verifier {
+	verifier := &Verifier{
+		jwksURL:    jwksURL,
+		issuers:    issuers,
+		audiences:  audiences,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		cacheTTL:   defaultCacheTTL,
+		leeway:     defaultLeeway,
+		now:        time.Now,
+		keys:       map[string]*rsa.PublicKey{},
+	}
+	for _, opt := range opts {
+		opt(verifier)
+	}
+	return verifier
+}
+
+type tokenHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+type tokenPayload struct {
+	Issuer        string          `json:"iss"`
+	Subject       string          `json:"sub"`
+	Audience      json.RawMessage `json:"aud"`
+	Email         string          `json:"email"`
+	EmailVerified json.RawMessage `json:"email_verified"`
+	Name          string          `json:"name"`
+	Picture       string          `json:"picture"`
+	Nonce         string          `json:"nonce"`
+	IssuedAt      int64           `json:"iat"`
+	ExpiresAt     int64           `json:"exp"`
+}
+
+// Verify confere assinatura, emissor, audiência e validade do token e devolve suas claims.
+func (v *Verifier) Verify(ctx context.Context, rawToken string) (*Claims, error) {
+	parts := strings.Split(strings.TrimSpace(rawToken), ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if header.Algorithm != "RS256" {
+		return nil, fmt.Errorf("%w: algoritmo %q não suportado", ErrInvalidToken, header.Algorithm)
+	}
+
+	key, err := v.key(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("%w: assinatura inválida", ErrInvalidToken)
+	}
+
+	var payload tokenPayload
+	if err := decodeSegment(parts[1], &payload); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	claims := &Claims{
+		Issuer:        payload.Issuer,
+		Subject:       payload.Subject,
+		Audience:      parseAudience(payload.Audience),
+		Email:         payload.Email,
+		EmailVerified: parseBoolClaim(payload.EmailVerified),
+		Name:          payload.Name,
+		Picture:       payload.Picture,
+		Nonce:         payload.Nonce,
+		IssuedAt:      time.Unix(payload.IssuedAt, 0),
+		ExpiresAt:     time.Unix(payload.ExpiresAt, 0),
+	}
+
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: sub ausente", ErrInvalidToken)
+	}
+	if !contains(v.issuers, claims.Issuer) {
+		return nil, fmt.Errorf("%w: emissor %q não aceito", ErrInvalidToken, claims.Issuer)
+	}
+	if !containsAny(v.audiences, claims.Audience) {
+		return nil, fmt.Errorf("%w: audiência não aceita", ErrInvalidToken)
+	}
+
+	now := v.now()
+	if payload.ExpiresAt == 0 || now.After(claims.ExpiresAt.Add(v.leeway)) {
+		return nil, ErrExpiredToken
+	}
+	if payload.IssuedAt != 0 && claims.IssuedAt.After(now.Add(v.leeway)) {
+		return nil, fmt.Errorf("%w: emitido no futuro", ErrInvalidToken)
+	}
+
+	return claims, nil
+}
+
+func (v *Verifier) key(ctx context.Context, keyID string) (*rsa.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.now()
+	if key, ok := v.keys[keyID]; ok && now.Before(v.expiresAt) {
+		return key, nil
+	}
+
+	// kid desconhecido pode indicar rotação de chaves; o recarregamento é limitado para não sobrecarregar o provedor
+	if now.Before(v.expiresAt) && now.Sub(v.lastRefresh) < minRefreshInterval {
+		return nil, fmt.Errorf("%w: chave %q desconhecida", ErrInvalidToken, keyID)
+	}
+
+	if err := v.refresh(ctx, now); err != nil {
+		if key, ok := v.keys[keyID]; ok {
+			// mantém as chaves anteriores se o provedor estiver indisponível
+			return key, nil
+		}
+		return nil, err
+	}
+
+	key, ok := v.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: chave %q desconhecida", ErrInvalidToken, keyID)
+	}
+	return key, nil
+}
+
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+}
+
+func (v *Verifier) refresh(ctx context.Context, now time.Time) error {
+	v.lastRefresh = now
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.jwksURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro ao buscar jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("jwks retornou status %d", resp.StatusCode)
+	}
+
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		return fmt.Errorf("jwks inválido: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(document.Keys))
+	for _, jwk := range document.Keys {
+		if jwk.KeyType != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		key, err := parseRSAKey(jwk)
+		if err != nil {
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+
+	v.keys = keys
+	v.expiresAt = now.Add(cacheDuration(resp.Header.Get("Cache-Control"), v.cacheTTL))
+	return nil
+}
+
+func parseRSAKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, err
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, err
+	}
+	exponent := new(big.Int).SetBytes(eBytes)
+	if !exponent.IsInt64() || exponent.Int64() < 3 {
+		return nil, errors.New("expoente rsa inválido")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(nBytes), E: int(exponent.Int64())}, nil
+}
+
+// cacheDuration respeita o max-age informado pelo provedor, usando o padrão quando ausente.
+func cacheDuration(cacheControl string, fallback time.Duration) time.Duration {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		directive = strings.TrimSpace(directive)
+		if !strings.HasPrefix(directive, "max-age=") {
+			continue
+		}
+		if seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age=")); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return fallback
+}
+
+func decodeSegment(segment string, dest interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, dest)
+}
+
+// parseAudience aceita aud como string única ou lista, conforme a especificação.
+func parseAudience(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return []string{single}
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list
+	}
+	return nil
+}
+
+// parseBoolClaim aceita booleanos e strings "true", formato usado por alguns provedores.
+func parseBoolClaim(raw json.RawMessage) bool {
+	if len(raw) == 0 {
+		return false
+	}
+	var value bool
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return strings.EqualFold(text, "true")
+	}
+	return false
+}
+
+func contains(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(allowed, values []string) bool {
+	for _, value := range values {
+		if contains(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,298 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testIssuer   = "https://accounts.example.com"
+	testAudience = "client-123"
+)
+
+// jwksServer publica as chaves públicas informadas e conta quantas vezes o JWKS foi buscado.
+type jwksServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	keys    map[string]*rsa.PrivateKey
+	fetches int
+}
+
+func newJWKSServer(t *testing.T, keys map[string]*rsa.PrivateKey) *jwksServer {
+	t.Helper()
+	server := &jwksServer{keys: keys}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		server.fetches++
+
+		document := struct {
+			Keys []jsonWebKey `json:"keys"`
+		}{}
+		for kid, key := range server.keys {
+			document.Keys = append(document.Keys, jsonWebKey{
+				KeyType: "RSA",
+				KeyID:   kid,
+				Use:     "sig",
+				N:       base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(document)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (s *jwksServer) rotate(keys map[string]*rsa.PrivateKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func (s *jwksServer) fetchCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetches
+}
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("erro gerando chave rsa: %v", err)
+	}
+	return key
+}
+
+func encodeSegment(t *testing.T, value interface{}) string {
+	t.Helper()
+	raw, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("erro codificando segmento: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	t.Helper()
+	unsigned := encodeSegment(t, map[string]string{"alg": "RS256", "kid": kid, "typ": "JWT"}) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("erro assinando token: %v", err)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims(now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"iss":            testIssuer,
+		"sub":            "user-42",
+		"aud":            testAudience,
+		"email":          "ana@example.com",
+		"email_verified": true,
+		"name":           "Ana",
+		"iat":            now.Add(-time.Minute).Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+	}
+}
+
+func TestVerifierAcceptsValidToken(t *testing.T) {
+	key := generateKey(t)
+	server := newJWKSServer(t, map[string]*rsa.PrivateKey{"k1": key})
+	now := time.Unix(1700000000, 0)
+	verifier := NewVerifier(server.URL, []string{testIssuer}, []string{testAudience}, WithClock(func() time.Time { return now }))
+
+	claims := validClaims(now)
+	claims["aud"] = []string{"outro-cliente", testAudience}
+	claims["email_verified"] = "true"
+
+	got, err := verifier.Verify(context.Background(), signRS256(t, key, "k1", claims))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if got.Subject != "user-42" || got.Email != "ana@example.com" || !got.EmailVerified || got.Issuer != testIssuer {
+		t.Fatalf("claims inesperadas: %+v", got)
+	}
+	if !got.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("ExpiresAt = %v", got.ExpiresAt)
+	}
+
+	if _, err := verifier.Verify(context.Background(), signRS256(t, key, "k1", validClaims(now))); err != nil {
+		t.Fatalf("segunda verificação: %v", err)
+	}
+	if fetches := server.fetchCount(); fetches != 1 {
+		t.Fatalf("JWKS buscado %d vezes, esperado 1 (cache)", fetches)
+	}
+}
+
+func TestVerifierRejectsClaims(t *testing.T) {
+	key := generateKey(t)
+	server := newJWKSServer(t, map[string]*rsa.PrivateKey{"k1": key})
+	now := time.Unix(1700000000, 0)
+	verifier := NewVerifier(server.URL, []string{testIssuer}, []string{testAudience}, WithClock(func() time.Time { return now }))
+
+	tests := []struct {
+		name   string
+		mutate func(claims map[string]interface{})
+		want   error
+	}{
+		{"emissor errado", func(c map[string]interface{}) { c["iss"] = "https://evil.example.com" }, ErrInvalidToken},
+		{"audiência errada", func(c map[string]interface{}) { c["aud"] = "outro-cliente" }, ErrInvalidToken},
+		{"audiência ausente", func(c map[string]interface{}) { delete(c, "aud") }, ErrInvalidToken},
+		{"sem sub", func(c map[string]interface{}) { delete(c, "sub") }, ErrInvalidToken},
+		{"expirado além da tolerância", func(c map[string]interface{}) { c["exp"] = now.Add(-2 * time.Minute).Unix() }, ErrExpiredToken},
+		{"sem exp", func(c map[string]interface{}) { delete(c, "exp") }, ErrExpiredToken},
+		{"emitido no futuro", func(c map[string]interface{}) { c["iat"] = now.Add(5 * time.Minute).Unix() }, ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims(now)
+			tt.mutate(claims)
+			if _, err := verifier.Verify(context.Background(), signRS256(t, key, "k1", claims)); !errors.Is(err, tt.want) {
+				t.Fatalf("erro = %v, esperado %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifierExpiryLeeway(t *testing.T) {
+	key := generateKey(t)
+	server := newJWKSServer(t, map[string]*rsa.PrivateKey{"k1": key})
+	issued := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		leeway  time.Duration
+		want    error
+	}{
+		{"dentro da validade", 59 * time.Minute, time.Minute, nil},
+		{"expirado dentro da tolerância padrão", time.Hour + 30*time.Second, time.Minute, nil},
+		{"expirado além da tolerância padrão", time.Hour + 61*time.Second, time.Minute, ErrExpiredToken},
+		{"sem tolerância", time.Hour + time.Second, 0, ErrExpiredToken},
+		{"tolerância maior", time.Hour + 4*time.Minute, 5 * time.Minute, nil},
+	}
+
+	token := signRS256(t, key, "k1", validClaims(issued))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := issued.Add(tt.elapsed)
+			verifier := NewVerifier(server.URL, []string{testIssuer}, []string{testAudience},
+				WithLeeway(tt.leeway), WithClock(func() time.Time { return now }))
+			if _, err := verifier.Verify(context.Background(), token); !errors.Is(err, tt.want) {
+				t.Fatalf("erro = %v, esperado %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifierRejectsUnsupportedAlgorithms(t *testing.T) {
+	key := generateKey(t)
+	server := newJWKSServer(t, map[string]*rsa.PrivateKey{"k1": key})
+	now := time.Unix(1700000000, 0)
+	verifier := NewVerifier(server.URL, []string{testIssuer}, []string{testAudience}, WithClock(func() time.Time { return now }))
+	payload := encodeSegment(t, validClaims(now))
+
+	none := encodeSegment(t, map[string]string{"alg": "none", "kid": "k1"}) + "." + payload + "."
+
+	// HS256 assinado com o módulo público da chave RSA: o ataque clássico de confusão de algoritmo.
+	hsUnsigned := encodeSegment(t, map[string]string{"alg": "HS256", "kid": "k1"}) + "." + payload
+	mac := hmac.New(sha256.New, key.N.Bytes())
+	mac.Write([]byte(hsUnsigned))
+	hs256 := hsUnsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+	// Cabeçalho trocado para "none" mantendo uma assinatura RS256 válida de outro cabeçalho.
+	valid := signRS256(t, key, "k1", validClaims(now))
+	validParts := strings.Split(valid, ".")
+	stripped := encodeSegment(t, map[string]string{"alg": "none", "kid": "k1"}) + "." + validParts[1] + "." + validParts[2]
+
+	tampered := valid[:len(valid)-4] + "AAAA"
+
+	tests := map[string]string{
+		"none":              none,
+		"HS256":             hs256,
+		"cabeçalho trocado": stripped,
+		"assinatura":        tampered,
+		"malformado":        "abc.def",
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := verifier.Verify(context.Background(), token); !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("erro = %v, esperado ErrInvalidToken", err)
+			}
+		})
+	}
+}
+
+func TestVerifierRefreshesOnKeyRotation(t *testing.T) {
+	oldKey, newKey := generateKey(t), generateKey(t)
+	server := newJWKSServer(t, map[string]*rsa.PrivateKey{"k1": oldKey})
+	now := time.Unix(1700000000, 0)
+	verifier := NewVerifier(server.URL, []string{testIssuer}, []string{testAudience}, WithClock(func() time.Time { return now }))
+
+	if _, err := verifier.Verify(context.Background(), signRS256(t, oldKey, "k1", validClaims(now))); err != nil {
+		t.Fatalf("token com a chave antiga: %v", err)
+	}
+
+	server.rotate(map[string]*rsa.PrivateKey{"k2": newKey})
+	rotated := signRS256(t, newKey, "k2", validClaims(now))
+
+	// Logo após a última busca o kid desconhecido é recusado sem consultar o provedor de novo.
+	if _, err := verifier.Verify(context.Background(), rotated); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("erro = %v, esperado ErrInvalidToken dentro do intervalo mínimo", err)
+	}
+	if fetches := server.fetchCount(); fetches != 1 {
+		t.Fatalf("JWKS buscado %d vezes, esperado 1", fetches)
+	}
+
+	now = now.Add(minRefreshInterval + time.Second)
+	got, err := verifier.Verify(context.Background(), rotated)
+	if err != nil {
+		t.Fatalf("token com a chave nova após a rotação: %v", err)
+	}
+	if got.Subject != "user-42" {
+		t.Fatalf("claims inesperadas: %+v", got)
+	}
+	if fetches := server.fetchCount(); fetches != 2 {
+		t.Fatalf("JWKS buscado %d vezes, esperado 2", fetches)
+	}
+
+	if _, err := verifier.Verify(context.Background(), signRS256(t, oldKey, "k1", validClaims(now))); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("chave retirada: erro = %v, esperado ErrInvalidToken", err)
+	}
+}
+
+func TestCacheDuration(t *testing.T) {
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{"", time.Hour},
+		{"public, max-age=19800, must-revalidate", 19800 * time.Second},
+		{"max-age=0", time.Hour},
+		{"max-age=abc", time.Hour},
+	}
+	for _, tt := range tests {
+		if got := cacheDuration(tt.header, time.Hour); got != tt.want {
+			t.Errorf("cacheDuration(%q) = %v, esperado %v", tt.header, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const defaultLocalDir = "./tmp/blobs"
+
+// ErrNotFound indica que não há arquivo armazenado com a chave informada.
+var ErrNotFound = errors.New("arquivo não encontrado")
+
+// BlobStore guarda arquivos binários identificados por chaves relativas com segmentos separados por "/",
+// como "receipts/<usuário>/<arquivo>.jpg".
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader) error
+	// Get abre o arquivo e informa seu tamanho em bytes; quem chama deve fechar o leitor.
+	Get(ctx context.Context, key string) (io.ReadCloser, int64, error)
+	// Delete remove o arquivo; chaves inexistentes não são erro.
+	Delete(ctx context.Context, key string) error
+}
+
+// NewFromEnv escolhe a implementação a partir de BLOB_STORE_DRIVER (local ou memory).
+func NewFromEnv() (BlobStore, error) {
+	switch strings.ToLower(os.Getenv("BLOB_STORE_DRIVER")) {
+	case "local", "":
+		dir := os.Getenv("BLOB_STORE_DIR")
+		if dir == "" {
+			dir = defaultLocalDir
+		}
+		return NewLocalStore(dir)
+	case "memory":
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("driver de armazenamento desconhecido: %s", os.Getenv("BLOB_STORE_DRIVER"))
+	}
+}
+
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || path.Clean(key) != key {
+		return fmt.Errorf("chave de arquivo inválida: %q", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == ".." || segment == "." {
+			return fmt.Errorf("chave de arquivo inválida: %q", key)
+		}
+	}
+	return nil
+}
+
+// LocalStore grava os arquivos em um diretório do sistema de arquivos local.
+type LocalStore struct {
+	dir string
+}
+
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("erro criando diretório de arquivos: %w", err)
+	}
+	return &LocalStore{dir: dir}, nil
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// Put grava em um arquivo temporário e o renomeia no fim, para que leituras nunca vejam um arquivo incompleto.
+func (s *LocalStore) Put(ctx context.Context, key string, body io.Reader) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+		return fmt.Errorf("erro criando diretório de arquivos: %w", err)
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("erro gravando arquivo: %w", err)
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := io.Copy(temp, body); err != nil {
+		temp.Close()
+		return fmt.Errorf("erro gravando arquivo: %w", err)
+	}
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("erro gravando arquivo: %w", err)
+	}
+	if err := os.Rename(temp.Name(), target); err != nil {
+		return fmt.Errorf("erro gravando arquivo: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return nil, 0, err
+	}
+	file, err := os.Open(target)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, 0, ErrNotFound
+		}
+		return nil, 0, fmt.Errorf("erro lendo arquivo: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, fmt.Errorf("erro lendo arquivo: %w", err)
+	}
+	return file, info.Size(), nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("erro removendo arquivo: %w", err)
+	}
+	return nil
+}
+
+// MemoryStore mantém os arquivos em memória, útil em testes. Os arquivos se perdem quando o processo termina.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	blobs map[string][]byte
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{blobs: map[string][]byte{}}
+}
+
+func (s *MemoryStore) Put(ctx context.Context, key string, body io.Reader) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("erro gravando arquivo: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[key] = data
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	if err := validateKey(key); err != nil {
+		return nil, 0, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, 0, ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), int64(len(data)), nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.blobs, key)
+	return nil
+}
+
+// Keys lista as chaves armazenadas, para inspeção em testes.
+func (s *MemoryStore) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := make([]string, 0, len(s.blobs))
+	for key := range s.blobs {
+		keys = append(keys, key)
+	}
+	return keys
+}
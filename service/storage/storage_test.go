@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateKey(t *testing.T) {
+	tests := []struct {
+		key   string
+		valid bool
+	}{
+		{"receipts/user/file.jpg", true},
+		{"file.png", true},
+		{"receipts/user/..hidden.jpg", true},
+		{"", false},
+		{"/etc/passwd", false},
+		{"../segredo", false},
+		{"receipts/../../segredo", false},
+		{"receipts/..", false},
+		{"receipts/./file.jpg", false},
+		{".", false},
+		{"receipts//file.jpg", false},
+		{"receipts/file.jpg/", false},
+		{"receipts\\file.jpg", false},
+		{"..\\segredo", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			err := validateKey(tt.key)
+			if tt.valid && err != nil {
+				t.Fatalf("validateKey(%q) = %v, esperado válida", tt.key, err)
+			}
+			if !tt.valid && err == nil {
+				t.Fatalf("validateKey(%q) deveria ser recusada", tt.key)
+			}
+		})
+	}
+}
+
+func TestStoresRoundTrip(t *testing.T) {
+	local, err := NewLocalStore(filepath.Join(t.TempDir(), "blobs"))
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+
+	stores := map[string]BlobStore{"local": local, "memory": NewMemoryStore()}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			key := "receipts/user-1/recibo.jpg"
+
+			if _, _, err := store.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+				t.Fatalf("Get antes do Put = %v, esperado ErrNotFound", err)
+			}
+
+			if err := store.Put(ctx, key, strings.NewReader("primeira versão")); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+			if err := store.Put(ctx, key, strings.NewReader("conteúdo da imagem")); err != nil {
+				t.Fatalf("Put sobrescrevendo: %v", err)
+			}
+
+			reader, size, err := store.Get(ctx, key)
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			content, err := io.ReadAll(reader)
+			reader.Close()
+			if err != nil {
+				t.Fatalf("erro lendo conteúdo: %v", err)
+			}
+			if string(content) != "conteúdo da imagem" || size != int64(len(content)) {
+				t.Fatalf("Get = %q (%d bytes), esperado o último conteúdo gravado", content, size)
+			}
+
+			if err := store.Delete(ctx, key); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+			if _, _, err := store.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+				t.Fatalf("Get após Delete = %v, esperado ErrNotFound", err)
+			}
+			if err := store.Delete(ctx, key); err != nil {
+				t.Fatalf("Delete de chave inexistente deveria ser aceito: %v", err)
+			}
+
+			for _, invalid := range []string{"../fora.jpg", "/abs.jpg", "a\\b.jpg"} {
+				if err := store.Put(ctx, invalid, strings.NewReader("x")); err == nil {
+					t.Errorf("Put(%q) deveria ser recusado", invalid)
+				}
+				if _, _, err := store.Get(ctx, invalid); err == nil || errors.Is(err, ErrNotFound) {
+					t.Errorf("Get(%q) = %v, esperado erro de chave inválida", invalid, err)
+				}
+				if err := store.Delete(ctx, invalid); err == nil {
+					t.Errorf("Delete(%q) deveria ser recusado", invalid)
+				}
+			}
+		})
+	}
+}
+
+func TestLocalStoreStaysInsideDirectory(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewLocalStore(filepath.Join(root, "blobs"))
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "../fora.txt", strings.NewReader("x")); err == nil {
+		t.Fatal("Put fora do diretório deveria ser recusado")
+	}
+	if _, err := os.Stat(filepath.Join(root, "fora.txt")); !os.IsNotExist(err) {
+		t.Fatal("nenhum arquivo deveria ser criado fora do diretório")
+	}
+
+	if err := store.Put(ctx, "receipts/a/b.txt", strings.NewReader("ok")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(root, "blobs", "receipts", "a"))
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "b.txt" {
+		t.Fatalf("arquivos no diretório = %v, esperado apenas b.txt sem temporários", entries)
+	}
+}
+
+func TestLocalStorePutHonoursCancelledContext(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := store.Put(ctx, "receipts/x.jpg", strings.NewReader("x")); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Put com contexto cancelado = %v, esperado context.Canceled", err)
+	}
+	if _, _, err := store.Get(context.Background(), "receipts/x.jpg"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get = %v, esperado ErrNotFound", err)
+	}
+}
+
+func TestNewFromEnv(t *testing.T) {
+	tests := []struct {
+		driver  string
+		want    string
+		wantErr bool
+	}{
+		{"", "*storage.LocalStore", false},
+		{"local", "*storage.LocalStore", false},
+		{"memory", "*storage.MemoryStore", false},
+		{"s3", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			t.Setenv("BLOB_STORE_DRIVER", tt.driver)
+			t.Setenv("BLOB_STORE_DIR", t.TempDir())
+			store, err := NewFromEnv()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("esperava erro, obteve %T", store)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewFromEnv: %v", err)
+			}
+			if got := fmt.Sprintf("%T", store); got != tt.want {
+				t.Fatalf("tipo = %s, esperado %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret é a chave ASCII "12345678901234567890" dos vetores SHA1 do apêndice B da RFC 6238.
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCodeAtRFC6238Vectors(t *testing.T) {
+	// A RFC publica códigos de oito dígitos; com seis dígitos valem os seis últimos.
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		at := time.Unix(tt.unix, 0)
+		got, err := CodeAt(rfcSecret, Step(at))
+		if err != nil {
+			t.Fatalf("CodeAt(%d): %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("CodeAt(%d) = %s, esperado %s", tt.unix, got, tt.want)
+		}
+
+		step, ok := Validate(rfcSecret, tt.want, at, 0)
+		if !ok || step != Step(at) {
+			t.Errorf("Validate(%d) = %d, %v; esperado %d, true", tt.unix, step, ok, Step(at))
+		}
+	}
+}
+
+func TestValidateSkew(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := Step(now)
+
+	tests := []struct {
+		name   string
+		offset int64
+		skew   int
+		ok     bool
+	}{
+		{"janela atual sem tolerância", 0, 0, true},
+		{"janela anterior sem tolerância", -1, 0, false},
+		{"janela anterior com tolerância", -1, 1, true},
+		{"janela seguinte com tolerância", 1, 1, true},
+		{"duas janelas atrás com tolerância um", -2, 1, false},
+		{"duas janelas à frente com tolerância um", 2, 1, false},
+		{"duas janelas atrás com tolerância dois", -2, 2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := CodeAt(rfcSecret, current+tt.offset)
+			if err != nil {
+				t.Fatalf("CodeAt: %v", err)
+			}
+			step, ok := Validate(rfcSecret, code, now, tt.skew)
+			if ok != tt.ok {
+				t.Fatalf("Validate = %v, esperado %v", ok, tt.ok)
+			}
+			if ok && step != current+tt.offset {
+				t.Fatalf("janela = %d, esperado %d", step, current+tt.offset)
+			}
+		})
+	}
+}
+
+func TestValidateReturnsStepForReplayCheck(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	code, err := CodeAt(rfcSecret, Step(now))
+	if err != nil {
+		t.Fatalf("CodeAt: %v", err)
+	}
+
+	// O mesmo código continua válido dentro da tolerância; o chamador recusa o reuso comparando a janela
+	// devolvida com a última aceita.
+	first, ok := Validate(rfcSecret, code, now, 1)
+	if !ok {
+		t.Fatal("primeiro uso deveria ser aceito")
+	}
+	second, ok := Validate(rfcSecret, code, now.Add(Period), 1)
+	if !ok {
+		t.Fatal("o código ainda está dentro da tolerância")
+	}
+	if second != first {
+		t.Fatalf("reuso devolveu a janela %d, esperado %d", second, first)
+	}
+	if _, ok := Validate(rfcSecret, code, now.Add(2*Period), 1); ok {
+		t.Fatal("código fora da tolerância deveria ser recusado")
+	}
+}
+
+func TestValidateRejectsMalformedInput(t *testing.T) {
+	now := time.Unix(59, 0)
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+	}{
+		{"código curto", rfcSecret, "28708"},
+		{"código longo", rfcSecret, "94287082"},
+		{"código errado", rfcSecret, "287083"},
+		{"segredo inválido", "não-é-base32!", "287082"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := Validate(tt.secret, tt.code, now, 1); ok {
+				t.Fatal("deveria ser recusado")
+			}
+		})
+	}
+
+	// Espaços, minúsculas e padding são tolerados no segredo, como digitado a partir de um app autenticador.
+	relaxed := strings.ToLower(rfcSecret[:8] + " " + rfcSecret[8:])
+	if _, ok := Validate(relaxed, " 287082 ", now, 0); !ok {
+		t.Fatal("segredo e código com espaços deveriam ser aceitos")
+	}
+}
+
+func TestGenerateSecretAndURI(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+	if len(secret) != 32 || strings.Contains(secret, "=") {
+		t.Fatalf("segredo inesperado: %q", secret)
+	}
+	if _, err := CodeAt(secret, 1); err != nil {
+		t.Fatalf("segredo gerado deveria ser decodificável: %v", err)
+	}
+
+	uri := URI("Golang Finance", "ana@example.com", secret)
+	for _, want := range []string{"otpauth://totp/Golang%20Finance:ana@example.com?", "secret=" + secret, "digits=6", "period=30", "algorithm=SHA1"} {
+		if !strings.Contains(uri, want) {
+			t.Errorf("URI sem %q: %s", want, uri)
+		}
+	}
+}